@@ -17,8 +17,12 @@ import (
 	"github.com/BachirKhiati/lexia/internal/services/ai"
 	"github.com/BachirKhiati/lexia/internal/services/auth"
 	"github.com/BachirKhiati/lexia/internal/services/language"
+	"github.com/BachirKhiati/lexia/internal/services/orator"
 	"github.com/BachirKhiati/lexia/internal/services/scraper"
 	"github.com/BachirKhiati/lexia/internal/services/srs"
+	"github.com/BachirKhiati/lexia/internal/services/translation"
+	"github.com/BachirKhiati/lexia/internal/services/tts"
+	"github.com/BachirKhiati/lexia/internal/services/tutor"
 	"github.com/BachirKhiati/lexia/internal/services/wiktionary"
 
 	_ "github.com/BachirKhiati/lexia/docs" // Import generated docs
@@ -72,6 +76,18 @@ import (
 // @tag.name SRS
 // @tag.description Spaced Repetition System for word memorization
 
+// @tag.name Tutor
+// @tag.description Multi-turn Socratic tutor conversations
+
+// @tag.name Translation
+// @tag.description Translations kept in a per-user translation memory
+
+// @tag.name Orator
+// @tag.description Pronunciation scoring for the speaking coach
+
+// @tag.name Admin
+// @tag.description Operational endpoints restricted to administrators
+
 func main() {
 	// Load configuration
 	cfg := config.Load()
@@ -88,15 +104,19 @@ func main() {
 		log.Fatalf("Failed to initialize schema: %v", err)
 	}
 
-	// Initialize AI service (Claude + Gemini)
-	aiService, err := ai.NewService(
-		cfg.AI.ClaudeAPIKey,
-		cfg.AI.GeminiAPIKey,
-		cfg.AI.DefaultProvider,
-	)
+	// Initialize AI service (Claude + Gemini + OpenAI-compatible)
+	aiService, err := ai.NewService(cfg.AI)
 	if err != nil {
 		log.Fatalf("Failed to initialize AI service: %v", err)
 	}
+	if cfg.AI.CacheEnabled {
+		aiService.SetCache(ai.NewPostgresCache(db.DB), cfg.AI.CacheTTL)
+	}
+	aiService.SetUsageTracking(ai.NewPostgresUsageStore(db.DB), ai.Quotas{
+		DailyTokens:   cfg.AI.DailyTokenQuota,
+		MonthlyTokens: cfg.AI.MonthlyTokenQuota,
+		MonthlyBudget: cfg.AI.MonthlyBudgetUSD,
+	})
 
 	// Initialize auth service
 	authService := auth.NewService(cfg.Auth.JWTSecret, cfg.Auth.JWTIssuer)
@@ -104,8 +124,26 @@ func main() {
 	// Initialize wiktionary service
 	wiktionaryService := wiktionary.NewService()
 
-	// Initialize language service (with AI fallback)
+	// Initialize the supported languages and the language service (with AI fallback)
+	languages, err := language.NewRegistry(cfg.Language)
+	if err != nil {
+		log.Fatalf("Failed to initialize languages: %v", err)
+	}
 	langService := language.NewService(wiktionaryService, aiService)
+	langService.SetLanguages(languages)
+
+	// Initialize text-to-speech (optional: the Analyzer works without audio)
+	var ttsService *tts.Service
+	if engine, err := tts.NewEngine(cfg.TTS); err != nil {
+		log.Printf("⚠️  Text-to-speech disabled: %v", err)
+	} else if engine != nil {
+		store, err := tts.NewDiskStore(cfg.TTS.CacheDir)
+		if err != nil {
+			log.Fatalf("Failed to initialize audio cache: %v", err)
+		}
+		ttsService = tts.NewService(engine, store)
+		langService.SetSpeaker(ttsService)
+	}
 
 	// Initialize scraper service
 	scraperService := scraper.NewService()
@@ -113,17 +151,33 @@ func main() {
 	// Initialize SRS service
 	srsService := srs.NewService()
 
+	// Initialize tutor service (Socratic conversations)
+	tutorService := tutor.NewService(tutor.NewPostgresStore(db.DB), aiService)
+
+	// Initialize translation service (per-user translation memory)
+	translationService := translation.NewService(translation.NewPostgresStore(db.DB), aiService)
+
+	// Initialize Orator service (pronunciation scoring, feeds SRS)
+	oratorService := orator.NewService(orator.NewPostgresStore(db.DB), srsService)
+
 	// Initialize handlers
-	authHandler := handlers.NewAuthHandler(db, authService)
+	authHandler := handlers.NewAuthHandler(db, authService, languages)
 	analyzerHandler := handlers.NewAnalyzerHandler(aiService, langService)
-	questHandler := handlers.NewQuestHandler(db, aiService)
-	synapseHandler := handlers.NewSynapseHandler(db)
-	lensHandler := handlers.NewLensHandler(db, scraperService)
+	questHandler := handlers.NewQuestHandler(db, aiService, languages)
+	synapseHandler := handlers.NewSynapseHandler(db, languages)
+	lensHandler := handlers.NewLensHandler(db, scraperService, languages)
 	userHandler := handlers.NewUserHandler(db)
 	srsHandler := handlers.NewSRSHandler(db, srsService)
 	analyticsHandler := handlers.NewAnalyticsHandler(db)
-	healthHandler := handlers.NewHealthHandler(db)
+	healthHandler := handlers.NewHealthHandler(db, aiService)
 	exportHandler := handlers.NewExportHandler(db)
+	adminHandler := handlers.NewAdminHandler(aiService)
+	usageHandler := handlers.NewUsageHandler(aiService)
+	tutorHandler := handlers.NewTutorHandler(tutorService, languages)
+	grammarHandler := handlers.NewGrammarHandler(aiService, languages)
+	audioHandler := handlers.NewAudioHandler(ttsService)
+	oratorHandler := handlers.NewOratorHandler(oratorService, languages)
+	translationHandler := handlers.NewTranslationHandler(translationService, languages)
 
 	// Setup router
 	r := chi.NewRouter()
@@ -167,6 +221,12 @@ func main() {
 			r.Post("/auth/login", authHandler.Login)
 		})
 
+		// Spoken audio, public so it can be the source of an audio element
+		r.Group(func(r chi.Router) {
+			r.Use(standardLimit.Limit)
+			r.Get("/audio/{hash}", audioHandler.GetAudio)
+		})
+
 		// Protected routes (authentication required)
 		r.Group(func(r chi.Router) {
 			r.Use(middleware.Auth(authService))
@@ -178,12 +238,24 @@ func main() {
 
 			// The Analyzer - Universal word analysis
 			r.Post("/analyze", analyzerHandler.AnalyzeWord)
+			r.Post("/analyze/batch", analyzerHandler.AnalyzeBatch)
+			r.Post("/grammar/check", grammarHandler.CheckGrammar)
+
+			// Translation with a per-user translation memory
+			r.Post("/translate", translationHandler.Translate)
+			r.Route("/translations", func(r chi.Router) {
+				r.Get("/", translationHandler.SearchTranslations)
+				r.Get("/{translationID}", translationHandler.GetTranslation)
+				r.Put("/{translationID}", translationHandler.UpdateTranslation)
+				r.Delete("/{translationID}", translationHandler.DeleteTranslation)
+			})
 
 			// The Scribe - Quest system
 			r.Route("/users/{userID}/quests", func(r chi.Router) {
 				r.Get("/", questHandler.GetUserQuests)
 				r.Post("/generate", questHandler.GenerateQuest)
 				r.Post("/validate", questHandler.ValidateQuest)
+				r.Post("/validate/stream", questHandler.ValidateQuestStream)
 			})
 
 			// The Synapse - Mind map
@@ -212,6 +284,17 @@ func main() {
 				r.Get("/challenging-words", analyticsHandler.GetChallengingWords)
 			})
 
+			// Socratic tutor conversations
+			r.Route("/tutor/sessions", func(r chi.Router) {
+				r.Get("/", tutorHandler.ListSessions)
+				r.Post("/", tutorHandler.CreateSession)
+				r.Get("/{sessionID}", tutorHandler.GetSession)
+				r.Post("/{sessionID}/messages", tutorHandler.SendMessage)
+			})
+
+			// AI usage and remaining quota for the current user
+			r.Get("/ai/usage", usageHandler.GetUsage)
+
 			// System Statistics (protected - requires authentication)
 			r.Get("/system/stats", healthHandler.Stats)
 
@@ -221,7 +304,17 @@ func main() {
 			r.Post("/import/csv", exportHandler.ImportCSV)
 			r.Post("/import/json", exportHandler.ImportJSON)
 
-			// The Orator - Speaking coach (completed)
+			// The Orator - Speaking coach
+			r.Get("/orator/attempts", oratorHandler.ListAttempts)
+			r.Post("/orator/attempts", oratorHandler.SubmitAttempt)
+
+			// Administration (restricted to ADMIN_EMAILS)
+			r.Route("/admin", func(r chi.Router) {
+				r.Use(middleware.RequireAdmin(cfg.Auth.AdminEmails))
+				r.Get("/ai-cache", adminHandler.GetAICacheStats)
+				r.Post("/ai-cache/invalidate", adminHandler.InvalidateAICache)
+				r.Get("/prompts", adminHandler.GetPrompts)
+			})
 		})
 	})
 
@@ -3,7 +3,9 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -16,6 +18,7 @@ type Config struct {
 	Language LanguageConfig
 	CORS     CORSConfig
 	Auth     AuthConfig
+	TTS      TTSConfig
 }
 
 type ServerConfig struct {
@@ -41,7 +44,47 @@ type AIConfig struct {
 	ClaudeAPIKey       string
 	GeminiAPIKey       string
 	OpenAIAPIKey       string
+	OpenAIBaseURL      string // OpenAI-compatible server (Ollama, llama.cpp, vLLM)
+	OpenAIModel        string
+	ClaudeModel        string
+	GeminiModel        string
 	DefaultProvider    string
+	// TaskRoutes maps a task name to the providers to try, in order
+	TaskRoutes map[string][]string
+	// TaskModels tunes the model calls of each task
+	TaskModels map[string]TaskModelConfig
+	// Resilience: retries per provider, then failover to the next healthy one
+	MaxAttempts      int
+	RequestTimeout   time.Duration
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+	// Shared Postgres cache for definitions, translations and grammar checks
+	CacheEnabled bool
+	CacheTTL     time.Duration
+	// Per-user limits; 0 means unlimited
+	DailyTokenQuota   int64
+	MonthlyTokenQuota int64
+	MonthlyBudgetUSD  float64
+	// PromptsDir holds prompt templates that replace the embedded ones
+	PromptsDir string
+}
+
+// TaskModelConfig is the model, output length, temperature and timeout of one task
+type TaskModelConfig struct {
+	// Models maps a provider name to its model for the task; providers not
+	// listed use their default model (CLAUDE_MODEL, GEMINI_MODEL, OPENAI_MODEL)
+	Models      map[string]string
+	MaxTokens   int
+	Temperature float64
+	Timeout     time.Duration
+}
+
+// TTSConfig selects the text-to-speech engine and where clips are cached
+type TTSConfig struct {
+	Engine     string // "espeak", or "none" to turn speech off
+	CacheDir   string
+	EspeakPath string
+	SpeechRate int // words per minute; 0 keeps the engine default
 }
 
 type LanguageConfig struct {
@@ -54,8 +97,9 @@ type CORSConfig struct {
 }
 
 type AuthConfig struct {
-	JWTSecret string
-	JWTIssuer string
+	JWTSecret   string
+	JWTIssuer   string
+	AdminEmails []string // users allowed to call /admin endpoints
 }
 
 func Load() *Config {
@@ -64,6 +108,8 @@ func Load() *Config {
 		log.Println("No .env file found, using environment variables")
 	}
 
+	requestTimeout := time.Duration(getEnvInt("AI_REQUEST_TIMEOUT_SECONDS", 30)) * time.Second
+
 	return &Config{
 		Server: ServerConfig{
 			Port:        getEnv("PORT", "8080"),
@@ -85,18 +131,63 @@ func Load() *Config {
 			ClaudeAPIKey:    getEnv("CLAUDE_API_KEY", ""),
 			GeminiAPIKey:    getEnv("GEMINI_API_KEY", ""),
 			OpenAIAPIKey:    getEnv("OPENAI_API_KEY", ""),
+			OpenAIBaseURL:   getEnv("OPENAI_BASE_URL", ""),
+			OpenAIModel:     getEnv("OPENAI_MODEL", "gpt-4o-mini"),
+			ClaudeModel:     getEnv("CLAUDE_MODEL", "claude-3-5-sonnet-20241022"),
+			GeminiModel:     getEnv("GEMINI_MODEL", "gemini-2.0-flash-exp"),
 			DefaultProvider: getEnv("DEFAULT_AI_PROVIDER", "claude"),
+			TaskRoutes: map[string][]string{
+				// Claude is best for creative, Socratic teaching
+				"quest_generation":  getEnvList("AI_ROUTE_QUEST_GENERATION", "claude,gemini,openai"),
+				"quest_validation":  getEnvList("AI_ROUTE_QUEST_VALIDATION", "claude,gemini,openai"),
+				"socratic_feedback": getEnvList("AI_ROUTE_SOCRATIC_FEEDBACK", "claude,gemini,openai"),
+				"tutor":             getEnvList("AI_ROUTE_TUTOR", "claude,gemini,openai"),
+				// Gemini is faster and cheaper for lookups and structured analysis
+				"translation":     getEnvList("AI_ROUTE_TRANSLATION", "gemini,claude,openai"),
+				"grammar":         getEnvList("AI_ROUTE_GRAMMAR", "gemini,claude,openai"),
+				"definition":      getEnvList("AI_ROUTE_DEFINITION", "gemini,claude,openai"),
+				"sense_selection": getEnvList("AI_ROUTE_SENSE_SELECTION", "gemini,claude,openai"),
+			},
+			TaskModels: map[string]TaskModelConfig{
+				// Judging a learner's text in quest validation needs the strongest models
+				"quest_generation":  getTaskModel("quest_generation", "", 1024, 0.7, requestTimeout),
+				"quest_validation":  getTaskModel("quest_validation", "claude=claude-3-5-sonnet-20241022,gemini=gemini-1.5-pro", 1024, 0.3, requestTimeout),
+				"socratic_feedback": getTaskModel("socratic_feedback", "", 1024, 0.7, requestTimeout),
+				"tutor":             getTaskModel("tutor", "", 1024, 0.7, requestTimeout),
+				// Lookups are short and frequent: small models, low temperature
+				"translation":     getTaskModel("translation", "claude=claude-3-5-haiku-20241022", 1024, 0.3, requestTimeout),
+				"grammar":         getTaskModel("grammar", "", 1024, 0.2, requestTimeout),
+				"definition":      getTaskModel("definition", "claude=claude-3-5-haiku-20241022", 512, 0.2, requestTimeout),
+				"sense_selection": getTaskModel("sense_selection", "claude=claude-3-5-haiku-20241022", 64, 0, 15*time.Second),
+			},
+			MaxAttempts:       getEnvInt("AI_MAX_ATTEMPTS", 3),
+			RequestTimeout:    requestTimeout,
+			BreakerThreshold:  getEnvInt("AI_BREAKER_THRESHOLD", 5),
+			BreakerCooldown:   time.Duration(getEnvInt("AI_BREAKER_COOLDOWN_SECONDS", 30)) * time.Second,
+			CacheEnabled:      getEnv("AI_CACHE_ENABLED", "true") == "true",
+			CacheTTL:          time.Duration(getEnvInt("AI_CACHE_TTL_HOURS", 720)) * time.Hour,
+			DailyTokenQuota:   int64(getEnvInt("AI_DAILY_TOKEN_QUOTA", 50000)),
+			MonthlyTokenQuota: int64(getEnvInt("AI_MONTHLY_TOKEN_QUOTA", 1000000)),
+			MonthlyBudgetUSD:  getEnvFloat("AI_MONTHLY_BUDGET_USD", 5),
+			PromptsDir:        getEnv("AI_PROMPTS_DIR", ""),
 		},
 		Language: LanguageConfig{
 			DefaultLanguage:    getEnv("DEFAULT_LANGUAGE", "finnish"),
-			SupportedLanguages: strings.Split(getEnv("SUPPORTED_LANGUAGES", "finnish,english"), ","),
+			SupportedLanguages: strings.Split(getEnv("SUPPORTED_LANGUAGES", "finnish,swedish,english"), ","),
 		},
 		CORS: CORSConfig{
 			AllowedOrigins: strings.Split(getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:3000"), ","),
 		},
 		Auth: AuthConfig{
-			JWTSecret: getEnv("JWT_SECRET", "your-secret-key-change-this-in-production"),
-			JWTIssuer: getEnv("JWT_ISSUER", "synapse-api"),
+			JWTSecret:   getEnv("JWT_SECRET", "your-secret-key-change-this-in-production"),
+			JWTIssuer:   getEnv("JWT_ISSUER", "synapse-api"),
+			AdminEmails: getEnvList("ADMIN_EMAILS", ""),
+		},
+		TTS: TTSConfig{
+			Engine:     getEnv("TTS_ENGINE", "espeak"),
+			CacheDir:   getEnv("TTS_CACHE_DIR", "./data/audio"),
+			EspeakPath: getEnv("ESPEAK_PATH", "espeak-ng"),
+			SpeechRate: getEnvInt("TTS_SPEECH_RATE", 0),
 		},
 	}
 }
@@ -107,3 +198,54 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvInt reads an integer, falling back to defaultValue when unset or invalid
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(getEnv(key, ""))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvFloat reads a float, falling back to defaultValue when unset or invalid
+func getEnvFloat(key string, defaultValue float64) float64 {
+	value, err := strconv.ParseFloat(getEnv(key, ""), 64)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvList reads a comma-separated list, dropping blanks and surrounding spaces
+func getEnvList(key, defaultValue string) []string {
+	var values []string
+	for _, v := range strings.Split(getEnv(key, defaultValue), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// getTaskModel reads a task's model settings from AI_MODEL_<TASK> (a list of
+// provider=model pairs), AI_MAX_TOKENS_<TASK>, AI_TEMPERATURE_<TASK> and
+// AI_TIMEOUT_SECONDS_<TASK>, e.g. AI_MODEL_DEFINITION=claude=claude-3-5-haiku-20241022
+func getTaskModel(task, models string, maxTokens int, temperature float64, timeout time.Duration) TaskModelConfig {
+	suffix := strings.ToUpper(task)
+	tm := TaskModelConfig{
+		Models:      make(map[string]string),
+		MaxTokens:   getEnvInt("AI_MAX_TOKENS_"+suffix, maxTokens),
+		Temperature: getEnvFloat("AI_TEMPERATURE_"+suffix, temperature),
+		Timeout:     time.Duration(getEnvInt("AI_TIMEOUT_SECONDS_"+suffix, int(timeout/time.Second))) * time.Second,
+	}
+	for _, pair := range getEnvList("AI_MODEL_"+suffix, models) {
+		provider, model, ok := strings.Cut(pair, "=")
+		if !ok {
+			log.Printf("Ignoring %q in AI_MODEL_%s: expected provider=model", pair, suffix)
+			continue
+		}
+		tm.Models[strings.TrimSpace(provider)] = strings.TrimSpace(model)
+	}
+	return tm
+}
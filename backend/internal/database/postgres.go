@@ -108,6 +108,14 @@ func (db *DB) InitSchema() error {
 		added_at TIMESTAMP NOT NULL DEFAULT NOW()
 	);
 
+	CREATE TABLE IF NOT EXISTS article_sentences (
+		id SERIAL PRIMARY KEY,
+		article_id INTEGER NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+		position INTEGER NOT NULL,
+		text TEXT NOT NULL,
+		UNIQUE(article_id, position)
+	);
+
 	CREATE TABLE IF NOT EXISTS user_progress (
 		id SERIAL PRIMARY KEY,
 		user_id INTEGER UNIQUE NOT NULL REFERENCES users(id) ON DELETE CASCADE,
@@ -118,6 +126,109 @@ func (db *DB) InitSchema() error {
 		updated_at TIMESTAMP NOT NULL DEFAULT NOW()
 	);
 
+	-- Shared cache of model answers (definitions, translations, grammar)
+	CREATE TABLE IF NOT EXISTS ai_cache (
+		cache_key VARCHAR(64) PRIMARY KEY, -- sha256 of task/language/input/model/prompt version
+		task VARCHAR(50) NOT NULL,
+		language VARCHAR(50) NOT NULL,
+		input TEXT NOT NULL,
+		model VARCHAR(100) NOT NULL,
+		prompt_version VARCHAR(100) NOT NULL,
+		response JSONB NOT NULL,
+		hit_count INTEGER NOT NULL DEFAULT 0,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		expires_at TIMESTAMP NOT NULL
+	);
+
+	-- Metered AI calls for per-user budgets and quotas
+	CREATE TABLE IF NOT EXISTS ai_usage (
+		id SERIAL PRIMARY KEY,
+		user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		task VARCHAR(50) NOT NULL,
+		provider VARCHAR(50) NOT NULL,
+		model VARCHAR(100) NOT NULL,
+		input_tokens INTEGER NOT NULL DEFAULT 0,
+		output_tokens INTEGER NOT NULL DEFAULT 0,
+		cost_usd NUMERIC(12, 6) NOT NULL DEFAULT 0,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+
+	-- Socratic tutor conversations, optionally about a quest or an article
+	CREATE TABLE IF NOT EXISTS tutor_sessions (
+		id SERIAL PRIMARY KEY,
+		user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		language VARCHAR(50) NOT NULL,
+		level VARCHAR(50) NOT NULL,
+		quest_id INTEGER REFERENCES quests(id) ON DELETE SET NULL,
+		article_id INTEGER REFERENCES articles(id) ON DELETE SET NULL,
+		title VARCHAR(255) NOT NULL,
+		summary TEXT NOT NULL DEFAULT '',
+		summarized_through INTEGER NOT NULL DEFAULT 0, -- last message folded into summary
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+
+	CREATE TABLE IF NOT EXISTS tutor_messages (
+		id SERIAL PRIMARY KEY,
+		session_id INTEGER NOT NULL REFERENCES tutor_sessions(id) ON DELETE CASCADE,
+		role VARCHAR(20) NOT NULL, -- user or assistant
+		content TEXT NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+
+	-- Per-user translation memory; identical inputs reuse their entry
+	CREATE TABLE IF NOT EXISTS translation_memory (
+		id SERIAL PRIMARY KEY,
+		user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		source_language VARCHAR(50) NOT NULL,
+		target_language VARCHAR(50) NOT NULL,
+		source_text TEXT NOT NULL,
+		source_hash CHAR(64) NOT NULL, -- sha256 of the normalized source text
+		translation TEXT NOT NULL,
+		machine_translation TEXT NOT NULL,
+		edited BOOLEAN NOT NULL DEFAULT FALSE,
+		pinned BOOLEAN NOT NULL DEFAULT FALSE,
+		use_count INTEGER NOT NULL DEFAULT 1,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		UNIQUE(user_id, source_language, target_language, source_hash)
+	);
+
+	-- Orator pronunciation attempts, scored per word of the target phrase
+	CREATE TABLE IF NOT EXISTS orator_attempts (
+		id SERIAL PRIMARY KEY,
+		user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		language VARCHAR(50) NOT NULL,
+		target TEXT NOT NULL,
+		transcript TEXT NOT NULL,
+		score INTEGER NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+
+	CREATE TABLE IF NOT EXISTS orator_attempt_words (
+		id SERIAL PRIMARY KEY,
+		attempt_id INTEGER NOT NULL REFERENCES orator_attempts(id) ON DELETE CASCADE,
+		user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		position INTEGER NOT NULL, -- index of the word in the target phrase
+		word VARCHAR(255) NOT NULL,
+		word_id INTEGER REFERENCES words(id) ON DELETE SET NULL,
+		score INTEGER NOT NULL,
+		issues JSONB NOT NULL DEFAULT '[]',
+		rescheduled BOOLEAN NOT NULL DEFAULT FALSE
+	);
+
+	-- Columns added after the initial release
+	-- Prompt template revision (e.g. "finnish/quest_generation@2") that produced AI content
+	ALTER TABLE quests ADD COLUMN IF NOT EXISTS prompt_version VARCHAR(100);
+	ALTER TABLE words ADD COLUMN IF NOT EXISTS prompt_version VARCHAR(100);
+	-- Sentence the learner found the word in; it decided which sense was saved
+	ALTER TABLE words ADD COLUMN IF NOT EXISTS context TEXT;
+	-- Grammatical category of a conjugated form, beside its tense and person
+	ALTER TABLE word_conjugations ADD COLUMN IF NOT EXISTS mood VARCHAR(20) NOT NULL DEFAULT 'indicative';
+	ALTER TABLE word_conjugations ADD COLUMN IF NOT EXISTS voice VARCHAR(10) NOT NULL DEFAULT 'active';
+	ALTER TABLE word_conjugations ADD COLUMN IF NOT EXISTS polarity VARCHAR(15) NOT NULL DEFAULT 'affirmative';
+	ALTER TABLE word_conjugations ADD COLUMN IF NOT EXISTS variant VARCHAR(50);
+
 	-- Indexes for performance
 	CREATE INDEX IF NOT EXISTS idx_words_user_id ON words(user_id);
 	CREATE INDEX IF NOT EXISTS idx_words_status ON words(status);
@@ -126,9 +237,18 @@ func (db *DB) InitSchema() error {
 	CREATE INDEX IF NOT EXISTS idx_quests_user_id ON quests(user_id);
 	CREATE INDEX IF NOT EXISTS idx_quests_status ON quests(status);
 	CREATE INDEX IF NOT EXISTS idx_quests_user_status ON quests(user_id, status);
+	CREATE INDEX IF NOT EXISTS idx_tutor_sessions_user ON tutor_sessions(user_id, updated_at DESC);
+	CREATE INDEX IF NOT EXISTS idx_tutor_messages_session ON tutor_messages(session_id, id);
+	CREATE INDEX IF NOT EXISTS idx_translation_memory_user ON translation_memory(user_id, updated_at DESC);
+	CREATE INDEX IF NOT EXISTS idx_translation_memory_pinned ON translation_memory(user_id, source_language, target_language) WHERE pinned;
+	CREATE INDEX IF NOT EXISTS idx_orator_attempts_user ON orator_attempts(user_id, created_at DESC);
+	CREATE INDEX IF NOT EXISTS idx_orator_attempt_words_user_word ON orator_attempt_words(user_id, word);
 	CREATE INDEX IF NOT EXISTS idx_word_relations_user_id ON word_relations(user_id);
 	CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
 	CREATE INDEX IF NOT EXISTS idx_users_username ON users(username);
+	CREATE INDEX IF NOT EXISTS idx_ai_cache_task_language ON ai_cache(task, language);
+	CREATE INDEX IF NOT EXISTS idx_ai_cache_expires_at ON ai_cache(expires_at);
+	CREATE INDEX IF NOT EXISTS idx_ai_usage_user_created ON ai_usage(user_id, created_at);
 	`
 
 	_, err := db.Exec(schema)
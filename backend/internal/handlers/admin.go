@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/BachirKhiati/lexia/internal/services/ai"
+)
+
+type AdminHandler struct {
+	aiService *ai.Service
+}
+
+func NewAdminHandler(aiService *ai.Service) *AdminHandler {
+	return &AdminHandler{aiService: aiService}
+}
+
+// GetAICacheStats returns hit/miss counters for the AI answer cache
+// @Summary Get AI cache statistics
+// @Description Returns hit/miss counters and live entry count of the shared AI answer cache
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} ai.CacheStats "Cache statistics"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Router /admin/ai-cache [get]
+func (h *AdminHandler) GetAICacheStats(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.aiService.CacheStats(r.Context()))
+}
+
+// InvalidateAICache removes cached AI answers
+// @Summary Invalidate AI cache entries
+// @Description Deletes cached AI answers matching the filter. An empty filter clears the whole cache.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body ai.CacheFilter false "Entries to remove"
+// @Success 200 {object} map[string]int64 "Number of removed entries"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Invalidation failed"
+// @Router /admin/ai-cache/invalidate [post]
+func (h *AdminHandler) InvalidateAICache(w http.ResponseWriter, r *http.Request) {
+	var filter ai.CacheFilter
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&filter); err != nil {
+			http.Error(w, "Invalid request", http.StatusBadRequest)
+			return
+		}
+	}
+
+	removed, err := h.aiService.InvalidateCache(r.Context(), filter)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]int64{"removed": removed})
+}
+
+// promptInfo describes one prompt template revision
+type promptInfo struct {
+	ID string `json:"id"`
+	*ai.Prompt
+}
+
+// GetPrompts lists the prompt templates in use
+// @Summary List AI prompt templates
+// @Description Returns every prompt template with its revision, including per-language variants and deployment overrides
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} ai.Prompt "Prompt templates"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Router /admin/prompts [get]
+func (h *AdminHandler) GetPrompts(w http.ResponseWriter, r *http.Request) {
+	prompts := h.aiService.Prompts().List()
+	infos := make([]promptInfo, 0, len(prompts))
+	for _, p := range prompts {
+		infos = append(infos, promptInfo{ID: p.ID(), Prompt: p})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(infos)
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/BachirKhiati/lexia/internal/middleware"
+	"github.com/BachirKhiati/lexia/internal/services/ai"
+)
+
+// aiContext returns the request context with the authenticated user attached,
+// so AI calls are metered against that user's quota
+func aiContext(r *http.Request) context.Context {
+	if claims, ok := middleware.GetUserFromContext(r); ok {
+		return ai.WithUserID(r.Context(), claims.UserID)
+	}
+	return r.Context()
+}
+
+// aiErrorStatus maps an error from the AI service to an HTTP status code
+func aiErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ai.ErrQuotaExceeded):
+		return http.StatusTooManyRequests
+	case errors.Is(err, ai.ErrAllProvidersFailed):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// writeAIError writes an AI service error with the matching status code.
+// Quota errors also tell the client when it may try again.
+func writeAIError(w http.ResponseWriter, err error) {
+	var quotaErr *ai.QuotaError
+	if errors.As(err, &quotaErr) {
+		retryAfter := int(time.Until(quotaErr.ResetAt).Seconds()) + 1
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	}
+	http.Error(w, err.Error(), aiErrorStatus(err))
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BachirKhiati/lexia/internal/services/ai"
+)
+
+func TestWriteAIError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		retryAfter bool
+	}{
+		{"quota", fmt.Errorf("generate: %w", &ai.QuotaError{Period: "daily", Limit: "10 tokens", ResetAt: time.Now().Add(time.Hour)}), http.StatusTooManyRequests, true},
+		{"providers down", fmt.Errorf("%w: overloaded", ai.ErrAllProvidersFailed), http.StatusServiceUnavailable, false},
+		{"other", fmt.Errorf("boom"), http.StatusInternalServerError, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			writeAIError(rr, tt.err)
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rr.Code, tt.wantStatus)
+			}
+			retryAfter := rr.Header().Get("Retry-After")
+			if !tt.retryAfter {
+				if retryAfter != "" {
+					t.Errorf("unexpected Retry-After %q", retryAfter)
+				}
+				return
+			}
+			if seconds, err := strconv.Atoi(retryAfter); err != nil || seconds < 3500 || seconds > 3601 {
+				t.Errorf("Retry-After = %q, want about an hour", retryAfter)
+			}
+		})
+	}
+}
+
+func TestGetPromptsListsTemplates(t *testing.T) {
+	aiService, err := ai.NewFakeService(ai.NewFakeProvider("fake"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := NewAdminHandler(aiService)
+
+	rr := httptest.NewRecorder()
+	handler.GetPrompts(rr, httptest.NewRequest(http.MethodGet, "/api/v1/admin/prompts", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d", rr.Code)
+	}
+	for _, id := range []string{"default/definition@2", "finnish/grammar@3"} {
+		if !strings.Contains(rr.Body.String(), `"id":"`+id+`"`) {
+			t.Errorf("missing prompt %s in %s", id, rr.Body.String())
+		}
+	}
+}
@@ -2,13 +2,20 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/BachirKhiati/lexia/internal/models"
 	"github.com/BachirKhiati/lexia/internal/services/ai"
 	"github.com/BachirKhiati/lexia/internal/services/language"
 )
 
+// maxBatchTextRunes bounds the text accepted by /analyze/batch
+const maxBatchTextRunes = 5000
+
 type AnalyzerHandler struct {
 	aiService       *ai.Service
 	languageService *language.Service
@@ -23,7 +30,7 @@ func NewAnalyzerHandler(aiService *ai.Service, langService *language.Service) *A
 
 // AnalyzeWord handles the universal pop-up analyzer requests
 // @Summary Analyze a word
-// @Description Get comprehensive analysis of a word including definition, part of speech, examples, and conjugations
+// @Description Get comprehensive analysis of a word including definition, part of speech, examples, and conjugations. When the request includes the sentence the word appeared in (context), the sense that fits the sentence is returned and the other likely senses are listed in other_senses.
 // @Tags Analyzer
 // @Accept json
 // @Produce json
@@ -32,6 +39,7 @@ func NewAnalyzerHandler(aiService *ai.Service, langService *language.Service) *A
 // @Success 200 {object} models.AnalyzerResponse "Word analysis"
 // @Failure 400 {object} map[string]string "Invalid request"
 // @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 429 {object} map[string]string "AI usage quota exceeded"
 // @Failure 500 {object} map[string]string "Analysis failed"
 // @Router /analyze [post]
 func (h *AnalyzerHandler) AnalyzeWord(w http.ResponseWriter, r *http.Request) {
@@ -40,11 +48,14 @@ func (h *AnalyzerHandler) AnalyzeWord(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Invalid request", http.StatusBadRequest)
 		return
 	}
+	if !resolveLanguage(w, h.languageService.Languages(), &req.Language) {
+		return
+	}
 
-	// Get word analysis from language service
-	analysis, err := h.languageService.AnalyzeWord(r.Context(), req.Word, req.Language)
+	// Get word analysis from language service; the sentence picks the sense
+	analysis, err := h.languageService.AnalyzeWord(aiContext(r), req.Word, req.Language, req.Context)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeAIError(w, err)
 		return
 	}
 
@@ -54,3 +65,54 @@ func (h *AnalyzerHandler) AnalyzeWord(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(analysis)
 }
+
+// AnalyzeBatch analyzes every word of a sentence or paragraph
+// @Summary Analyze all words of a text
+// @Description Tokenize a text and analyze each distinct word, like /analyze with the word's sentence as context. Words are compared case-insensitively and listed in order of first appearance with their number of occurrences. A word that cannot be analyzed, or is not reached before the shared deadline, carries an error instead of an analysis; the request itself still succeeds.
+// @Tags Analyzer
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.BatchAnalyzeRequest true "Text to analyze"
+// @Success 200 {object} models.BatchAnalyzeResponse "Analysis of each distinct word"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 429 {object} map[string]string "AI usage quota exceeded"
+// @Router /analyze/batch [post]
+func (h *AnalyzerHandler) AnalyzeBatch(w http.ResponseWriter, r *http.Request) {
+	var req models.BatchAnalyzeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.Text) == "" {
+		http.Error(w, "Text is required", http.StatusBadRequest)
+		return
+	}
+	if utf8.RuneCountInString(req.Text) > maxBatchTextRunes {
+		http.Error(w, fmt.Sprintf("Text is longer than %d characters", maxBatchTextRunes), http.StatusBadRequest)
+		return
+	}
+	if !resolveLanguage(w, h.languageService.Languages(), &req.Language) {
+		return
+	}
+	tokens, err := h.languageService.AnalyzeBatch(aiContext(r), req.Text, req.Language)
+	if err != nil {
+		if errors.Is(err, language.ErrTooManyTokens) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeAIError(w, err)
+		return
+	}
+
+	response := models.BatchAnalyzeResponse{Language: req.Language, Tokens: tokens}
+	for _, token := range tokens {
+		if token.Error != "" {
+			response.Failed++
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
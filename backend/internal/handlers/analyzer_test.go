@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BachirKhiati/lexia/internal/models"
+	"github.com/BachirKhiati/lexia/internal/services/ai"
+	"github.com/BachirKhiati/lexia/internal/services/language"
+	"github.com/BachirKhiati/lexia/internal/services/wiktionary"
+)
+
+func newFakeAnalyzer(t *testing.T, fake *ai.FakeProvider) *AnalyzerHandler {
+	t.Helper()
+	aiService, err := ai.NewFakeService(fake)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewAnalyzerHandler(aiService, language.NewService(nil, aiService))
+}
+
+func TestAnalyzeWordUsesAIDefinition(t *testing.T) {
+	fake := ai.NewFakeProvider("fake").Script(ai.TaskDefinition,
+		ai.Reply(`{"definition": "to take", "part_of_speech": "verb", "examples": ["Otan kahvia."]}`))
+	handler := newFakeAnalyzer(t, fake)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/analyze", strings.NewReader(`{"word": "ottaa", "language": "finnish"}`))
+	rr := httptest.NewRecorder()
+	handler.AnalyzeWord(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rr.Code, rr.Body.String())
+	}
+	var resp models.AnalyzerResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Definition != "to take" || resp.PartOfSpeech != "verb" || len(resp.Examples) != 1 {
+		t.Errorf("response = %+v", resp)
+	}
+	if resp.PromptVersion != "default/definition@2" {
+		t.Errorf("prompt version = %q", resp.PromptVersion)
+	}
+	if n := fake.CallCount(ai.TaskDefinition); n != 1 {
+		t.Errorf("definition calls = %d, want 1", n)
+	}
+}
+
+// spentQuota fails every definition as a user over quota would
+type spentQuota struct{}
+
+func (spentQuota) GetWordDefinition(ctx context.Context, word string, language string) (*ai.DefinitionResult, error) {
+	return nil, fmt.Errorf("definition: %w", &ai.QuotaError{Period: "daily", Limit: "10 tokens", ResetAt: time.Now().Add(time.Hour)})
+}
+
+func TestAnalyzeOverQuota(t *testing.T) {
+	handler := NewAnalyzerHandler(nil, language.NewService(nil, spentQuota{}))
+
+	rr := httptest.NewRecorder()
+	handler.AnalyzeWord(rr, httptest.NewRequest(http.MethodPost, "/api/v1/analyze", strings.NewReader(`{"word": "ottaa", "language": "finnish"}`)))
+	if rr.Code != http.StatusTooManyRequests || rr.Header().Get("Retry-After") == "" {
+		t.Errorf("word: status = %d, Retry-After %q, want 429 with Retry-After", rr.Code, rr.Header().Get("Retry-After"))
+	}
+
+	rr = httptest.NewRecorder()
+	handler.AnalyzeBatch(rr, httptest.NewRequest(http.MethodPost, "/api/v1/analyze/batch", strings.NewReader(`{"text": "Talo on iso.", "language": "fi"}`)))
+	if rr.Code != http.StatusTooManyRequests {
+		t.Errorf("batch: status = %d, want 429", rr.Code)
+	}
+}
+
+func TestAnalyzeWordRejectsInvalidBody(t *testing.T) {
+	handler := newFakeAnalyzer(t, ai.NewFakeProvider("fake"))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/analyze", strings.NewReader(`{`))
+	rr := httptest.NewRecorder()
+	handler.AnalyzeWord(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rr.Code, http.StatusBadRequest)
+	}
+}
+
+func TestAnalyzeWordRejectsUnsupportedLanguage(t *testing.T) {
+	fake := ai.NewFakeProvider("fake")
+	handler := newFakeAnalyzer(t, fake)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/analyze", strings.NewReader(`{"word": "talo", "language": "klingon"}`))
+	rr := httptest.NewRecorder()
+	handler.AnalyzeWord(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rr.Code, http.StatusBadRequest)
+	}
+	if n := fake.CallCount(ai.TaskDefinition); n != 0 {
+		t.Errorf("definition calls = %d, want 0", n)
+	}
+}
+
+func TestAnalyzeWordPicksSenseForContext(t *testing.T) {
+	wiktionaryServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"fi": [
+			{"partOfSpeech": "Numeral", "definitions": [{"definition": "six"}]},
+			{"partOfSpeech": "Noun", "definitions": [{"definition": "spruce"}]}
+		]}`))
+	}))
+	defer wiktionaryServer.Close()
+
+	fake := ai.NewFakeProvider("fake").Script(ai.TaskSenseSelection, ai.Reply(`{"sense": 2}`))
+	aiService, err := ai.NewFakeService(fake)
+	if err != nil {
+		t.Fatal(err)
+	}
+	langService := language.NewService(wiktionary.NewServiceWithBaseURL(wiktionaryServer.URL), aiService)
+	handler := NewAnalyzerHandler(aiService, langService)
+
+	body := `{"word": "kuusi", "language": "finnish", "context": "Pihalla kasvaa vanha kuusi."}`
+	rr := httptest.NewRecorder()
+	handler.AnalyzeWord(rr, httptest.NewRequest(http.MethodPost, "/api/v1/analyze", strings.NewReader(body)))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rr.Code, rr.Body.String())
+	}
+	var resp models.AnalyzerResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Definition != "spruce" || resp.Context != "Pihalla kasvaa vanha kuusi." {
+		t.Errorf("response = %+v", resp)
+	}
+	if len(resp.OtherSenses) != 1 || resp.OtherSenses[0] != "six" {
+		t.Errorf("other senses = %v", resp.OtherSenses)
+	}
+	if prompt := fake.Calls()[0].Prompt; !strings.Contains(prompt, "1. Numeral: six\n2. Noun: spruce") {
+		t.Errorf("senses missing from prompt: %q", prompt)
+	}
+}
+
+func TestAnalyzeBatch(t *testing.T) {
+	fake := ai.NewFakeProvider("fake").Script(ai.TaskDefinition,
+		ai.Reply(`{"definition": "a word", "part_of_speech": "noun", "examples": []}`))
+	handler := newFakeAnalyzer(t, fake)
+
+	body := `{"text": "Talo on iso. Iso talo!", "language": "fi"}`
+	rr := httptest.NewRecorder()
+	handler.AnalyzeBatch(rr, httptest.NewRequest(http.MethodPost, "/api/v1/analyze/batch", strings.NewReader(body)))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rr.Code, rr.Body.String())
+	}
+	var resp models.BatchAnalyzeResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Language != "finnish" || len(resp.Tokens) != 3 || resp.Failed != 0 {
+		t.Fatalf("response = %+v", resp)
+	}
+	if tok := resp.Tokens[0]; tok.Token != "talo" || tok.Occurrences != 2 || tok.Analysis == nil {
+		t.Errorf("first token = %+v", tok)
+	}
+	if n := fake.CallCount(ai.TaskDefinition); n != 3 {
+		t.Errorf("definition calls = %d, want one per distinct word", n)
+	}
+}
+
+func TestAnalyzeBatchRejectsEmptyText(t *testing.T) {
+	handler := newFakeAnalyzer(t, ai.NewFakeProvider("fake"))
+
+	rr := httptest.NewRecorder()
+	handler.AnalyzeBatch(rr, httptest.NewRequest(http.MethodPost, "/api/v1/analyze/batch", strings.NewReader(`{"text": "  "}`)))
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rr.Code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/BachirKhiati/lexia/internal/services/tts"
+)
+
+type AudioHandler struct {
+	ttsService *tts.Service // nil when text-to-speech is turned off
+}
+
+func NewAudioHandler(ttsService *tts.Service) *AudioHandler {
+	return &AudioHandler{ttsService: ttsService}
+}
+
+// GetAudio serves a synthesized clip
+// @Summary Get spoken audio
+// @Description Serve a clip of a word or example sentence. URLs come from audio_url and example_audio_urls in Analyzer responses. Clips never change, so they are cacheable indefinitely. No authentication is needed, so the URLs work as the source of an audio element.
+// @Tags Analyzer
+// @Produce audio/wav
+// @Param hash path string true "Clip key from an audio URL"
+// @Success 200 {file} binary "Audio clip"
+// @Failure 404 {object} map[string]string "Audio not found"
+// @Router /audio/{hash} [get]
+func (h *AudioHandler) GetAudio(w http.ResponseWriter, r *http.Request) {
+	if h.ttsService == nil {
+		http.Error(w, "Audio not found", http.StatusNotFound)
+		return
+	}
+
+	clip, err := h.ttsService.Open(chi.URLParam(r, "hash"))
+	if errors.Is(err, tts.ErrNotFound) {
+		http.Error(w, "Audio not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to read audio", http.StatusInternalServerError)
+		return
+	}
+	defer clip.Close()
+
+	// Keys are content hashes, so a clip never changes
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	http.ServeContent(w, r, "", time.Time{}, clip)
+}
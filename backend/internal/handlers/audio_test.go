@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/BachirKhiati/lexia/internal/services/tts"
+)
+
+// wavEngine returns a minimal WAV header followed by the text
+type wavEngine struct{}
+
+func (wavEngine) Name() string { return "wav" }
+
+func (wavEngine) Synthesize(ctx context.Context, text string, language string) ([]byte, error) {
+	return append([]byte("RIFF\x24\x00\x00\x00WAVEfmt "), text...), nil
+}
+
+func newAudioRouter(t *testing.T, service *tts.Service) http.Handler {
+	t.Helper()
+	r := chi.NewRouter()
+	r.Get("/api/v1/audio/{hash}", NewAudioHandler(service).GetAudio)
+	return r
+}
+
+func TestGetAudio(t *testing.T) {
+	store, err := tts.NewDiskStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := tts.NewService(wavEngine{}, store)
+	url, err := service.AudioURL(context.Background(), "talo", "finnish")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	newAudioRouter(t, service).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, url, nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rr.Code, rr.Body.String())
+	}
+	if ct := rr.Header().Get("Content-Type"); ct != "audio/wave" {
+		t.Errorf("content type = %q", ct)
+	}
+	if cc := rr.Header().Get("Cache-Control"); cc == "" {
+		t.Error("clip is not cacheable")
+	}
+
+	for _, path := range []string{tts.URLPrefix + "0000000000000000000000000000000000000000000000000000000000000000", tts.URLPrefix + "..%2Fsecret"} {
+		rr := httptest.NewRecorder()
+		newAudioRouter(t, service).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("%s: status = %d, want 404", path, rr.Code)
+		}
+	}
+}
+
+func TestGetAudioWithSpeechOff(t *testing.T) {
+	rr := httptest.NewRecorder()
+	newAudioRouter(t, nil).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tts.URLPrefix+"abc", nil))
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", rr.Code)
+	}
+}
@@ -10,17 +10,20 @@ import (
 	"github.com/BachirKhiati/lexia/internal/database"
 	"github.com/BachirKhiati/lexia/internal/middleware"
 	"github.com/BachirKhiati/lexia/internal/services/auth"
+	"github.com/BachirKhiati/lexia/internal/services/language"
 )
 
 type AuthHandler struct {
 	db          *database.DB
 	authService *auth.Service
+	languages   *language.Registry
 }
 
-func NewAuthHandler(db *database.DB, authService *auth.Service) *AuthHandler {
+func NewAuthHandler(db *database.DB, authService *auth.Service, languages *language.Registry) *AuthHandler {
 	return &AuthHandler{
 		db:          db,
 		authService: authService,
+		languages:   languages,
 	}
 }
 
@@ -79,8 +82,8 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Default language
-	if req.Language == "" {
-		req.Language = "finnish"
+	if !resolveLanguage(w, h.languages, &req.Language) {
+		return
 	}
 
 	// Hash password
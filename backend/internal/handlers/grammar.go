@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/BachirKhiati/lexia/internal/models"
+	"github.com/BachirKhiati/lexia/internal/services/ai"
+	"github.com/BachirKhiati/lexia/internal/services/language"
+)
+
+// maxGrammarCheckRunes bounds the text accepted by /grammar/check
+const maxGrammarCheckRunes = 2000
+
+type GrammarHandler struct {
+	aiService *ai.Service
+	languages *language.Registry
+}
+
+func NewGrammarHandler(aiService *ai.Service, languages *language.Registry) *GrammarHandler {
+	return &GrammarHandler{aiService: aiService, languages: languages}
+}
+
+// CheckGrammar checks a text and returns annotated issues
+// @Summary Check grammar
+// @Description Check a learner's text. Each issue carries the excerpt it is about, its position in the text as byte and rune (code point) offsets, a category (case, vowel_harmony, word_order, conjugation, consonant_gradation, agreement, spelling, vocabulary, other), a severity (error, warning, suggestion), a suggested replacement and a short explanation. The span is omitted when the excerpt could not be located.
+// @Tags Analyzer
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.GrammarCheckRequest true "Text to check"
+// @Success 200 {object} ai.GrammarResult "Grammar issues"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 429 {object} map[string]string "AI usage quota exceeded"
+// @Failure 503 {object} map[string]string "All AI providers unavailable"
+// @Router /grammar/check [post]
+func (h *GrammarHandler) CheckGrammar(w http.ResponseWriter, r *http.Request) {
+	var req models.GrammarCheckRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.Text) == "" {
+		http.Error(w, "Text is required", http.StatusBadRequest)
+		return
+	}
+	if utf8.RuneCountInString(req.Text) > maxGrammarCheckRunes {
+		http.Error(w, fmt.Sprintf("Text is longer than %d characters", maxGrammarCheckRunes), http.StatusBadRequest)
+		return
+	}
+	if !resolveLanguage(w, h.languages, &req.Language) {
+		return
+	}
+
+	result, err := h.aiService.AnalyzeGrammar(aiContext(r), req.Text, req.Language)
+	if err != nil {
+		writeAIError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
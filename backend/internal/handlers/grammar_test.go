@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/BachirKhiati/lexia/internal/services/ai"
+	"github.com/BachirKhiati/lexia/internal/services/language"
+)
+
+func TestCheckGrammar(t *testing.T) {
+	fake := ai.NewFakeProvider("fake").Script(ai.TaskGrammar, ai.Reply(`{
+		"correct": false,
+		"issues": [{"excerpt": "kirja", "category": "case", "severity": "error", "replacement": "kirjaa", "explanation": "Numbers take the partitive."}]
+	}`))
+	aiService, err := ai.NewFakeService(fake)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := NewGrammarHandler(aiService, language.NewDefaultRegistry())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/grammar/check", strings.NewReader(`{"text": "Ostin kaksi kirja.", "language": "finnish"}`))
+	rr := httptest.NewRecorder()
+	handler.CheckGrammar(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rr.Code, rr.Body.String())
+	}
+	var result ai.GrammarResult
+	if err := json.NewDecoder(rr.Body).Decode(&result); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Issues) != 1 {
+		t.Fatalf("issues = %+v", result.Issues)
+	}
+	issue := result.Issues[0]
+	if issue.Category != ai.CategoryCase || issue.Replacement != "kirjaa" || issue.Span == nil || issue.Span.RuneStart != 12 || issue.Span.RuneEnd != 17 {
+		t.Errorf("issue = %+v, span %+v", issue, issue.Span)
+	}
+}
+
+func TestCheckGrammarRejectsEmptyAndLongText(t *testing.T) {
+	aiService, err := ai.NewFakeService(ai.NewFakeProvider("fake"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := NewGrammarHandler(aiService, language.NewDefaultRegistry())
+
+	for _, text := range []string{"  ", strings.Repeat("ä", maxGrammarCheckRunes+1)} {
+		body, _ := json.Marshal(map[string]string{"text": text})
+		rr := httptest.NewRecorder()
+		handler.CheckGrammar(rr, httptest.NewRequest(http.MethodPost, "/api/v1/grammar/check", strings.NewReader(string(body))))
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("text of %d runes: status = %d, want %d", len([]rune(text)), rr.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestCheckGrammarRejectsUnsupportedLanguage(t *testing.T) {
+	aiService, err := ai.NewFakeService(ai.NewFakeProvider("fake"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := NewGrammarHandler(aiService, language.NewDefaultRegistry())
+
+	rr := httptest.NewRecorder()
+	handler.CheckGrammar(rr, httptest.NewRequest(http.MethodPost, "/api/v1/grammar/check", strings.NewReader(`{"text": "Hej då.", "language": "klingon"}`)))
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rr.Code, http.StatusBadRequest)
+	}
+}
@@ -7,16 +7,19 @@ import (
 	"time"
 
 	"github.com/BachirKhiati/lexia/internal/database"
+	"github.com/BachirKhiati/lexia/internal/services/ai"
 )
 
 type HealthHandler struct {
 	db        *database.DB
+	aiService *ai.Service
 	startTime time.Time
 }
 
-func NewHealthHandler(db *database.DB) *HealthHandler {
+func NewHealthHandler(db *database.DB, aiService *ai.Service) *HealthHandler {
 	return &HealthHandler{
 		db:        db,
+		aiService: aiService,
 		startTime: time.Now(),
 	}
 }
@@ -32,12 +35,14 @@ type HealthResponse struct {
 
 // SystemStats contains detailed system statistics
 type SystemStats struct {
-	Uptime          string         `json:"uptime"`
-	GoVersion       string         `json:"go_version"`
-	NumGoroutines   int            `json:"num_goroutines"`
-	MemoryStats     MemoryStats    `json:"memory_stats"`
-	DatabaseStats   DatabaseStats  `json:"database_stats"`
-	Timestamp       string         `json:"timestamp"`
+	Uptime        string              `json:"uptime"`
+	GoVersion     string              `json:"go_version"`
+	NumGoroutines int                 `json:"num_goroutines"`
+	MemoryStats   MemoryStats         `json:"memory_stats"`
+	DatabaseStats DatabaseStats       `json:"database_stats"`
+	AIProviders   []ai.ProviderStatus `json:"ai_providers"`
+	AICache       ai.CacheStats       `json:"ai_cache"`
+	Timestamp     string              `json:"timestamp"`
 }
 
 type MemoryStats struct {
@@ -182,6 +187,12 @@ func (h *HealthHandler) Stats(w http.ResponseWriter, r *http.Request) {
 		Timestamp:     time.Now().Format(time.RFC3339),
 	}
 
+	// Circuit breaker state per AI provider
+	if h.aiService != nil {
+		response.AIProviders = h.aiService.ProviderStatuses()
+		response.AICache = h.aiService.CacheStats(r.Context())
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/BachirKhiati/lexia/internal/services/language"
+)
+
+// resolveLanguage checks the language of a request against the supported
+// languages and replaces it with the language's name, so "fi" and "Finnish"
+// are both stored as "finnish". An empty language becomes the default. An
+// unsupported language is answered with 400 and false is returned.
+func resolveLanguage(w http.ResponseWriter, languages *language.Registry, lang *string) bool {
+	l, err := languages.Resolve(*lang)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	*lang = l.Name()
+	return true
+}
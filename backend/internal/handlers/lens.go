@@ -1,24 +1,28 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 
 	"github.com/BachirKhiati/lexia/internal/database"
 	"github.com/BachirKhiati/lexia/internal/middleware"
+	"github.com/BachirKhiati/lexia/internal/services/language"
 	"github.com/BachirKhiati/lexia/internal/services/scraper"
 )
 
 type LensHandler struct {
 	db             *database.DB
 	scraperService *scraper.Service
+	languages      *language.Registry
 }
 
-func NewLensHandler(db *database.DB, scraperService *scraper.Service) *LensHandler {
+func NewLensHandler(db *database.DB, scraperService *scraper.Service, languages *language.Registry) *LensHandler {
 	return &LensHandler{
 		db:             db,
 		scraperService: scraperService,
+		languages:      languages,
 	}
 }
 
@@ -28,10 +32,11 @@ type ImportRequest struct {
 }
 
 type ImportResponse struct {
-	ID      int    `json:"id"`
-	Title   string `json:"title"`
-	Content string `json:"content"`
-	URL     string `json:"url"`
+	ID        int      `json:"id"`
+	Title     string   `json:"title"`
+	Content   string   `json:"content"`
+	URL       string   `json:"url"`
+	Sentences []string `json:"sentences"`
 }
 
 // ImportArticle extracts content from a URL and saves it
@@ -53,8 +58,8 @@ func (h *LensHandler) ImportArticle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.Language == "" {
-		req.Language = "finnish"
+	if !resolveLanguage(w, h.languages, &req.Language) {
+		return
 	}
 
 	// Check if it's a YouTube URL
@@ -70,30 +75,57 @@ func (h *LensHandler) ImportArticle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Save to database
-	var articleID int
-	err = h.db.QueryRow(`
-		INSERT INTO articles (user_id, title, url, content, language)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id
-	`, claims.UserID, article.Title, article.URL, article.Content, req.Language).Scan(&articleID)
-
+	// Save the article with its sentences, which the reader shows one by one
+	lang, _ := h.languages.Lookup(req.Language)
+	sentences := lang.Sentences(article.Content)
+	articleID, err := h.saveArticle(r.Context(), claims.UserID, article, req.Language, sentences)
 	if err != nil {
 		http.Error(w, "Failed to save article", http.StatusInternalServerError)
 		return
 	}
 
 	response := ImportResponse{
-		ID:      articleID,
-		Title:   article.Title,
-		Content: article.Content,
-		URL:     article.URL,
+		ID:        articleID,
+		Title:     article.Title,
+		Content:   article.Content,
+		URL:       article.URL,
+		Sentences: sentences,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
+// saveArticle stores an article and its sentences in one transaction
+func (h *LensHandler) saveArticle(ctx context.Context, userID int, article *scraper.Article, lang string, sentences []string) (int, error) {
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var articleID int
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO articles (user_id, title, url, content, language)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`, userID, article.Title, article.URL, article.Content, lang).Scan(&articleID)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, sentence := range sentences {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO article_sentences (article_id, position, text)
+			VALUES ($1, $2, $3)
+		`, articleID, i, sentence)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return articleID, tx.Commit()
+}
+
 // GetUserArticles returns all articles imported by a user
 func (h *LensHandler) GetUserArticles(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middleware.GetUserFromContext(r)
@@ -116,6 +148,7 @@ func (h *LensHandler) GetUserArticles(w http.ResponseWriter, r *http.Request) {
 	defer rows.Close()
 
 	var articles []ImportResponse
+	var languages []string
 	for rows.Next() {
 		var article ImportResponse
 		var addedAt string
@@ -124,8 +157,52 @@ func (h *LensHandler) GetUserArticles(w http.ResponseWriter, r *http.Request) {
 			continue
 		}
 		articles = append(articles, article)
+		languages = append(languages, language)
+	}
+
+	sentences, err := h.articleSentences(claims.UserID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	for i := range articles {
+		articles[i].Sentences = sentences[articles[i].ID]
+		// Articles imported before sentences were stored are split now
+		if articles[i].Sentences == nil {
+			if lang, err := h.languages.Resolve(languages[i]); err == nil {
+				articles[i].Sentences = lang.Sentences(articles[i].Content)
+			}
+		}
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(articles)
 }
+
+// articleSentences returns the stored sentences of the articles listed by
+// GetUserArticles, in order, by article ID
+func (h *LensHandler) articleSentences(userID int) (map[int][]string, error) {
+	rows, err := h.db.Query(`
+		SELECT article_id, text
+		FROM article_sentences
+		WHERE article_id IN (
+			SELECT id FROM articles WHERE user_id = $1 ORDER BY added_at DESC LIMIT 50
+		)
+		ORDER BY article_id, position
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sentences := make(map[int][]string)
+	for rows.Next() {
+		var articleID int
+		var text string
+		if err := rows.Scan(&articleID, &text); err != nil {
+			return nil, err
+		}
+		sentences[articleID] = append(sentences[articleID], text)
+	}
+	return sentences, rows.Err()
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/BachirKhiati/lexia/internal/middleware"
+	"github.com/BachirKhiati/lexia/internal/models"
+	"github.com/BachirKhiati/lexia/internal/services/language"
+	"github.com/BachirKhiati/lexia/internal/services/orator"
+)
+
+type OratorHandler struct {
+	oratorService *orator.Service
+	languages     *language.Registry
+}
+
+func NewOratorHandler(oratorService *orator.Service, languages *language.Registry) *OratorHandler {
+	return &OratorHandler{oratorService: oratorService, languages: languages}
+}
+
+// SubmitAttempt scores a pronunciation attempt
+// @Summary Score a pronunciation attempt
+// @Description Compare the phrase the learner was asked to say with the transcript from speech recognition. The phrases are aligned sound by sound; in Finnish, long vowels, geminate consonants and ä/a, ö/o, y/u confusions are reported per word. The attempt is stored, and saved words pronounced poorly are scheduled for SRS review the next day.
+// @Tags Orator
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.OratorAttemptRequest true "Target phrase and recognized transcript"
+// @Success 201 {object} models.OratorAttempt "Scored attempt"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Failed to save attempt"
+// @Router /orator/attempts [post]
+func (h *OratorHandler) SubmitAttempt(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.OratorAttemptRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+	if !resolveLanguage(w, h.languages, &req.Language) {
+		return
+	}
+
+	attempt, err := h.oratorService.Submit(r.Context(), claims.UserID, req)
+	if errors.Is(err, orator.ErrEmptyAttempt) || errors.Is(err, orator.ErrTargetTooLong) ||
+		errors.Is(err, orator.ErrTranscriptTooLong) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to save attempt", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(attempt)
+}
+
+// ListAttempts returns the user's pronunciation attempts
+// @Summary List pronunciation attempts
+// @Description List the user's scored attempts, newest first, optionally only those containing a word
+// @Tags Orator
+// @Produce json
+// @Security BearerAuth
+// @Param word query string false "Only attempts whose phrase contains this word"
+// @Param limit query int false "Maximum number of attempts (default 50, at most 200)"
+// @Success 200 {array} models.OratorAttempt "Attempts with per-word scores"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Database error"
+// @Router /orator/attempts [get]
+func (h *OratorHandler) ListAttempts(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	filter := orator.ListFilter{Word: query.Get("word")}
+	filter.Limit, _ = strconv.Atoi(query.Get("limit"))
+
+	attempts, err := h.oratorService.Attempts(r.Context(), claims.UserID, filter)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(attempts)
+}
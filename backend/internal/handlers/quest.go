@@ -9,20 +9,33 @@ import (
 	"github.com/BachirKhiati/lexia/internal/database"
 	"github.com/BachirKhiati/lexia/internal/models"
 	"github.com/BachirKhiati/lexia/internal/services/ai"
+	"github.com/BachirKhiati/lexia/internal/services/language"
 )
 
 type QuestHandler struct {
 	db        *database.DB
 	aiService *ai.Service
+	languages *language.Registry
 }
 
-func NewQuestHandler(db *database.DB, aiService *ai.Service) *QuestHandler {
+func NewQuestHandler(db *database.DB, aiService *ai.Service, languages *language.Registry) *QuestHandler {
 	return &QuestHandler{
 		db:        db,
 		aiService: aiService,
+		languages: languages,
 	}
 }
 
+// learnedLanguage returns the name of the language a user learns, or the
+// default language if the user's is not supported
+func (h *QuestHandler) learnedLanguage(stored string) string {
+	l, err := h.languages.Resolve(stored)
+	if err != nil {
+		l, _ = h.languages.Resolve("")
+	}
+	return l.Name()
+}
+
 // GetUserQuests returns all quests for a user
 // @Summary Get user quests
 // @Description Retrieve all quests for a specific user
@@ -39,7 +52,7 @@ func (h *QuestHandler) GetUserQuests(w http.ResponseWriter, r *http.Request) {
 	userID := chi.URLParam(r, "userID")
 
 	rows, err := h.db.Query(`
-		SELECT id, user_id, title, description, solution, difficulty, status, created_at, completed_at
+		SELECT id, user_id, title, description, solution, difficulty, status, created_at, completed_at, COALESCE(prompt_version, '')
 		FROM quests
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -53,7 +66,7 @@ func (h *QuestHandler) GetUserQuests(w http.ResponseWriter, r *http.Request) {
 	var quests []models.Quest
 	for rows.Next() {
 		var q models.Quest
-		if err := rows.Scan(&q.ID, &q.UserID, &q.Title, &q.Description, &q.Solution, &q.Difficulty, &q.Status, &q.CreatedAt, &q.CompletedAt); err != nil {
+		if err := rows.Scan(&q.ID, &q.UserID, &q.Title, &q.Description, &q.Solution, &q.Difficulty, &q.Status, &q.CreatedAt, &q.CompletedAt, &q.PromptVersion); err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
@@ -74,12 +87,21 @@ func (h *QuestHandler) GetUserQuests(w http.ResponseWriter, r *http.Request) {
 // @Param userID path int true "User ID"
 // @Success 200 {object} models.Quest "Generated quest"
 // @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "User not found"
 // @Failure 500 {object} map[string]string "Quest generation failed"
+// @Failure 429 {object} map[string]string "AI usage quota exceeded"
+// @Failure 503 {object} map[string]string "All AI providers unavailable"
 // @Router /users/{userID}/quests/generate [post]
 func (h *QuestHandler) GenerateQuest(w http.ResponseWriter, r *http.Request) {
 	userID := chi.URLParam(r, "userID")
 	userIDInt, _ := strconv.Atoi(userID)
 
+	var userLanguage string
+	if err := h.db.QueryRow(`SELECT language FROM users WHERE id = $1`, userID).Scan(&userLanguage); err != nil {
+		http.Error(w, "User not found", http.StatusNotFound)
+		return
+	}
+
 	// Get user's ghost words
 	rows, err := h.db.Query(`
 		SELECT word FROM words
@@ -101,31 +123,20 @@ func (h *QuestHandler) GenerateQuest(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Generate quest using AI (Claude)
-	questJSON, err := h.aiService.GenerateQuest(r.Context(), "beginner", "finnish", ghostWords)
+	questData, err := h.aiService.GenerateQuest(aiContext(r), "beginner", h.learnedLanguage(userLanguage), ghostWords)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	// Parse quest JSON
-	var questData struct {
-		Title       string `json:"title"`
-		Description string `json:"description"`
-		Solution    string `json:"solution"`
-	}
-	if err := json.Unmarshal([]byte(questJSON), &questData); err != nil {
-		http.Error(w, "Failed to parse quest", http.StatusInternalServerError)
+		writeAIError(w, err)
 		return
 	}
 
 	// Save quest to database
 	var quest models.Quest
 	err = h.db.QueryRow(`
-		INSERT INTO quests (user_id, title, description, solution, difficulty, status)
-		VALUES ($1, $2, $3, $4, 'beginner', 'pending')
-		RETURNING id, user_id, title, description, solution, difficulty, status, created_at, completed_at
-	`, userIDInt, questData.Title, questData.Description, questData.Solution).Scan(
-		&quest.ID, &quest.UserID, &quest.Title, &quest.Description, &quest.Solution, &quest.Difficulty, &quest.Status, &quest.CreatedAt, &quest.CompletedAt,
+		INSERT INTO quests (user_id, title, description, solution, difficulty, status, prompt_version)
+		VALUES ($1, $2, $3, $4, 'beginner', 'pending', $5)
+		RETURNING id, user_id, title, description, solution, difficulty, status, created_at, completed_at, prompt_version
+	`, userIDInt, questData.Title, questData.Description, questData.Solution, questData.PromptVersion).Scan(
+		&quest.ID, &quest.UserID, &quest.Title, &quest.Description, &quest.Solution, &quest.Difficulty, &quest.Status, &quest.CreatedAt, &quest.CompletedAt, &quest.PromptVersion,
 	)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -149,6 +160,8 @@ func (h *QuestHandler) GenerateQuest(w http.ResponseWriter, r *http.Request) {
 // @Failure 401 {object} map[string]string "Unauthorized"
 // @Failure 404 {object} map[string]string "Quest not found"
 // @Failure 500 {object} map[string]string "Validation failed"
+// @Failure 429 {object} map[string]string "AI usage quota exceeded"
+// @Failure 503 {object} map[string]string "All AI providers unavailable"
 // @Router /users/{userID}/quests/validate [post]
 func (h *QuestHandler) ValidateQuest(w http.ResponseWriter, r *http.Request) {
 	var req models.QuestValidationRequest
@@ -157,30 +170,34 @@ func (h *QuestHandler) ValidateQuest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get quest details
+	// Get quest details, and the language its author learns
 	var quest models.Quest
+	var userLanguage string
 	err := h.db.QueryRow(`
-		SELECT id, title, description FROM quests WHERE id = $1
-	`, req.QuestID).Scan(&quest.ID, &quest.Title, &quest.Description)
+		SELECT q.id, q.title, q.description, u.language
+		FROM quests q
+		JOIN users u ON u.id = q.user_id
+		WHERE q.id = $1
+	`, req.QuestID).Scan(&quest.ID, &quest.Title, &quest.Description, &userLanguage)
 	if err != nil {
 		http.Error(w, "Quest not found", http.StatusNotFound)
 		return
 	}
 
 	// Validate with AI
-	isValid, feedback, err := h.aiService.ValidateQuestSubmission(
-		r.Context(),
+	verdict, err := h.aiService.ValidateQuestSubmission(
+		aiContext(r),
 		quest.Description,
 		req.UserText,
-		"finnish",
+		h.learnedLanguage(userLanguage),
 	)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeAIError(w, err)
 		return
 	}
 
 	// If valid, mark quest as completed
-	if isValid {
+	if verdict.IsValid {
 		_, err = h.db.Exec(`
 			UPDATE quests
 			SET status = 'completed', completed_at = NOW()
@@ -193,10 +210,88 @@ func (h *QuestHandler) ValidateQuest(w http.ResponseWriter, r *http.Request) {
 	}
 
 	response := models.QuestValidationResponse{
-		IsValid:  isValid,
-		Feedback: feedback,
+		IsValid:  verdict.IsValid,
+		Feedback: verdict.Feedback,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
+
+// ValidateQuestStream validates a quest submission and streams the feedback
+// @Summary Validate quest submission (streaming)
+// @Description Like /validate, but the Socratic feedback is streamed over Server-Sent Events while it is generated.
+// @Description Events: "token" ({"text"}) carries the next piece of feedback; "reset" means a provider failed mid-answer and the feedback shown so far must be discarded;
+// @Description "verdict" (QuestValidationResponse) closes the stream; "error" ({"error", "status"}) ends it on failure.
+// @Description Use fetch with a streaming body reader, since EventSource only supports GET.
+// @Tags Quests
+// @Accept json
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param request body models.QuestValidationRequest true "Quest validation data"
+// @Success 200 {object} models.QuestValidationResponse "Event stream ending with the verdict"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Quest not found"
+// @Failure 429 {object} map[string]string "AI usage quota exceeded"
+// @Failure 500 {object} map[string]string "Streaming not supported"
+// @Failure 503 {object} map[string]string "All AI providers unavailable"
+// @Router /users/{userID}/quests/validate/stream [post]
+func (h *QuestHandler) ValidateQuestStream(w http.ResponseWriter, r *http.Request) {
+	var req models.QuestValidationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	stream, ok := newSSEWriter(w)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	// Get quest details, and the language its author learns
+	var quest models.Quest
+	var userLanguage string
+	err := h.db.QueryRow(`
+		SELECT q.id, q.title, q.description, u.language
+		FROM quests q
+		JOIN users u ON u.id = q.user_id
+		WHERE q.id = $1
+	`, req.QuestID).Scan(&quest.ID, &quest.Title, &quest.Description, &userLanguage)
+	if err != nil {
+		http.Error(w, "Quest not found", http.StatusNotFound)
+		return
+	}
+
+	// Validate with AI, forwarding feedback as it is written
+	verdict, err := h.aiService.StreamQuestValidation(
+		aiContext(r),
+		quest.Description,
+		req.UserText,
+		h.learnedLanguage(userLanguage),
+		stream,
+	)
+	if err != nil {
+		stream.Error(err)
+		return
+	}
+
+	// If valid, mark quest as completed
+	if verdict.IsValid {
+		_, err = h.db.Exec(`
+			UPDATE quests
+			SET status = 'completed', completed_at = NOW()
+			WHERE id = $1
+		`, req.QuestID)
+		if err != nil {
+			stream.Error(err)
+			return
+		}
+	}
+
+	stream.Event("verdict", models.QuestValidationResponse{
+		IsValid:  verdict.IsValid,
+		Feedback: verdict.Feedback,
+	})
+}
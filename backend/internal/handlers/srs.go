@@ -50,7 +50,7 @@ func (h *SRSHandler) GetDueWords(w http.ResponseWriter, r *http.Request) {
 	// Query words due for review
 	query := `
 		SELECT id, user_id, word, lemma, language, definition, part_of_speech,
-		       examples, status, added_at, mastered_at,
+		       examples, COALESCE(context, ''), status, added_at, mastered_at,
 		       ease_factor, repetition_count, interval, next_review_at, last_reviewed_at
 		FROM words
 		WHERE user_id = $1
@@ -74,7 +74,7 @@ func (h *SRSHandler) GetDueWords(w http.ResponseWriter, r *http.Request) {
 
 		err := rows.Scan(
 			&word.ID, &word.UserID, &word.Word, &word.Lemma, &word.Language,
-			&word.Definition, &word.PartOfSpeech, &examples, &word.Status,
+			&word.Definition, &word.PartOfSpeech, &examples, &word.Context, &word.Status,
 			&word.AddedAt, &word.MasteredAt,
 			&word.EaseFactor, &word.RepetitionCount, &word.Interval,
 			&word.NextReviewAt, &word.LastReviewedAt,
@@ -140,13 +140,13 @@ func (h *SRSHandler) SubmitReview(w http.ResponseWriter, r *http.Request) {
 
 	err := h.db.QueryRow(`
 		SELECT id, user_id, word, lemma, language, definition, part_of_speech,
-		       examples, status, added_at, mastered_at,
+		       examples, COALESCE(context, ''), status, added_at, mastered_at,
 		       ease_factor, repetition_count, interval, next_review_at, last_reviewed_at
 		FROM words
 		WHERE id = $1 AND user_id = $2
 	`, req.WordID, userID).Scan(
 		&word.ID, &word.UserID, &word.Word, &word.Lemma, &word.Language,
-		&word.Definition, &word.PartOfSpeech, &examples, &word.Status,
+		&word.Definition, &word.PartOfSpeech, &examples, &word.Context, &word.Status,
 		&word.AddedAt, &word.MasteredAt,
 		&word.EaseFactor, &word.RepetitionCount, &word.Interval,
 		&word.NextReviewAt, &word.LastReviewedAt,
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// sseWriter sends Server-Sent Events. Headers are written with the first
+// event, so a handler can still answer with a plain HTTP error before that.
+type sseWriter struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+	started bool
+}
+
+func newSSEWriter(w http.ResponseWriter) (*sseWriter, bool) {
+	flusher, ok := w.(http.Flusher)
+	return &sseWriter{w: w, flusher: flusher}, ok
+}
+
+// Event sends one event with data encoded as JSON
+func (s *sseWriter) Event(name string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if !s.started {
+		s.w.Header().Set("Content-Type", "text/event-stream")
+		s.w.Header().Set("Cache-Control", "no-cache")
+		s.w.Header().Set("Connection", "keep-alive")
+		s.w.Header().Set("X-Accel-Buffering", "no") // stop nginx from buffering the stream
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+	}
+
+	if _, err := fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", name, payload); err != nil {
+		return err
+	}
+	s.flusher.Flush()
+	return nil
+}
+
+// Text implements ai.StreamSink
+func (s *sseWriter) Text(fragment string) error {
+	return s.Event("token", map[string]string{"text": fragment})
+}
+
+// Reset implements ai.StreamSink
+func (s *sseWriter) Reset() error {
+	return s.Event("reset", map[string]string{})
+}
+
+// Error reports a failure: as an HTTP error if nothing was streamed yet,
+// otherwise as a final "error" event
+func (s *sseWriter) Error(err error) {
+	if !s.started {
+		writeAIError(s.w, err)
+		return
+	}
+	s.Event("error", map[string]interface{}{
+		"error":  err.Error(),
+		"status": aiErrorStatus(err),
+	})
+}
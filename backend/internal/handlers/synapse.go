@@ -3,19 +3,23 @@ package handlers
 import (
 	"database/sql"
 	"encoding/json"
+	"log"
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/BachirKhiati/lexia/internal/database"
 	"github.com/BachirKhiati/lexia/internal/models"
+	"github.com/BachirKhiati/lexia/internal/services/language"
 )
 
 type SynapseHandler struct {
-	db *database.DB
+	db        *database.DB
+	languages *language.Registry
 }
 
-func NewSynapseHandler(db *database.DB) *SynapseHandler {
-	return &SynapseHandler{db: db}
+func NewSynapseHandler(db *database.DB, languages *language.Registry) *SynapseHandler {
+	return &SynapseHandler{db: db, languages: languages}
 }
 
 // GetMindMap returns the user's complete knowledge graph
@@ -92,28 +96,135 @@ func (h *SynapseHandler) AddWord(w http.ResponseWriter, r *http.Request) {
 		PartOfSpeech string   `json:"part_of_speech"`
 		Examples     []string `json:"examples"`
 		Language     string   `json:"language"`
+		// PromptVersion comes from the analyzer when the definition is AI-generated
+		PromptVersion string `json:"prompt_version"`
+		// Context is the sentence the learner found the word in
+		Context string `json:"context"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Invalid request", http.StatusBadRequest)
 		return
 	}
+	if !resolveLanguage(w, h.languages, &req.Language) {
+		return
+	}
 
 	// Insert word as "ghost" status
 	var wordID int
 	err := h.db.QueryRow(`
-		INSERT INTO words (user_id, word, lemma, definition, part_of_speech, examples, language, status)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, 'ghost')
+		INSERT INTO words (user_id, word, lemma, definition, part_of_speech, examples, language, status, prompt_version, context)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, 'ghost', NULLIF($8, ''), NULLIF($9, ''))
 		RETURNING id
-	`, userID, req.Word, req.Lemma, req.Definition, req.PartOfSpeech, req.Examples, req.Language).Scan(&wordID)
+	`, userID, req.Word, req.Lemma, req.Definition, req.PartOfSpeech, req.Examples, req.Language, req.PromptVersion, req.Context).Scan(&wordID)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	// Linking compounds is a nicety: the word is saved either way
+	lemma := req.Lemma
+	if lemma == "" {
+		lemma = req.Word
+	}
+	lang, _ := h.languages.Lookup(req.Language)
+	linked, err := h.linkCompounds(userID, wordID, lemma, lang)
+	if err != nil {
+		log.Printf("⚠️  Linking compound parts of '%s' failed: %v", lemma, err)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"id":     wordID,
-		"status": "ghost",
+		"id":           wordID,
+		"status":       "ghost",
+		"linked_words": linked,
 	})
 }
+
+// linkCompounds relates a new word to the user's words it is compounded
+// of, and the user's compounds to it if it is one of their parts: adding
+// työpaikka links it to työ and paikka, and adding paikka links
+// työpaikka to it. Relations that exist already are not made again. It
+// returns the number of relations made.
+func (h *SynapseHandler) linkCompounds(userID string, wordID int, lemma string, lang language.Language) (int, error) {
+	lemma = strings.ToLower(strings.TrimSpace(lemma))
+	linked := 0
+	link := func(compoundID int, part string) error {
+		result, err := h.db.Exec(`
+			INSERT INTO word_relations (user_id, source_word_id, target_word_id, relation_type)
+			SELECT $1, $2, w.id, 'compound_part'
+			FROM words w
+			WHERE w.user_id = $1 AND w.language = $3 AND w.id <> $2
+				AND LOWER(COALESCE(NULLIF(w.lemma, ''), w.word)) = $4
+				AND NOT EXISTS (
+					SELECT 1 FROM word_relations r
+					WHERE r.source_word_id = $2 AND r.target_word_id = w.id AND r.relation_type = 'compound_part'
+				)
+		`, userID, compoundID, lang.Name(), part)
+		if err != nil {
+			return err
+		}
+		n, _ := result.RowsAffected()
+		linked += int(n)
+		return nil
+	}
+
+	seen := map[string]bool{lemma: true}
+	for _, part := range lang.SplitCompound(lemma) {
+		if seen[part.Lemma] {
+			continue
+		}
+		seen[part.Lemma] = true
+		if err := link(wordID, part.Lemma); err != nil {
+			return linked, err
+		}
+	}
+
+	// Only words that contain the new word can be compounds of it
+	rows, err := h.db.Query(`
+		SELECT id, COALESCE(NULLIF(lemma, ''), word)
+		FROM words
+		WHERE user_id = $1 AND language = $2 AND id <> $3
+			AND STRPOS(LOWER(COALESCE(NULLIF(lemma, ''), word)), $4) > 0
+			AND LOWER(COALESCE(NULLIF(lemma, ''), word)) <> $4
+	`, userID, lang.Name(), wordID, lemma)
+	if err != nil {
+		return linked, err
+	}
+	var compounds []int
+	for rows.Next() {
+		var id int
+		var compound string
+		if err := rows.Scan(&id, &compound); err != nil {
+			rows.Close()
+			return linked, err
+		}
+		for _, part := range lang.SplitCompound(compound) {
+			if part.Lemma == lemma {
+				compounds = append(compounds, id)
+				break
+			}
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return linked, err
+	}
+
+	for _, id := range compounds {
+		result, err := h.db.Exec(`
+			INSERT INTO word_relations (user_id, source_word_id, target_word_id, relation_type)
+			SELECT $1, $2, $3, 'compound_part'
+			WHERE NOT EXISTS (
+				SELECT 1 FROM word_relations
+				WHERE source_word_id = $2 AND target_word_id = $3 AND relation_type = 'compound_part'
+			)
+		`, userID, id, wordID)
+		if err != nil {
+			return linked, err
+		}
+		n, _ := result.RowsAffected()
+		linked += int(n)
+	}
+	return linked, nil
+}
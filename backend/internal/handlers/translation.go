@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/BachirKhiati/lexia/internal/middleware"
+	"github.com/BachirKhiati/lexia/internal/models"
+	"github.com/BachirKhiati/lexia/internal/services/language"
+	"github.com/BachirKhiati/lexia/internal/services/translation"
+)
+
+type TranslationHandler struct {
+	translationService *translation.Service
+	languages          *language.Registry
+}
+
+func NewTranslationHandler(translationService *translation.Service, languages *language.Registry) *TranslationHandler {
+	return &TranslationHandler{translationService: translationService, languages: languages}
+}
+
+// writeTranslationError maps translation service errors to HTTP status codes
+func writeTranslationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, translation.ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, translation.ErrEmptyText), errors.Is(err, translation.ErrTextTooLong):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		writeAIError(w, err)
+	}
+}
+
+// Translate translates a sentence or passage
+// @Summary Translate text
+// @Description Translate a sentence or passage. Translations are kept in the user's translation memory: an identical input (same text and languages, ignoring spacing) returns the stored translation, including the user's edits, without calling the AI. New translations also list the user's pinned translations of text found inside the input.
+// @Tags Translation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.TranslateRequest true "Text and languages"
+// @Success 200 {object} models.TranslateResponse "Translation"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 429 {object} map[string]string "AI usage quota exceeded"
+// @Failure 503 {object} map[string]string "All AI providers unavailable"
+// @Router /translate [post]
+func (h *TranslationHandler) Translate(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.TranslateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+	// The source is a language being learned; the target can be any language
+	if !resolveLanguage(w, h.languages, &req.FromLanguage) {
+		return
+	}
+
+	result, err := h.translationService.Translate(aiContext(r), claims.UserID, req)
+	if err != nil {
+		writeTranslationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// SearchTranslations lists the user's translation memory
+// @Summary Search translation memory
+// @Description List stored translations, pinned ones first, then the most recently changed
+// @Tags Translation
+// @Produce json
+// @Security BearerAuth
+// @Param q query string false "Text to find in the source or the translation"
+// @Param from_language query string false "Only this source language"
+// @Param to_language query string false "Only this target language"
+// @Param pinned query bool false "Only pinned translations"
+// @Param limit query int false "Maximum number of entries (default 50, at most 200)"
+// @Success 200 {array} models.TranslationEntry "Stored translations"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Database error"
+// @Router /translations [get]
+func (h *TranslationHandler) SearchTranslations(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	filter := translation.SearchFilter{
+		Query:          query.Get("q"),
+		SourceLanguage: query.Get("from_language"),
+		TargetLanguage: query.Get("to_language"),
+	}
+	filter.PinnedOnly, _ = strconv.ParseBool(query.Get("pinned"))
+	filter.Limit, _ = strconv.Atoi(query.Get("limit"))
+
+	entries, err := h.translationService.Search(r.Context(), claims.UserID, filter)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entries)
+}
+
+// GetTranslation returns one stored translation
+// @Summary Get a stored translation
+// @Tags Translation
+// @Produce json
+// @Security BearerAuth
+// @Param translationID path int true "Translation ID"
+// @Success 200 {object} models.TranslationEntry "Stored translation"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Translation not found"
+// @Router /translations/{translationID} [get]
+func (h *TranslationHandler) GetTranslation(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.Atoi(chi.URLParam(r, "translationID"))
+	if err != nil {
+		http.Error(w, "Invalid translation ID", http.StatusBadRequest)
+		return
+	}
+
+	entry, err := h.translationService.Get(r.Context(), claims.UserID, id)
+	if err != nil {
+		writeTranslationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entry)
+}
+
+// UpdateTranslation edits or pins a stored translation
+// @Summary Edit or pin a translation
+// @Description Replace the stored translation with the user's own wording, and/or pin it as the preferred rendering. The AI's original stays available as machine_translation. Later identical inputs return the edited translation.
+// @Tags Translation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param translationID path int true "Translation ID"
+// @Param request body models.UpdateTranslationRequest true "New wording and/or pin state"
+// @Success 200 {object} models.TranslationEntry "Updated translation"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Translation not found"
+// @Router /translations/{translationID} [put]
+func (h *TranslationHandler) UpdateTranslation(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.Atoi(chi.URLParam(r, "translationID"))
+	if err != nil {
+		http.Error(w, "Invalid translation ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.UpdateTranslationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	entry, err := h.translationService.Update(r.Context(), claims.UserID, id, req)
+	if err != nil {
+		writeTranslationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entry)
+}
+
+// DeleteTranslation removes a stored translation
+// @Summary Delete a stored translation
+// @Tags Translation
+// @Security BearerAuth
+// @Param translationID path int true "Translation ID"
+// @Success 204 "Deleted"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Translation not found"
+// @Router /translations/{translationID} [delete]
+func (h *TranslationHandler) DeleteTranslation(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.Atoi(chi.URLParam(r, "translationID"))
+	if err != nil {
+		http.Error(w, "Invalid translation ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.translationService.Delete(r.Context(), claims.UserID, id); err != nil {
+		writeTranslationError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
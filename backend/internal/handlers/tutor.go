@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/BachirKhiati/lexia/internal/middleware"
+	"github.com/BachirKhiati/lexia/internal/models"
+	"github.com/BachirKhiati/lexia/internal/services/language"
+	"github.com/BachirKhiati/lexia/internal/services/tutor"
+	"github.com/go-chi/chi/v5"
+)
+
+type TutorHandler struct {
+	tutorService *tutor.Service
+	languages    *language.Registry
+}
+
+func NewTutorHandler(tutorService *tutor.Service, languages *language.Registry) *TutorHandler {
+	return &TutorHandler{tutorService: tutorService, languages: languages}
+}
+
+// writeTutorError maps tutor service errors to HTTP status codes
+func writeTutorError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, tutor.ErrSessionNotFound), errors.Is(err, tutor.ErrContextNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, tutor.ErrEmptyMessage):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		writeAIError(w, err)
+	}
+}
+
+// CreateSession starts a tutor conversation
+// @Summary Start a tutor session
+// @Description Start a Socratic tutor conversation, optionally about one of the user's quests or articles. If a first message is given, the response includes the tutor's reply.
+// @Tags Tutor
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CreateTutorSessionRequest true "Session settings and optional first message"
+// @Success 201 {object} models.TutorSession "Created session"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Quest or article not found"
+// @Failure 429 {object} map[string]string "AI usage quota exceeded"
+// @Failure 503 {object} map[string]string "All AI providers unavailable"
+// @Router /tutor/sessions [post]
+func (h *TutorHandler) CreateSession(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.CreateTutorSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+	if !resolveLanguage(w, h.languages, &req.Language) {
+		return
+	}
+
+	session, err := h.tutorService.StartSession(aiContext(r), claims.UserID, req)
+	if err != nil {
+		writeTutorError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(session)
+}
+
+// SendMessage continues a tutor conversation
+// @Summary Send a message to the tutor
+// @Description Add the learner's message to a session and return the tutor's reply. Earlier turns are sent along, summarized when the conversation gets long.
+// @Tags Tutor
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param sessionID path int true "Session ID"
+// @Param request body models.TutorMessageRequest true "Learner message"
+// @Success 200 {object} models.TutorTurn "Stored message and tutor reply"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Session not found"
+// @Failure 429 {object} map[string]string "AI usage quota exceeded"
+// @Failure 503 {object} map[string]string "All AI providers unavailable"
+// @Router /tutor/sessions/{sessionID}/messages [post]
+func (h *TutorHandler) SendMessage(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	sessionID, err := strconv.Atoi(chi.URLParam(r, "sessionID"))
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.TutorMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	turn, err := h.tutorService.Continue(aiContext(r), claims.UserID, sessionID, req.Message)
+	if err != nil {
+		writeTutorError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(turn)
+}
+
+// GetSession returns a tutor session with its messages
+// @Summary Get a tutor session
+// @Description Return a tutor session with the full message history
+// @Tags Tutor
+// @Produce json
+// @Security BearerAuth
+// @Param sessionID path int true "Session ID"
+// @Success 200 {object} models.TutorSession "Session with messages"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Session not found"
+// @Router /tutor/sessions/{sessionID} [get]
+func (h *TutorHandler) GetSession(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	sessionID, err := strconv.Atoi(chi.URLParam(r, "sessionID"))
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+
+	session, err := h.tutorService.GetSession(r.Context(), claims.UserID, sessionID)
+	if err != nil {
+		writeTutorError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(session)
+}
+
+// ListSessions returns the user's tutor sessions
+// @Summary List tutor sessions
+// @Description List the user's tutor sessions, most recently active first
+// @Tags Tutor
+// @Produce json
+// @Security BearerAuth
+// @Param quest_id query int false "Only sessions about this quest"
+// @Param article_id query int false "Only sessions about this article"
+// @Param limit query int false "Maximum number of sessions (default 50, at most 200)"
+// @Success 200 {array} models.TutorSession "Sessions without messages"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Database error"
+// @Router /tutor/sessions [get]
+func (h *TutorHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	var filter tutor.ListFilter
+	filter.QuestID, _ = strconv.Atoi(query.Get("quest_id"))
+	filter.ArticleID, _ = strconv.Atoi(query.Get("article_id"))
+	filter.Limit, _ = strconv.Atoi(query.Get("limit"))
+
+	sessions, err := h.tutorService.ListSessions(r.Context(), claims.UserID, filter)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sessions)
+}
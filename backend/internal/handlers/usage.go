@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/BachirKhiati/lexia/internal/middleware"
+	"github.com/BachirKhiati/lexia/internal/services/ai"
+)
+
+type UsageHandler struct {
+	aiService *ai.Service
+}
+
+func NewUsageHandler(aiService *ai.Service) *UsageHandler {
+	return &UsageHandler{aiService: aiService}
+}
+
+// GetUsage returns the current user's AI usage and quotas
+// @Summary Get AI usage
+// @Description Token usage and estimated cost for today and this month, broken down by task, with the configured quotas
+// @Tags Progress
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} ai.UsageSummary "Usage summary"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Failed to load usage"
+// @Router /ai/usage [get]
+func (h *UsageHandler) GetUsage(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	summary, err := h.aiService.UsageSummary(r.Context(), claims.UserID)
+	if err != nil {
+		http.Error(w, "Failed to load usage", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(summary)
+}
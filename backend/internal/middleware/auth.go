@@ -68,3 +68,28 @@ func GetUserFromContext(r *http.Request) (*auth.Claims, bool) {
 	claims, ok := r.Context().Value(UserContextKey).(*auth.Claims)
 	return claims, ok
 }
+
+// RequireAdmin only lets through authenticated users whose email is listed
+// in adminEmails. It must run after Auth.
+func RequireAdmin(adminEmails []string) func(http.Handler) http.Handler {
+	admins := make(map[string]bool, len(adminEmails))
+	for _, email := range adminEmails {
+		admins[strings.ToLower(email)] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := GetUserFromContext(r)
+			if !ok {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			if !admins[strings.ToLower(claims.Email)] {
+				log.Printf("[AUTH] Non-admin user %d denied access to %s", claims.UserID, r.URL.Path)
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -25,6 +25,7 @@ type Quest struct {
 	Status      string    `json:"status"` // pending, in_progress, completed
 	CreatedAt   time.Time `json:"created_at"`
 	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	PromptVersion string  `json:"prompt_version,omitempty"` // prompt template revision that generated it
 }
 
 // Word represents a vocabulary item in the knowledge graph
@@ -37,6 +38,7 @@ type Word struct {
 	Definition   string    `json:"definition"`
 	PartOfSpeech string    `json:"part_of_speech"` // noun, verb, adjective, etc.
 	Examples     []string  `json:"examples"`
+	Context      string    `json:"context,omitempty"` // Sentence the word was found in
 	Status       string    `json:"status"` // ghost (discovered), solid (mastered)
 	AddedAt      time.Time `json:"added_at"`
 	MasteredAt   *time.Time `json:"mastered_at,omitempty"`
@@ -52,12 +54,52 @@ type Word struct {
 type WordConjugation struct {
 	ID       int    `json:"id"`
 	WordID   int    `json:"word_id"`
-	Tense    string `json:"tense"` // present, past, perfect, etc.
-	Person   string `json:"person"` // 1sg, 2sg, 3sg, 1pl, 2pl, 3pl
+	Mood     string `json:"mood"` // indicative, conditional, imperative, potential, infinitive, participle
+	Tense    string `json:"tense"` // present, past, perfect, pluperfect
+	Voice    string `json:"voice"` // active, passive
+	Polarity string `json:"polarity"` // affirmative, negative
+	Person   string `json:"person"` // 1sg, 2sg, 3sg, 1pl, 2pl, 3pl; empty for passive and non-finite forms
+	Variant  string `json:"variant,omitempty"` // which infinitive or participle: "third illative", "agent"
 	Form     string `json:"form"` // The conjugated form
 	Language string `json:"language"`
 }
 
+// WordDeclension is one case form of a noun or adjective
+type WordDeclension struct {
+	Case         string `json:"case"`   // nominative, genitive, partitive... (15 cases)
+	Number       string `json:"number"` // singular, plural
+	Definiteness string `json:"definiteness,omitempty"` // Swedish: indefinite, definite
+	Gender       string `json:"gender,omitempty"`       // Swedish adjectives: common, neuter
+	Degree       string `json:"degree,omitempty"`       // Swedish adjectives: positive, comparative, superlative
+	Form         string `json:"form"`
+}
+
+// MorphAnalysis is one reading of an inflected word as a form of its lemma
+type MorphAnalysis struct {
+	Lemma        string   `json:"lemma"`
+	PartOfSpeech string   `json:"part_of_speech"`
+	Case         string   `json:"case,omitempty"`       // Nouns, adjectives and pronouns
+	Number       string   `json:"number,omitempty"`     // singular, plural
+	Mood         string   `json:"mood,omitempty"`       // Verbs
+	Tense        string   `json:"tense,omitempty"`
+	Voice        string   `json:"voice,omitempty"`
+	Person       string   `json:"person,omitempty"`     // 1sg ... 3pl
+	Variant      string   `json:"variant,omitempty"`    // Which infinitive or participle
+	Definiteness string   `json:"definiteness,omitempty"` // Swedish: indefinite, definite
+	Gender       string   `json:"gender,omitempty"`
+	Degree       string   `json:"degree,omitempty"`
+	Possessive   string   `json:"possessive,omitempty"` // Possessive suffix: 1sg, 2sg, 1pl, 2pl, 3
+	Clitics      []string `json:"clitics,omitempty"`    // Particles such as ko, kin, han
+	Guessed      bool     `json:"guessed,omitempty"`    // The lemma is not in the bundled lexicon
+}
+
+// CompoundPart is one of the words a compound is made of, as it is written
+// in the compound and as its lemma
+type CompoundPart struct {
+	Form  string `json:"form"`
+	Lemma string `json:"lemma"`
+}
+
 // WordRelation represents connections in the mind map
 type WordRelation struct {
 	ID           int       `json:"id"`
@@ -105,8 +147,39 @@ type AnalyzerResponse struct {
 	PartOfSpeech string              `json:"part_of_speech"`
 	Examples     []string            `json:"examples"`
 	Conjugations []WordConjugation   `json:"conjugations,omitempty"`
+	Declensions  []WordDeclension    `json:"declensions,omitempty"` // Case forms of a noun or adjective
+	KotusType    int                 `json:"kotus_type,omitempty"`  // Kotus inflection type (1-49) of a declined word
+	Gender       string              `json:"gender,omitempty"`      // Gender of a Swedish noun: common (en) or neuter (ett)
+	Morphology   []MorphAnalysis     `json:"morphology,omitempty"`  // Readings of Word as a form of Lemma
+	Compound     []CompoundPart      `json:"compound,omitempty"`    // Parts of a compound word, in order
 	AudioURL     string              `json:"audio_url,omitempty"`
+	ExampleAudioURLs []string        `json:"example_audio_urls,omitempty"` // Audio of each example, same order; empty where none
 	InSynapse    bool                `json:"in_synapse"` // Is this word already in user's mind map?
+	PromptVersion string             `json:"prompt_version,omitempty"` // Set when the definition came from AI
+	Context      string              `json:"context,omitempty"`      // Sentence the word was looked up in
+	OtherSenses  []string            `json:"other_senses,omitempty"` // Next best senses, best first
+}
+
+// BatchAnalyzeRequest asks for the analysis of every word of a text
+type BatchAnalyzeRequest struct {
+	Text     string `json:"text"`
+	Language string `json:"language"`
+}
+
+// BatchTokenResult is the analysis of one distinct word of a text. Error is
+// set instead of Analysis when that word could not be analyzed.
+type BatchTokenResult struct {
+	Token       string            `json:"token"`
+	Occurrences int               `json:"occurrences"`
+	Analysis    *AnalyzerResponse `json:"analysis,omitempty"`
+	Error       string            `json:"error,omitempty"`
+}
+
+// BatchAnalyzeResponse lists the distinct words of a text in order of first appearance
+type BatchAnalyzeResponse struct {
+	Language string             `json:"language"`
+	Tokens   []BatchTokenResult `json:"tokens"`
+	Failed   int                `json:"failed"`
 }
 
 // QuestValidationRequest validates user's quest submission
@@ -157,3 +230,130 @@ type ReviewResponse struct {
 	NextInterval int    `json:"next_interval"` // days until next review
 	Message      string `json:"message"`
 }
+
+// TutorSession is a conversation with the Socratic tutor
+type TutorSession struct {
+	ID                int            `json:"id"`
+	UserID            int            `json:"user_id"`
+	Language          string         `json:"language"`
+	Level             string         `json:"level"`
+	QuestID           *int           `json:"quest_id,omitempty"`
+	ArticleID         *int           `json:"article_id,omitempty"`
+	Title             string         `json:"title"`
+	Summary           string         `json:"summary,omitempty"` // condensed older turns
+	SummarizedThrough int            `json:"-"`                 // last message ID folded into Summary
+	MessageCount      int            `json:"message_count"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	Messages          []TutorMessage `json:"messages,omitempty"`
+}
+
+// TutorMessage is one turn of a tutor session
+type TutorMessage struct {
+	ID        int       `json:"id"`
+	SessionID int       `json:"session_id"`
+	Role      string    `json:"role"` // user or assistant
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateTutorSessionRequest starts a tutor session
+type CreateTutorSessionRequest struct {
+	Language  string `json:"language"`
+	Level     string `json:"level,omitempty"`      // defaults to beginner
+	QuestID   *int   `json:"quest_id,omitempty"`   // discuss one of the user's quests
+	ArticleID *int   `json:"article_id,omitempty"` // discuss one of the user's articles
+	Message   string `json:"message,omitempty"`    // optional first message
+}
+
+// TutorMessageRequest continues a tutor session
+type TutorMessageRequest struct {
+	Message string `json:"message"`
+}
+
+// TutorTurn is the learner's message and the tutor's reply
+type TutorTurn struct {
+	Message TutorMessage `json:"message"`
+	Reply   TutorMessage `json:"reply"`
+}
+
+// TranslateRequest asks for the translation of a sentence or passage
+type TranslateRequest struct {
+	Text         string `json:"text"`
+	FromLanguage string `json:"from_language"` // defaults to finnish
+	ToLanguage   string `json:"to_language"`   // defaults to english
+}
+
+// TranslationEntry is one translation in a learner's translation memory
+type TranslationEntry struct {
+	ID                 int       `json:"id"`
+	UserID             int       `json:"user_id"`
+	SourceLanguage     string    `json:"source_language"`
+	TargetLanguage     string    `json:"target_language"`
+	SourceText         string    `json:"source_text"`
+	SourceHash         string    `json:"-"` // identifies identical inputs
+	Translation        string    `json:"translation"`         // the learner's version once edited
+	MachineTranslation string    `json:"machine_translation"` // what the AI first returned
+	Edited             bool      `json:"edited"`
+	Pinned             bool      `json:"pinned"` // the learner's preferred rendering
+	UseCount           int       `json:"use_count"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// TranslateResponse is a translation and where it came from
+type TranslateResponse struct {
+	Entry  *TranslationEntry `json:"entry"`
+	Reused bool              `json:"reused"` // served from the translation memory
+	// PinnedMatches are pinned translations of text found inside the input,
+	// so the learner's preferred renderings can be shown alongside
+	PinnedMatches []TranslationEntry `json:"pinned_matches,omitempty"`
+}
+
+// UpdateTranslationRequest edits or pins a stored translation. Omitted
+// fields are left unchanged.
+type UpdateTranslationRequest struct {
+	Translation *string `json:"translation,omitempty"`
+	Pinned      *bool   `json:"pinned,omitempty"`
+}
+
+// OratorAttemptRequest submits one spoken attempt at a phrase
+type OratorAttemptRequest struct {
+	Target     string `json:"target"`     // phrase the learner was asked to say
+	Transcript string `json:"transcript"` // what speech recognition heard
+	Language   string `json:"language"`
+}
+
+// PronunciationIssue is one sound the learner missed
+type PronunciationIssue struct {
+	Kind     string `json:"kind"` // vowel_length, consonant_length, vowel_quality, substitution, missing or extra
+	Expected string `json:"expected,omitempty"`
+	Heard    string `json:"heard,omitempty"`
+}
+
+// WordScore is how well one word of the target phrase was pronounced
+type WordScore struct {
+	Word        string               `json:"word"`
+	WordID      *int                 `json:"word_id,omitempty"` // the learner's Synapse word, if saved
+	Score       int                  `json:"score"`             // 0-100
+	Issues      []PronunciationIssue `json:"issues"`
+	Rescheduled bool                 `json:"rescheduled,omitempty"` // sent back to SRS review
+}
+
+// OratorAttempt is a scored pronunciation attempt
+type OratorAttempt struct {
+	ID         int         `json:"id"`
+	UserID     int         `json:"user_id"`
+	Language   string      `json:"language"`
+	Target     string      `json:"target"`
+	Transcript string      `json:"transcript"`
+	Score      int         `json:"score"` // 0-100
+	Words      []WordScore `json:"words"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+// GrammarCheckRequest asks for a grammar check of a learner's text
+type GrammarCheckRequest struct {
+	Text     string `json:"text"`
+	Language string `json:"language"`
+}
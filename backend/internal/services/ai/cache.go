@@ -0,0 +1,280 @@
+package ai
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// CacheKey identifies a cached model answer. Changing any part (a new model,
+// a new prompt revision) produces a different key, so stale answers are
+// never served after an upgrade.
+type CacheKey struct {
+	Task          Task
+	Language      string
+	Input         string // already normalized
+	Model         string
+	PromptVersion string
+}
+
+// Hash returns the stable primary key for the entry
+func (k CacheKey) Hash() string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		string(k.Task), k.Language, k.Input, k.Model, k.PromptVersion,
+	}, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// CacheFilter selects entries for invalidation. Empty fields match everything.
+type CacheFilter struct {
+	Task        Task   `json:"task,omitempty"`
+	Language    string `json:"language,omitempty"`
+	Input       string `json:"input,omitempty"`
+	Model       string `json:"model,omitempty"`
+	ExpiredOnly bool   `json:"expired_only,omitempty"`
+}
+
+// CacheStats reports cache effectiveness since the process started
+type CacheStats struct {
+	Enabled bool    `json:"enabled"`
+	Hits    int64   `json:"hits"`
+	Misses  int64   `json:"misses"`
+	HitRate float64 `json:"hit_rate"`
+	Entries int64   `json:"entries"`
+}
+
+// Cache stores model answers across requests and users
+type Cache interface {
+	// Get returns the first live entry among keys, in the given order
+	Get(ctx context.Context, keys []CacheKey) (value []byte, found bool, err error)
+	Set(ctx context.Context, key CacheKey, value []byte, ttl time.Duration) error
+	Invalidate(ctx context.Context, filter CacheFilter) (int64, error)
+	Count(ctx context.Context) (int64, error)
+}
+
+// PostgresCache keeps cached answers in the ai_cache table
+type PostgresCache struct {
+	db *sql.DB
+}
+
+// NewPostgresCache creates a cache backed by the ai_cache table
+func NewPostgresCache(db *sql.DB) *PostgresCache {
+	return &PostgresCache{db: db}
+}
+
+func (c *PostgresCache) Get(ctx context.Context, keys []CacheKey) ([]byte, bool, error) {
+	if len(keys) == 0 {
+		return nil, false, nil
+	}
+
+	hashes := make([]string, len(keys))
+	for i, key := range keys {
+		hashes[i] = key.Hash()
+	}
+
+	// array_position keeps the caller's preference order among several hits
+	var hash string
+	var value []byte
+	err := c.db.QueryRowContext(ctx, `
+		SELECT cache_key, response FROM ai_cache
+		WHERE cache_key = ANY($1) AND expires_at > NOW()
+		ORDER BY array_position($1, cache_key::text)
+		LIMIT 1
+	`, pq.Array(hashes)).Scan(&hash, &value)
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	if _, err := c.db.ExecContext(ctx, `UPDATE ai_cache SET hit_count = hit_count + 1 WHERE cache_key = $1`, hash); err != nil {
+		log.Printf("⚠️  Failed to bump AI cache hit count: %v", err)
+	}
+
+	return value, true, nil
+}
+
+func (c *PostgresCache) Set(ctx context.Context, key CacheKey, value []byte, ttl time.Duration) error {
+	_, err := c.db.ExecContext(ctx, `
+		INSERT INTO ai_cache (cache_key, task, language, input, model, prompt_version, response, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (cache_key) DO UPDATE
+		SET response = EXCLUDED.response, created_at = NOW(), expires_at = EXCLUDED.expires_at
+	`, key.Hash(), string(key.Task), key.Language, key.Input, key.Model, key.PromptVersion, value, time.Now().Add(ttl))
+	return err
+}
+
+func (c *PostgresCache) Invalidate(ctx context.Context, filter CacheFilter) (int64, error) {
+	query, args := invalidateQuery(filter)
+	result, err := c.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// invalidateQuery builds the DELETE for filter. Inputs are matched as they
+// were stored: case-folded for definitions and as typed otherwise, so an
+// input without a task matches either.
+func invalidateQuery(filter CacheFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	add := func(column, value string) {
+		if value == "" {
+			return
+		}
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	add("task", string(filter.Task))
+	add("language", filter.Language)
+	if filter.Task == "" && filter.Input != "" {
+		args = append(args, normalizeInput("", filter.Input), string(TaskDefinition), normalizeInput(TaskDefinition, filter.Input))
+		conditions = append(conditions, fmt.Sprintf("(input = $%d OR task = $%d AND input = $%d)", len(args)-2, len(args)-1, len(args)))
+	} else {
+		add("input", normalizeInput(filter.Task, filter.Input))
+	}
+	add("model", filter.Model)
+	if filter.ExpiredOnly {
+		conditions = append(conditions, "expires_at <= NOW()")
+	}
+
+	query := "DELETE FROM ai_cache"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	return query, args
+}
+
+func (c *PostgresCache) Count(ctx context.Context) (int64, error) {
+	var count int64
+	err := c.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM ai_cache WHERE expires_at > NOW()`).Scan(&count)
+	return count, err
+}
+
+// normalizeInput canonicalizes user input so trivially different requests
+// share an entry. Single words are case-folded; longer text keeps its case.
+func normalizeInput(task Task, input string) string {
+	input = strings.Join(strings.Fields(input), " ")
+	if task == TaskDefinition {
+		input = strings.ToLower(input)
+	}
+	return input
+}
+
+// responseCache wraps a Cache with TTL handling and hit/miss counters
+type responseCache struct {
+	store  Cache
+	ttl    time.Duration
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// SetCache enables caching of definitions, translations and grammar analyses
+func (s *Service) SetCache(store Cache, ttl time.Duration) {
+	s.cache = &responseCache{store: store, ttl: ttl}
+}
+
+// CacheStats reports hit/miss counters and the number of live entries
+func (s *Service) CacheStats(ctx context.Context) CacheStats {
+	if s.cache == nil {
+		return CacheStats{}
+	}
+
+	stats := CacheStats{
+		Enabled: true,
+		Hits:    s.cache.hits.Load(),
+		Misses:  s.cache.misses.Load(),
+	}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRate = float64(stats.Hits) / float64(total)
+	}
+	if count, err := s.cache.store.Count(ctx); err == nil {
+		stats.Entries = count
+	}
+	return stats
+}
+
+// InvalidateCache removes cached answers matching filter
+func (s *Service) InvalidateCache(ctx context.Context, filter CacheFilter) (int64, error) {
+	if s.cache == nil {
+		return 0, fmt.Errorf("AI cache is not enabled")
+	}
+	return s.cache.store.Invalidate(ctx, filter)
+}
+
+// promptVersion identifies the template revision used for a prompt
+func (s *Service) promptVersion(name, language string) string {
+	return s.prompts.Version(name, language)
+}
+
+// withCache serves task from the cache when possible. On a miss it runs the
+// task through the provider chain and stores the answer under the model that
+// produced it.
+func withCache[T any](ctx context.Context, s *Service, task Task, language, input, version string, call func(ctx context.Context, provider AIProvider) (T, error)) (T, error) {
+	var result T
+	if s.cache == nil {
+		err := s.execute(ctx, task, func(ctx context.Context, provider AIProvider) error {
+			var err error
+			result, err = call(ctx, provider)
+			return err
+		})
+		return result, err
+	}
+
+	normalized := normalizeInput(task, input)
+	keyFor := func(model string) CacheKey {
+		return CacheKey{Task: task, Language: language, Input: normalized, Model: model, PromptVersion: version}
+	}
+
+	// An answer from any routed provider will do, preferring the route order
+	var keys []CacheKey
+	for _, name := range s.candidates(task) {
+		if provider, err := s.registry.Get(name); err == nil {
+			keys = append(keys, keyFor(s.modelOf(task, name, provider)))
+		}
+	}
+
+	value, found, err := s.cache.store.Get(ctx, keys)
+	if err != nil {
+		log.Printf("⚠️  AI cache lookup failed for %s: %v", task, err)
+	}
+	if found {
+		if err := json.Unmarshal(value, &result); err == nil {
+			s.cache.hits.Add(1)
+			return result, nil
+		}
+	}
+	s.cache.misses.Add(1)
+
+	var model string
+	err = s.executeNamed(ctx, task, func(ctx context.Context, name string, provider AIProvider) error {
+		var err error
+		result, err = call(ctx, provider)
+		model = s.modelOf(task, name, provider)
+		return err
+	})
+	if err != nil {
+		return result, err
+	}
+
+	value, err = json.Marshal(result)
+	if err == nil {
+		err = s.cache.store.Set(ctx, keyFor(model), value, s.cache.ttl)
+	}
+	if err != nil {
+		log.Printf("⚠️  Failed to store AI cache entry for %s: %v", task, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,127 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// memoryCache is an in-process Cache for tests
+type memoryCache struct {
+	entries map[string][]byte
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{entries: make(map[string][]byte)}
+}
+
+func (c *memoryCache) Get(ctx context.Context, keys []CacheKey) ([]byte, bool, error) {
+	for _, key := range keys {
+		if value, ok := c.entries[key.Hash()]; ok {
+			return value, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func (c *memoryCache) Set(ctx context.Context, key CacheKey, value []byte, ttl time.Duration) error {
+	c.entries[key.Hash()] = value
+	return nil
+}
+
+func (c *memoryCache) Invalidate(ctx context.Context, filter CacheFilter) (int64, error) {
+	removed := int64(len(c.entries))
+	c.entries = make(map[string][]byte)
+	return removed, nil
+}
+
+func (c *memoryCache) Count(ctx context.Context) (int64, error) {
+	return int64(len(c.entries)), nil
+}
+
+// countingProvider counts definition lookups
+type countingProvider struct {
+	namedProvider
+	definitions int
+}
+
+func (p *countingProvider) GetWordDefinition(ctx context.Context, word string, language string) (*DefinitionResult, error) {
+	p.definitions++
+	return &DefinitionResult{Definition: "house", PartOfSpeech: "noun"}, nil
+}
+
+func TestServiceCachesDefinitions(t *testing.T) {
+	provider := &countingProvider{namedProvider: namedProvider{name: "counting"}}
+	registry := NewRegistry()
+	if err := registry.Register("counting", provider); err != nil {
+		t.Fatal(err)
+	}
+	service, err := NewServiceWithRegistry(registry, nil, "counting")
+	if err != nil {
+		t.Fatal(err)
+	}
+	service.SetCache(newMemoryCache(), time.Hour)
+
+	ctx := context.Background()
+	for _, word := range []string{"talo", " Talo ", "TALO"} {
+		result, err := service.GetWordDefinition(ctx, word, "finnish")
+		if err != nil || result.Definition != "house" {
+			t.Fatalf("GetWordDefinition(%q) = %+v, %v", word, result, err)
+		}
+		if result.PromptVersion != "default/definition@2" {
+			t.Errorf("PromptVersion = %q, want default/definition@2", result.PromptVersion)
+		}
+	}
+
+	if provider.definitions != 1 {
+		t.Errorf("provider called %d times, want 1", provider.definitions)
+	}
+
+	stats := service.CacheStats(ctx)
+	if stats.Hits != 2 || stats.Misses != 1 || stats.Entries != 1 {
+		t.Errorf("stats = %+v, want 2 hits, 1 miss, 1 entry", stats)
+	}
+
+	// Another language is a different key
+	if _, err := service.GetWordDefinition(ctx, "talo", "estonian"); err != nil {
+		t.Fatal(err)
+	}
+	if provider.definitions != 2 {
+		t.Errorf("provider called %d times, want 2", provider.definitions)
+	}
+}
+
+func TestCacheKeyIncludesModelAndPromptVersion(t *testing.T) {
+	base := CacheKey{Task: TaskDefinition, Language: "finnish", Input: "talo", Model: "gemini/flash", PromptVersion: "1"}
+
+	otherModel := base
+	otherModel.Model = "claude/sonnet"
+	otherPrompt := base
+	otherPrompt.PromptVersion = "2"
+
+	if base.Hash() == otherModel.Hash() || base.Hash() == otherPrompt.Hash() {
+		t.Error("cache key must change with model and prompt version")
+	}
+}
+
+func TestInvalidateQueryNormalizesInput(t *testing.T) {
+	tests := []struct {
+		filter CacheFilter
+		query  string
+		args   string
+	}{
+		{CacheFilter{}, "DELETE FROM ai_cache", "[]"},
+		{CacheFilter{Task: TaskDefinition, Input: " Talo "}, "DELETE FROM ai_cache WHERE task = $1 AND input = $2", "[definition talo]"},
+		{CacheFilter{Task: TaskTranslation, Input: "Talo"}, "DELETE FROM ai_cache WHERE task = $1 AND input = $2", "[translation Talo]"},
+		// Without a task the case-folded definition row is matched too
+		{CacheFilter{Language: "finnish", Input: "Talo"}, "DELETE FROM ai_cache WHERE language = $1 AND (input = $2 OR task = $3 AND input = $4)", "[finnish Talo definition talo]"},
+	}
+
+	for _, tt := range tests {
+		query, args := invalidateQuery(tt.filter)
+		if query != tt.query || fmt.Sprint(args) != tt.args {
+			t.Errorf("invalidateQuery(%+v) = %q %v, want %q %s", tt.filter, query, args, tt.query, tt.args)
+		}
+	}
+}
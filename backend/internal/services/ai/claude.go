@@ -8,19 +8,28 @@ import (
 	"io"
 	"net/http"
 	"strings"
+
+	"github.com/BachirKhiati/lexia/internal/config"
 )
 
-const claudeAPIURL = "https://api.anthropic.com/v1/messages"
+const (
+	claudeAPIURL = "https://api.anthropic.com/v1/messages"
+	claudeModel  = "claude-3-5-sonnet-20241022"
+)
 
 type ClaudeProvider struct {
 	apiKey     string
+	model      string
 	httpClient *http.Client
 }
 
 type claudeRequest struct {
-	Model     string          `json:"model"`
-	MaxTokens int             `json:"max_tokens"`
-	Messages  []claudeMessage `json:"messages"`
+	Model       string          `json:"model"`
+	MaxTokens   int             `json:"max_tokens"`
+	Temperature float64         `json:"temperature"`
+	System      string          `json:"system,omitempty"`
+	Messages    []claudeMessage `json:"messages"`
+	Stream      bool            `json:"stream,omitempty"`
 }
 
 type claudeMessage struct {
@@ -32,39 +41,113 @@ type claudeResponse struct {
 	Content []struct {
 		Text string `json:"text"`
 	} `json:"content"`
+	Usage struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+func init() {
+	RegisterBackend("claude", func(cfg config.AIConfig) (AIProvider, error) {
+		if cfg.ClaudeAPIKey == "" {
+			return nil, nil
+		}
+		provider, err := NewClaudeProvider(cfg.ClaudeAPIKey, WithModel(cfg.ClaudeModel))
+		if err != nil {
+			return nil, err
+		}
+		return provider, nil
+	}, AllTasks...)
 }
 
-func NewClaudeProvider(apiKey string) (*ClaudeProvider, error) {
+func NewClaudeProvider(apiKey string, opts ...ProviderOption) (*ClaudeProvider, error) {
 	if apiKey == "" {
 		return nil, fmt.Errorf("Claude API key is required")
 	}
 
+	options := applyProviderOptions(opts)
+	model := options.model
+	if model == "" {
+		model = claudeModel
+	}
 	return &ClaudeProvider{
 		apiKey:     apiKey,
-		httpClient: &http.Client{},
+		model:      model,
+		httpClient: options.httpClient,
 	}, nil
 }
 
-func (c *ClaudeProvider) callClaude(ctx context.Context, prompt string) (string, error) {
-	reqBody := claudeRequest{
-		Model:     "claude-3-5-sonnet-20241022",
-		MaxTokens: 1024,
-		Messages: []claudeMessage{
-			{
-				Role:    "user",
-				Content: prompt,
-			},
+// callClaude sends a rendered prompt with its instructions as the system prompt
+func (c *ClaudeProvider) callClaude(ctx context.Context, prompt RenderedPrompt) (string, error) {
+	return c.send(ctx, c.newRequest(ctx, prompt))
+}
+
+// send posts a request and returns the text of the reply
+func (c *ClaudeProvider) send(ctx context.Context, reqBody claudeRequest) (string, error) {
+	resp, err := c.post(ctx, reqBody)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var claudeResp claudeResponse
+	if err := json.Unmarshal(body, &claudeResp); err != nil {
+		return "", fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	reportUsage(ctx, Usage{
+		Provider:     "claude",
+		Model:        reqBody.Model,
+		InputTokens:  claudeResp.Usage.InputTokens,
+		OutputTokens: claudeResp.Usage.OutputTokens,
+	})
+
+	if len(claudeResp.Content) == 0 {
+		return "", fmt.Errorf("%w from Claude", ErrEmptyResponse)
+	}
+
+	return claudeResp.Content[0].Text, nil
+}
+
+func (c *ClaudeProvider) newRequest(ctx context.Context, prompt RenderedPrompt) claudeRequest {
+	reqBody := c.baseRequest(ctx)
+	reqBody.System = prompt.System
+	reqBody.Messages = []claudeMessage{
+		{
+			Role:    "user",
+			Content: prompt.User,
 		},
 	}
+	return reqBody
+}
+
+// baseRequest applies the model settings of the task being executed
+func (c *ClaudeProvider) baseRequest(ctx context.Context) claudeRequest {
+	// 1.0 is the API's own default temperature
+	settings := modelSettings(ctx, ModelSettings{Model: c.model, MaxTokens: 1024, Temperature: 1.0})
+	return claudeRequest{
+		Model:       settings.Model,
+		MaxTokens:   settings.MaxTokens,
+		Temperature: settings.Temperature,
+	}
+}
 
+// post sends a request to the Messages API. Non-200 responses are returned
+// as an *APIError with the body already consumed.
+func (c *ClaudeProvider) post(ctx context.Context, reqBody claudeRequest) (*http.Response, error) {
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", claudeAPIURL, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -73,155 +156,200 @@ func (c *ClaudeProvider) callClaude(ctx context.Context, prompt string) (string,
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("API request failed: %w", err)
+		return nil, fmt.Errorf("API request failed: %w", err)
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &APIError{Provider: "claude", StatusCode: resp.StatusCode, Body: string(body)}
+	}
+	return resp, nil
+}
+
+// claudeStreamEvent covers the fields we use from the Messages streaming events
+type claudeStreamEvent struct {
+	Type    string `json:"type"`
+	Message struct {
+		Usage struct {
+			InputTokens int `json:"input_tokens"`
+		} `json:"usage"`
+	} `json:"message"`
+	Delta struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"delta"`
+	Usage struct {
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+	Error struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// CompleteStream sends a rendered prompt and forwards the reply as it is generated
+func (c *ClaudeProvider) CompleteStream(ctx context.Context, prompt RenderedPrompt, onText func(fragment string) error) (string, error) {
+	reqBody := c.newRequest(ctx, prompt)
+	reqBody.Stream = true
+
+	resp, err := c.post(ctx, reqBody)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return "", err
 	}
+	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+	var text strings.Builder
+	usage := Usage{Provider: "claude", Model: reqBody.Model}
+	defer func() { reportUsage(ctx, usage) }()
+
+	err = readServerSentEvents(resp.Body, func(event, data string) error {
+		var ev claudeStreamEvent
+		if err := json.Unmarshal([]byte(data), &ev); err != nil {
+			return fmt.Errorf("failed to unmarshal stream event: %w", err)
+		}
+
+		switch ev.Type {
+		case "message_start":
+			usage.InputTokens = ev.Message.Usage.InputTokens
+		case "content_block_delta":
+			if ev.Delta.Type == "text_delta" {
+				text.WriteString(ev.Delta.Text)
+				return onText(ev.Delta.Text)
+			}
+		case "message_delta":
+			usage.OutputTokens = ev.Usage.OutputTokens
+		case "error":
+			// Mid-stream errors arrive as events; overloaded_error is Anthropic's 529
+			status := http.StatusInternalServerError
+			if ev.Error.Type == "overloaded_error" {
+				status = 529
+			}
+			return &APIError{Provider: "claude", StatusCode: status, Body: ev.Error.Message}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
 	}
 
-	var claudeResp claudeResponse
-	if err := json.Unmarshal(body, &claudeResp); err != nil {
-		return "", fmt.Errorf("failed to unmarshal response: %w", err)
+	if text.Len() == 0 {
+		return "", fmt.Errorf("%w from Claude", ErrEmptyResponse)
 	}
+	return text.String(), nil
+}
 
-	if len(claudeResp.Content) == 0 {
-		return "", fmt.Errorf("empty response from Claude")
+// Chat sends a conversation with its system prompt as separate messages
+func (c *ClaudeProvider) Chat(ctx context.Context, system string, messages []Message) (string, error) {
+	reqBody := c.baseRequest(ctx)
+	reqBody.System = system
+	for _, m := range messages {
+		reqBody.Messages = append(reqBody.Messages, claudeMessage{Role: m.Role, Content: m.Content})
 	}
+	return c.send(ctx, reqBody)
+}
 
-	return claudeResp.Content[0].Text, nil
+// Model returns the model name used for requests
+func (c *ClaudeProvider) Model() string {
+	return c.model
 }
 
-func (c *ClaudeProvider) GenerateQuest(ctx context.Context, userLevel string, language string, ghostWords []string) (string, error) {
-	ghostWordsStr := strings.Join(ghostWords, ", ")
+// Complete sends a raw prompt and returns the model's text
+func (c *ClaudeProvider) Complete(ctx context.Context, prompt string) (string, error) {
+	return c.callClaude(ctx, RenderedPrompt{User: prompt})
+}
 
-	prompt := fmt.Sprintf(`You are a Socratic language teacher for %s. Generate a short, engaging quest (learning task) for a %s level learner.
+func (c *ClaudeProvider) GenerateQuest(ctx context.Context, userLevel string, language string, ghostWords []string) (*QuestResult, error) {
+	prompt, err := renderPrompt(ctx, string(TaskQuestGeneration), PromptData{Language: language, Level: userLevel, GhostWords: ghostWords})
+	if err != nil {
+		return nil, err
+	}
 
-The quest should:
-1. Be specific and actionable (e.g., "Write 3 sentences about your morning using past tense")
-2. Incorporate these words the user wants to learn: %s
-3. Be appropriate for their level
-4. Include clear success criteria
+	response, err := c.callClaude(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
 
-Return ONLY a JSON object with this structure:
-{
-  "title": "Quest title",
-  "description": "Detailed quest instructions",
-  "solution": "One example solution that demonstrates success"
-}`, language, userLevel, ghostWordsStr)
+	var result QuestResult
+	if err := decodeStructured(ctx, c, QuestSchema, response, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse quest: %w", err)
+	}
 
-	return c.callClaude(ctx, prompt)
+	return &result, nil
 }
 
-func (c *ClaudeProvider) ValidateQuestSubmission(ctx context.Context, quest string, userText string, language string) (bool, string, error) {
-	prompt := fmt.Sprintf(`You are a Socratic %s teacher. A student submitted this text for the following quest:
-
-Quest: %s
-Student's submission: %s
-
-Evaluate their submission and provide Socratic guidance.
-
-Return ONLY a JSON object:
-{
-  "is_valid": true/false,
-  "feedback": "Socratic feedback (guide them, don't just correct)"
-}`, language, quest, userText)
+func (c *ClaudeProvider) ValidateQuestSubmission(ctx context.Context, quest string, userText string, language string) (*ValidationResult, error) {
+	prompt, err := renderPrompt(ctx, string(TaskQuestValidation), PromptData{Language: language, Quest: quest, Text: userText})
+	if err != nil {
+		return nil, err
+	}
 
 	response, err := c.callClaude(ctx, prompt)
 	if err != nil {
-		return false, "", err
+		return nil, err
 	}
 
-	var result struct {
-		IsValid  bool   `json:"is_valid"`
-		Feedback string `json:"feedback"`
-	}
-	if err := json.Unmarshal([]byte(response), &result); err != nil {
-		return false, "", fmt.Errorf("failed to parse validation response: %w", err)
+	var result ValidationResult
+	if err := decodeStructured(ctx, c, ValidationSchema, response, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse validation response: %w", err)
 	}
 
-	return result.IsValid, result.Feedback, nil
+	return &result, nil
 }
 
 func (c *ClaudeProvider) GenerateSocraticFeedback(ctx context.Context, userText string, language string) (string, error) {
-	prompt := fmt.Sprintf(`You are a Socratic %s teacher. The student wrote: "%s"
-
-Provide brief, encouraging Socratic feedback that guides them without giving direct answers.`, language, userText)
+	prompt, err := renderPrompt(ctx, string(TaskSocraticFeedback), PromptData{Language: language, Text: userText})
+	if err != nil {
+		return "", err
+	}
 
 	return c.callClaude(ctx, prompt)
 }
 
 func (c *ClaudeProvider) Translate(ctx context.Context, text string, fromLang string, toLang string) (string, error) {
-	prompt := fmt.Sprintf(`Translate this text from %s to %s: "%s"
-
-Return ONLY the translated text, nothing else.`, fromLang, toLang, text)
+	prompt, err := renderPrompt(ctx, string(TaskTranslation), PromptData{Language: fromLang, FromLanguage: fromLang, ToLanguage: toLang, Text: text})
+	if err != nil {
+		return "", err
+	}
 
 	return c.callClaude(ctx, prompt)
 }
 
-func (c *ClaudeProvider) AnalyzeGrammar(ctx context.Context, text string, language string) (map[string]interface{}, error) {
-	prompt := fmt.Sprintf(`Analyze the grammar of this %s text: "%s"
-
-Return a JSON object with:
-{
-  "correct": true/false,
-  "errors": ["error description"],
-  "suggestions": ["suggestion"]
-}`, language, text)
+func (c *ClaudeProvider) AnalyzeGrammar(ctx context.Context, text string, language string) (*GrammarResult, error) {
+	prompt, err := renderPrompt(ctx, string(TaskGrammar), PromptData{Language: language, Text: text})
+	if err != nil {
+		return nil, err
+	}
 
 	response, err := c.callClaude(ctx, prompt)
 	if err != nil {
 		return nil, err
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal([]byte(response), &result); err != nil {
+	var result GrammarResult
+	if err := decodeStructured(ctx, c, GrammarSchema, response, &result); err != nil {
 		return nil, fmt.Errorf("failed to parse grammar analysis: %w", err)
 	}
 
-	return result, nil
-}
-
-func (c *ClaudeProvider) GetWordDefinition(ctx context.Context, word string, language string) (definition string, partOfSpeech string, examples []string, err error) {
-	prompt := fmt.Sprintf(`You are a dictionary for %s language. Provide a definition for the word "%s".
-
-Return ONLY a JSON object with no additional text:
-{
-  "definition": "Clear, concise definition in English",
-  "part_of_speech": "noun/verb/adjective/etc",
-  "examples": ["Example sentence 1", "Example sentence 2"]
+	return &result, nil
 }
 
-Guidelines:
-- definition: A single clear sentence explaining what the word means
-- part_of_speech: The word's grammatical category (noun, verb, adjective, adverb, etc.)
-- examples: 2-3 realistic example sentences showing how to use the word in context`, language, word)
-
-	response, err := c.callClaude(ctx, prompt)
+func (c *ClaudeProvider) GetWordDefinition(ctx context.Context, word string, language string) (*DefinitionResult, error) {
+	prompt, err := renderPrompt(ctx, string(TaskDefinition), PromptData{Language: language, Word: word})
 	if err != nil {
-		return "", "", nil, fmt.Errorf("failed to get AI definition: %w", err)
-	}
-
-	var result struct {
-		Definition   string   `json:"definition"`
-		PartOfSpeech string   `json:"part_of_speech"`
-		Examples     []string `json:"examples"`
+		return nil, err
 	}
 
-	if err := json.Unmarshal([]byte(response), &result); err != nil {
-		return "", "", nil, fmt.Errorf("failed to parse AI definition response: %w", err)
+	response, err := c.callClaude(ctx, prompt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AI definition: %w", err)
 	}
 
-	// Validate we got meaningful data
-	if result.Definition == "" {
-		return "", "", nil, fmt.Errorf("AI returned empty definition")
+	// The schema rejects an empty definition, so a success is always meaningful
+	var result DefinitionResult
+	if err := decodeStructured(ctx, c, DefinitionSchema, response, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse AI definition response: %w", err)
 	}
 
-	return result.Definition, result.PartOfSpeech, result.Examples, nil
+	return &result, nil
 }
@@ -0,0 +1,149 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Roles of the messages in a conversation
+const (
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// PromptTutorSummary names the prompt that condenses older tutor turns
+const PromptTutorSummary = "tutor_summary"
+
+// Message is one turn of a conversation
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// Chatter is implemented by providers that accept a system prompt and a
+// message history natively. Other providers get the history as one prompt.
+type Chatter interface {
+	Chat(ctx context.Context, system string, messages []Message) (string, error)
+}
+
+// TutorContext is what the tutor knows about a conversation besides its
+// recent messages
+type TutorContext struct {
+	Language string
+	Level    string
+	Quest    string // quest description, when the session is attached to one
+	Article  string // article text, when the session is attached to one
+	Summary  string // summary of the turns no longer sent verbatim
+}
+
+// maxArticleChars bounds how much of an attached article goes into the prompt
+const maxArticleChars = 4000
+
+// Tutor continues a Socratic tutoring conversation. messages must end with
+// the learner's latest message.
+func (s *Service) Tutor(ctx context.Context, tc TutorContext, messages []Message) (string, error) {
+	if len(messages) == 0 || messages[len(messages)-1].Role != RoleUser {
+		return "", fmt.Errorf("tutor conversation must end with a user message")
+	}
+
+	article := tc.Article
+	if len(article) > maxArticleChars {
+		article = truncate(article, maxArticleChars)
+	}
+	prompt, _, err := s.prompts.Render(string(TaskTutor), PromptData{
+		Language: tc.Language,
+		Level:    tc.Level,
+		Quest:    tc.Quest,
+		Article:  article,
+		Summary:  tc.Summary,
+	})
+	if err != nil {
+		return "", err
+	}
+	messages = withBackground(prompt.User, messages)
+
+	var reply string
+	err = s.execute(ctx, TaskTutor, func(ctx context.Context, provider AIProvider) error {
+		text, err := chat(ctx, provider, prompt.System, messages)
+		if err != nil {
+			return err
+		}
+		if text = strings.TrimSpace(text); text == "" {
+			return ErrEmptyResponse
+		}
+		reply = text
+		return nil
+	})
+	return reply, err
+}
+
+// SummarizeConversation folds messages into the running summary of a
+// conversation, so old turns can be dropped from the prompt
+func (s *Service) SummarizeConversation(ctx context.Context, language string, summary string, messages []Message) (string, error) {
+	prompt, _, err := s.prompts.Render(PromptTutorSummary, PromptData{
+		Language: language,
+		Summary:  summary,
+		Text:     transcript(messages),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var result string
+	err = s.execute(ctx, TaskTutor, func(ctx context.Context, provider AIProvider) error {
+		text, err := complete(ctx, provider, prompt)
+		if err != nil {
+			return err
+		}
+		if text = strings.TrimSpace(text); text == "" {
+			return ErrEmptyResponse
+		}
+		result = text
+		return nil
+	})
+	return result, err
+}
+
+// withBackground puts the delimited background of a conversation (quest,
+// article, summary) in front of the first user message, keeping it out of
+// the system prompt. messages is not modified.
+func withBackground(background string, messages []Message) []Message {
+	if background == "" {
+		return messages
+	}
+	if messages[0].Role != RoleUser {
+		return append([]Message{{Role: RoleUser, Content: background}}, messages...)
+	}
+	out := append([]Message(nil), messages...)
+	out[0].Content = background + "\n\n" + out[0].Content
+	return out
+}
+
+// chat sends a conversation to provider, flattening it into a single prompt
+// when the provider has no native message support
+func chat(ctx context.Context, provider AIProvider, system string, messages []Message) (string, error) {
+	if chatter, ok := provider.(Chatter); ok {
+		return chatter.Chat(ctx, system, messages)
+	}
+
+	var prompt strings.Builder
+	prompt.WriteString(system)
+	prompt.WriteString("\n\nConversation so far:\n")
+	prompt.WriteString(transcript(messages))
+	prompt.WriteString("\nTutor:")
+	return provider.Complete(ctx, prompt.String())
+}
+
+// transcript renders messages as "Student:"/"Tutor:" lines
+func transcript(messages []Message) string {
+	var b strings.Builder
+	for _, m := range messages {
+		speaker := "Student"
+		if m.Role == RoleAssistant {
+			speaker = "Tutor"
+		}
+		fmt.Fprintf(&b, "%s: %s\n", speaker, m.Content)
+	}
+	return b.String()
+}
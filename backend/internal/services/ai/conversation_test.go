@@ -0,0 +1,118 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// requestCapturingTransport answers every request with body and keeps the
+// last request body
+type requestCapturingTransport struct {
+	body    string
+	request []byte
+}
+
+func (c *requestCapturingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.request, _ = io.ReadAll(req.Body)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(c.body)),
+		Request:    req,
+	}, nil
+}
+
+func TestClaudeChatSendsHistory(t *testing.T) {
+	transport := &requestCapturingTransport{body: `{"content": [{"type": "text", "text": "Mikä on kahvi englanniksi?"}], "usage": {"input_tokens": 40, "output_tokens": 9}}`}
+	provider, err := NewClaudeProvider("test-key", WithHTTPClient(&http.Client{Transport: transport}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry := NewRegistry()
+	if err := registry.Register("claude", provider); err != nil {
+		t.Fatal(err)
+	}
+	service, err := NewServiceWithRegistry(registry, nil, "claude")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reply, err := service.Tutor(context.Background(), TutorContext{Language: "finnish", Level: "beginner", Summary: "Talked about breakfast."}, []Message{
+		{Role: RoleUser, Content: "Juon kahvia."},
+		{Role: RoleAssistant, Content: "Hyvä! Mitä muuta?"},
+		{Role: RoleUser, Content: "Mitä kahvi on?"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply != "Mikä on kahvi englanniksi?" {
+		t.Errorf("reply = %q", reply)
+	}
+
+	var sent claudeRequest
+	if err := json.Unmarshal(transport.request, &sent); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(sent.System, "Socratic finnish tutor") || strings.Contains(sent.System, "Talked about breakfast.") {
+		t.Errorf("system = %q, want the instructions without the summary", sent.System)
+	}
+	if len(sent.Messages) != 3 || sent.Messages[1].Role != RoleAssistant || sent.Messages[2].Content != "Mitä kahvi on?" {
+		t.Errorf("messages = %+v", sent.Messages)
+	}
+	if want := "<summary>\nTalked about breakfast.\n</summary>\n\nJuon kahvia."; len(sent.Messages) > 0 && sent.Messages[0].Content != want {
+		t.Errorf("first message = %q, want %q", sent.Messages[0].Content, want)
+	}
+}
+
+func TestChatFlattensHistoryForPlainProviders(t *testing.T) {
+	provider := &echoProvider{namedProvider: namedProvider{name: "plain"}}
+
+	prompt, err := chat(context.Background(), provider, "SYSTEM", []Message{
+		{Role: RoleUser, Content: "Hei"},
+		{Role: RoleAssistant, Content: "Moi!"},
+		{Role: RoleUser, Content: "Kiitos"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "SYSTEM\n\nConversation so far:\nStudent: Hei\nTutor: Moi!\nStudent: Kiitos\n\nTutor:"
+	if prompt != want {
+		t.Errorf("prompt = %q, want %q", prompt, want)
+	}
+}
+
+func TestTutorRequiresUserMessageLast(t *testing.T) {
+	service, err := NewFakeService(NewFakeProvider("fake"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = service.Tutor(context.Background(), TutorContext{Language: "finnish"}, []Message{{Role: RoleAssistant, Content: "Hei"}})
+	if err == nil {
+		t.Error("expected an error for a conversation ending with the tutor")
+	}
+}
+
+func TestTruncateKeepsRunes(t *testing.T) {
+	article := strings.Repeat("ä", maxArticleChars)
+	got := truncate(article, maxArticleChars+1)
+	if !utf8.ValidString(got) || !strings.HasSuffix(got, "ä...") {
+		t.Errorf("truncate cut a rune: %q", got[len(got)-8:])
+	}
+	if got := truncate("talo", 10); got != "talo" {
+		t.Errorf("truncate(talo) = %q", got)
+	}
+}
+
+// echoProvider answers every completion with the prompt it was sent
+type echoProvider struct {
+	namedProvider
+}
+
+func (p *echoProvider) Complete(ctx context.Context, prompt string) (string, error) {
+	return prompt, nil
+}
@@ -0,0 +1,69 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"google.golang.org/genai"
+)
+
+var (
+	// ErrEmptyResponse means the provider answered but produced no text
+	ErrEmptyResponse = errors.New("empty response")
+
+	// ErrAllProvidersFailed is returned when every candidate provider for a
+	// task failed or had its circuit breaker open
+	ErrAllProvidersFailed = errors.New("all AI providers failed")
+)
+
+// APIError is a non-2xx response from a provider's HTTP API
+type APIError struct {
+	Provider   string
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Body)
+}
+
+// isRetryable reports whether err is transient, so the same provider is worth
+// another attempt: rate limits, overload (Anthropic's 529), 5xx and timeouts.
+// Replies that are still invalid after their repair round-trip are not: the
+// next provider is tried instead.
+func isRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return isRetryableStatus(apiErr.StatusCode)
+	}
+
+	var geminiErr genai.APIError
+	if errors.As(err, &geminiErr) {
+		return isRetryableStatus(geminiErr.Code)
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrEmptyResponse) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests,
+		http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout,
+		529: // Anthropic "overloaded"
+		return true
+	}
+	return false
+}
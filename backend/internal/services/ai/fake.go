@@ -0,0 +1,261 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrNoFakeReply is returned by FakeProvider when nothing was scripted for a task
+var ErrNoFakeReply = errors.New("no scripted reply")
+
+// FakeReply is one scripted answer: the raw model text, or an error
+type FakeReply struct {
+	Text string
+	Err  error
+}
+
+// Reply scripts a raw model answer. Structured tasks parse it exactly like a
+// real reply, so invalid JSON exercises the repair path.
+func Reply(text string) FakeReply {
+	return FakeReply{Text: text}
+}
+
+// Fail scripts an error, e.g. &APIError{StatusCode: 529} to test failover
+func Fail(err error) FakeReply {
+	return FakeReply{Err: err}
+}
+
+// FakeCall is a prompt the fake received, with the model settings it was
+// asked to use. System is the separate system prompt, if there was one.
+type FakeCall struct {
+	Task     Task
+	System   string
+	Prompt   string
+	Settings ModelSettings
+}
+
+// FakeProvider is an in-process AIProvider with scripted answers, for tests
+// and offline development. Prompts are rendered from the real templates.
+// Replies are consumed per task in order; the last one repeats. Raw
+// completions (repair round-trips, streaming) use the queue of the task being
+// executed by the Service.
+type FakeProvider struct {
+	name  string
+	model string
+
+	mu      sync.Mutex
+	scripts map[Task][]FakeReply
+	spent   map[Task]bool // the only queued reply was used and is repeating
+	calls   []FakeCall
+}
+
+// NewFakeProvider creates a fake that reports name as its provider name
+func NewFakeProvider(name string) *FakeProvider {
+	return &FakeProvider{
+		name:    name,
+		model:   "fake-1",
+		scripts: make(map[Task][]FakeReply),
+		spent:   make(map[Task]bool),
+	}
+}
+
+// Script queues replies for a task. A reply that is only repeating because
+// the queue ran out is replaced. It returns the fake for chaining.
+func (f *FakeProvider) Script(task Task, replies ...FakeReply) *FakeProvider {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.spent[task] {
+		f.scripts[task] = nil
+		f.spent[task] = false
+	}
+	f.scripts[task] = append(f.scripts[task], replies...)
+	return f
+}
+
+// Calls returns every prompt received so far
+func (f *FakeProvider) Calls() []FakeCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeCall(nil), f.calls...)
+}
+
+// CallCount returns how many prompts were received for a task
+func (f *FakeProvider) CallCount(task Task) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	count := 0
+	for _, c := range f.calls {
+		if c.Task == task {
+			count++
+		}
+	}
+	return count
+}
+
+// Model returns the model name used for requests
+func (f *FakeProvider) Model() string {
+	return f.model
+}
+
+// next records the call and pops the next scripted reply for task
+func (f *FakeProvider) next(ctx context.Context, task Task, system, prompt string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	settings := modelSettings(ctx, ModelSettings{Model: f.model, MaxTokens: 1024, Temperature: 0.7})
+
+	f.mu.Lock()
+	f.calls = append(f.calls, FakeCall{Task: task, System: system, Prompt: prompt, Settings: settings})
+	queue := f.scripts[task]
+	var reply FakeReply
+	switch len(queue) {
+	case 0:
+		f.mu.Unlock()
+		return "", fmt.Errorf("%w for %q", ErrNoFakeReply, task)
+	case 1:
+		reply = queue[0]
+		f.spent[task] = true
+	default:
+		reply = queue[0]
+		f.scripts[task] = queue[1:]
+	}
+	f.mu.Unlock()
+
+	// Roughly four characters per token, like the real tokenizers
+	reportUsage(ctx, Usage{
+		Provider:     f.name,
+		Model:        settings.Model,
+		InputTokens:  (len(system)+len(prompt))/4 + 1,
+		OutputTokens: len(reply.Text)/4 + 1,
+	})
+	return reply.Text, reply.Err
+}
+
+// Complete answers from the queue of the task being executed
+func (f *FakeProvider) Complete(ctx context.Context, prompt string) (string, error) {
+	task, _ := taskFromContext(ctx)
+	return f.next(ctx, task, "", prompt)
+}
+
+// CompleteStream delivers the scripted reply word by word
+func (f *FakeProvider) CompleteStream(ctx context.Context, prompt RenderedPrompt, onText func(fragment string) error) (string, error) {
+	task, _ := taskFromContext(ctx)
+	text, err := f.next(ctx, task, prompt.System, prompt.User)
+	if err != nil {
+		return "", err
+	}
+	for _, fragment := range strings.SplitAfter(text, " ") {
+		if err := onText(fragment); err != nil {
+			return "", err
+		}
+	}
+	return text, nil
+}
+
+// Chat answers from the queue of the task being executed. The recorded prompt
+// is the only user message, or the transcript of a longer conversation.
+func (f *FakeProvider) Chat(ctx context.Context, system string, messages []Message) (string, error) {
+	task, _ := taskFromContext(ctx)
+	if len(messages) == 1 && messages[0].Role == RoleUser {
+		return f.next(ctx, task, system, messages[0].Content)
+	}
+	return f.next(ctx, task, system, transcript(messages))
+}
+
+func (f *FakeProvider) render(ctx context.Context, task Task, data PromptData) (string, error) {
+	prompt, err := renderPrompt(ctx, string(task), data)
+	if err != nil {
+		return "", err
+	}
+	return f.next(ctx, task, prompt.System, prompt.User)
+}
+
+func (f *FakeProvider) GenerateQuest(ctx context.Context, userLevel string, language string, ghostWords []string) (*QuestResult, error) {
+	response, err := f.render(ctx, TaskQuestGeneration, PromptData{Language: language, Level: userLevel, GhostWords: ghostWords})
+	if err != nil {
+		return nil, err
+	}
+
+	var result QuestResult
+	if err := decodeStructured(withTask(ctx, TaskQuestGeneration), f, QuestSchema, response, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse quest: %w", err)
+	}
+	return &result, nil
+}
+
+func (f *FakeProvider) ValidateQuestSubmission(ctx context.Context, quest string, userText string, language string) (*ValidationResult, error) {
+	response, err := f.render(ctx, TaskQuestValidation, PromptData{Language: language, Quest: quest, Text: userText})
+	if err != nil {
+		return nil, err
+	}
+
+	var result ValidationResult
+	if err := decodeStructured(withTask(ctx, TaskQuestValidation), f, ValidationSchema, response, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse validation response: %w", err)
+	}
+	return &result, nil
+}
+
+func (f *FakeProvider) GenerateSocraticFeedback(ctx context.Context, userText string, language string) (string, error) {
+	return f.render(ctx, TaskSocraticFeedback, PromptData{Language: language, Text: userText})
+}
+
+func (f *FakeProvider) Translate(ctx context.Context, text string, fromLang string, toLang string) (string, error) {
+	return f.render(ctx, TaskTranslation, PromptData{Language: fromLang, FromLanguage: fromLang, ToLanguage: toLang, Text: text})
+}
+
+func (f *FakeProvider) AnalyzeGrammar(ctx context.Context, text string, language string) (*GrammarResult, error) {
+	response, err := f.render(ctx, TaskGrammar, PromptData{Language: language, Text: text})
+	if err != nil {
+		return nil, err
+	}
+
+	var result GrammarResult
+	if err := decodeStructured(withTask(ctx, TaskGrammar), f, GrammarSchema, response, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse grammar analysis: %w", err)
+	}
+	return &result, nil
+}
+
+func (f *FakeProvider) GetWordDefinition(ctx context.Context, word string, language string) (*DefinitionResult, error) {
+	response, err := f.render(ctx, TaskDefinition, PromptData{Language: language, Word: word})
+	if err != nil {
+		return nil, err
+	}
+
+	var result DefinitionResult
+	if err := decodeStructured(withTask(ctx, TaskDefinition), f, DefinitionSchema, response, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse AI definition response: %w", err)
+	}
+	return &result, nil
+}
+
+// NewFakeService wraps fake providers in a Service with fast, deterministic
+// resilience settings. The first provider is the default.
+func NewFakeService(providers ...*FakeProvider) (*Service, error) {
+	registry := NewRegistry()
+	for _, p := range providers {
+		if err := registry.Register(p.name, p); err != nil {
+			return nil, err
+		}
+	}
+
+	var defaultProvider string
+	if len(providers) > 0 {
+		defaultProvider = providers[0].name
+	}
+	service, err := NewServiceWithRegistry(registry, nil, defaultProvider)
+	if err != nil {
+		return nil, err
+	}
+	service.SetResilience(ResilienceConfig{
+		MaxAttempts: 1,
+		BaseDelay:   1,
+		MaxDelay:    1,
+	})
+	return service, nil
+}
@@ -0,0 +1,107 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFakeProviderQuestFlow(t *testing.T) {
+	fake := NewFakeProvider("fake").
+		Script(TaskQuestGeneration, Reply(`{"title": "Aamu", "description": "Kirjoita aamustasi", "solution": "Herään."}`)).
+		Script(TaskQuestValidation, Reply(`{"is_valid": true, "feedback": "Hyvä!"}`))
+	service, err := NewFakeService(fake)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	quest, err := service.GenerateQuest(ctx, "beginner", "finnish", []string{"kahvi"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if quest.Title != "Aamu" || quest.PromptVersion != "finnish/quest_generation@2" {
+		t.Errorf("quest = %+v", quest)
+	}
+
+	verdict, err := service.ValidateQuestSubmission(ctx, quest.Description, "Herään kuudelta.", "finnish")
+	if err != nil || !verdict.IsValid {
+		t.Fatalf("verdict = %+v, %v", verdict, err)
+	}
+
+	calls := fake.Calls()
+	if len(calls) != 2 || !strings.Contains(calls[0].Prompt, "kahvi") || !strings.Contains(calls[1].Prompt, "Herään kuudelta.") {
+		t.Errorf("prompts were not rendered from the templates: %+v", calls)
+	}
+}
+
+func TestFakeProviderRepairsInvalidJSON(t *testing.T) {
+	fake := NewFakeProvider("fake").Script(TaskDefinition,
+		Reply("Sure! talo means house."),
+		Reply(`{"definition": "house", "part_of_speech": "noun"}`),
+	)
+	service, err := NewFakeService(fake)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := service.GetWordDefinition(context.Background(), "talo", "finnish")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Definition != "house" {
+		t.Errorf("definition = %+v", result)
+	}
+	if n := fake.CallCount(TaskDefinition); n != 2 {
+		t.Errorf("definition calls = %d, want 2 (original + repair)", n)
+	}
+}
+
+func TestInvalidReplyFailsOverWithoutRetrying(t *testing.T) {
+	first := NewFakeProvider("first").Script(TaskDefinition, Reply("talo means house"))
+	second := NewFakeProvider("second").Script(TaskDefinition, Reply("a house"))
+	service, err := NewFakeService(first, second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	service.SetResilience(ResilienceConfig{MaxAttempts: 3, FailureThreshold: 5})
+
+	_, err = service.GetWordDefinition(context.Background(), "talo", "finnish")
+	if !errors.Is(err, ErrInvalidStructuredOutput) || !errors.Is(err, ErrAllProvidersFailed) {
+		t.Errorf("err = %v, want ErrInvalidStructuredOutput wrapped in ErrAllProvidersFailed", err)
+	}
+	// One call and one repair per provider, never a retry
+	if first.CallCount(TaskDefinition) != 2 || second.CallCount(TaskDefinition) != 2 {
+		t.Errorf("calls: first %d, second %d, want 2 each", first.CallCount(TaskDefinition), second.CallCount(TaskDefinition))
+	}
+}
+
+func TestFakeProvidersFailOver(t *testing.T) {
+	down := NewFakeProvider("down").Script(TaskTranslation, Fail(&APIError{Provider: "down", StatusCode: 503}))
+	up := NewFakeProvider("up").Script(TaskTranslation, Reply("house"))
+	service, err := NewFakeService(down, up)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := service.Translate(context.Background(), "talo", "finnish", "english")
+	if err != nil || got != "house" {
+		t.Fatalf("Translate = %q, %v", got, err)
+	}
+	if down.CallCount(TaskTranslation) != 1 || up.CallCount(TaskTranslation) != 1 {
+		t.Errorf("calls: down %d, up %d", down.CallCount(TaskTranslation), up.CallCount(TaskTranslation))
+	}
+}
+
+func TestFakeProviderWithoutScript(t *testing.T) {
+	service, err := NewFakeService(NewFakeProvider("fake"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = service.AnalyzeGrammar(context.Background(), "Minä on", "finnish")
+	if !errors.Is(err, ErrNoFakeReply) || !errors.Is(err, ErrAllProvidersFailed) {
+		t.Errorf("err = %v, want ErrNoFakeReply wrapped in ErrAllProvidersFailed", err)
+	}
+}
@@ -2,68 +2,123 @@ package ai
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	"google.golang.org/genai"
+
+	"github.com/BachirKhiati/lexia/internal/config"
 )
 
+// defaultGeminiModel is Gemini 2.0 Flash experimental
+const defaultGeminiModel = "gemini-2.0-flash-exp"
+
 type GeminiProvider struct {
 	client *genai.Client
 	model  string
 }
 
-func NewGeminiProvider(apiKey string) (*GeminiProvider, error) {
+func init() {
+	RegisterBackend("gemini", func(cfg config.AIConfig) (AIProvider, error) {
+		if cfg.GeminiAPIKey == "" {
+			return nil, nil
+		}
+		provider, err := NewGeminiProvider(cfg.GeminiAPIKey, WithModel(cfg.GeminiModel))
+		if err != nil {
+			return nil, err
+		}
+		return provider, nil
+	}, AllTasks...)
+}
+
+func NewGeminiProvider(apiKey string, opts ...ProviderOption) (*GeminiProvider, error) {
 	if apiKey == "" {
 		return nil, fmt.Errorf("Gemini API key is required")
 	}
 
+	options := applyProviderOptions(opts)
 	ctx := context.Background()
 	client, err := genai.NewClient(ctx, &genai.ClientConfig{
-		APIKey:  apiKey,
-		Backend: genai.BackendGeminiAPI,
+		APIKey:     apiKey,
+		Backend:    genai.BackendGeminiAPI,
+		HTTPClient: options.httpClient,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Gemini client: %w", err)
 	}
 
+	model := options.model
+	if model == "" {
+		model = defaultGeminiModel
+	}
 	return &GeminiProvider{
 		client: client,
-		model:  "gemini-2.0-flash-exp", // Using Gemini 2.0 Flash experimental
+		model:  model,
 	}, nil
 }
 
-func (g *GeminiProvider) callGemini(ctx context.Context, prompt string) (string, error) {
+// callGemini sends a rendered prompt with its instructions as the system instruction
+func (g *GeminiProvider) callGemini(ctx context.Context, prompt RenderedPrompt) (string, error) {
 	// Create content with the prompt
 	parts := []*genai.Part{
-		{Text: prompt},
+		{Text: prompt.User},
 	}
 
 	content := []*genai.Content{
 		{Parts: parts},
 	}
 
-	// Configure generation parameters
-	config := &genai.GenerateContentConfig{
-		Temperature:    genai.Ptr(float32(0.7)),
-		MaxOutputTokens: 1024,
+	// Debug logging
+	fmt.Printf("[GEMINI DEBUG] Prompt length: %d chars\n", len(prompt.System)+len(prompt.User))
+
+	return g.generate(ctx, content, systemConfig(prompt.System))
+}
+
+// systemConfig returns a generation config carrying the system instruction, if any
+func systemConfig(system string) *genai.GenerateContentConfig {
+	config := &genai.GenerateContentConfig{}
+	if system != "" {
+		config.SystemInstruction = genai.NewContentFromText(system, genai.RoleUser)
 	}
+	return config
+}
+
+// settings applies the model settings of the task being executed to config
+// and returns the model to call
+func (g *GeminiProvider) settings(ctx context.Context, config *genai.GenerateContentConfig) string {
+	settings := modelSettings(ctx, ModelSettings{Model: g.model, MaxTokens: 1024, Temperature: 0.7})
+	config.Temperature = genai.Ptr(float32(settings.Temperature))
+	config.MaxOutputTokens = int32(settings.MaxTokens)
+	return settings.Model
+}
+
+// generate calls the API and returns the text of the reply
+func (g *GeminiProvider) generate(ctx context.Context, content []*genai.Content, config *genai.GenerateContentConfig) (string, error) {
+	model := g.settings(ctx, config)
 
 	// Call Gemini API
-	resp, err := g.client.Models.GenerateContent(ctx, g.model, content, config)
+	resp, err := g.client.Models.GenerateContent(ctx, model, content, config)
 	if err != nil {
 		return "", fmt.Errorf("Gemini API error: %w", err)
 	}
 
+	if resp.UsageMetadata != nil {
+		reportUsage(ctx, Usage{
+			Provider:     "gemini",
+			Model:        model,
+			InputTokens:  int(resp.UsageMetadata.PromptTokenCount),
+			OutputTokens: int(resp.UsageMetadata.CandidatesTokenCount),
+		})
+	}
+
 	// Debug logging
-	fmt.Printf("[GEMINI DEBUG] Model: %s\n", g.model)
-	fmt.Printf("[GEMINI DEBUG] Prompt length: %d chars\n", len(prompt))
+	fmt.Printf("[GEMINI DEBUG] Model: %s\n", model)
 
 	// Extract text from response
 	text := resp.Text()
 	if text == "" {
-		return "", fmt.Errorf("empty response from Gemini")
+		return "", fmt.Errorf("%w from Gemini", ErrEmptyResponse)
 	}
 
 	fmt.Printf("[GEMINI DEBUG] Response length: %d chars\n", len(text))
@@ -75,10 +130,66 @@ func (g *GeminiProvider) callGemini(ctx context.Context, prompt string) (string,
 	return text, nil
 }
 
+// Chat sends a conversation with the system prompt as system instruction.
+// Gemini calls the assistant role "model".
+func (g *GeminiProvider) Chat(ctx context.Context, system string, messages []Message) (string, error) {
+	content := make([]*genai.Content, 0, len(messages))
+	for _, m := range messages {
+		role := genai.Role(genai.RoleUser)
+		if m.Role == RoleAssistant {
+			role = genai.RoleModel
+		}
+		content = append(content, genai.NewContentFromText(m.Content, role))
+	}
+	return g.generate(ctx, content, systemConfig(system))
+}
+
+// CompleteStream sends a rendered prompt and forwards the reply as it is generated
+func (g *GeminiProvider) CompleteStream(ctx context.Context, prompt RenderedPrompt, onText func(fragment string) error) (string, error) {
+	content := []*genai.Content{
+		{Parts: []*genai.Part{{Text: prompt.User}}},
+	}
+	config := systemConfig(prompt.System)
+	model := g.settings(ctx, config)
+
+	var text strings.Builder
+	usage := Usage{Provider: "gemini", Model: model}
+	defer func() { reportUsage(ctx, usage) }()
+
+	for resp, err := range g.client.Models.GenerateContentStream(ctx, model, content, config) {
+		if err != nil {
+			return "", fmt.Errorf("Gemini API error: %w", err)
+		}
+		// Token counts are cumulative; the last chunk has the totals
+		if resp.UsageMetadata != nil {
+			usage.InputTokens = int(resp.UsageMetadata.PromptTokenCount)
+			usage.OutputTokens = int(resp.UsageMetadata.CandidatesTokenCount)
+		}
+		fragment := resp.Text()
+		if fragment == "" {
+			continue
+		}
+		text.WriteString(fragment)
+		if err := onText(fragment); err != nil {
+			return "", err
+		}
+	}
+
+	if text.Len() == 0 {
+		return "", fmt.Errorf("%w from Gemini", ErrEmptyResponse)
+	}
+	return text.String(), nil
+}
+
+// truncate cuts s to at most maxLen bytes and marks the cut. The cut backs
+// off to the start of a rune, so that ä or ö are not split.
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
 	}
+	for maxLen > 0 && !utf8.RuneStart(s[maxLen]) {
+		maxLen--
+	}
 	return s[:maxLen] + "..."
 }
 
@@ -104,132 +215,109 @@ func stripMarkdownCodeBlocks(text string) string {
 	return text
 }
 
-func (g *GeminiProvider) GenerateQuest(ctx context.Context, userLevel string, language string, ghostWords []string) (string, error) {
-	var wordRequirement string
-	if len(ghostWords) > 0 {
-		ghostWordsStr := strings.Join(ghostWords, ", ")
-		wordRequirement = fmt.Sprintf("2. Try to use some of these vocabulary words: %s", ghostWordsStr)
-	} else {
-		wordRequirement = "2. Choose appropriate vocabulary for the learner's level"
-	}
-
-	prompt := fmt.Sprintf(`You are a language teacher for %s. Generate a short learning quest for a %s level learner.
+// Model returns the model name used for requests
+func (g *GeminiProvider) Model() string {
+	return g.model
+}
 
-The quest should:
-1. Be specific and actionable (e.g., "Write 3 sentences about your weekend")
-%s
-3. Be appropriate for their level
-4. Be achievable in 5-10 minutes
+// Complete sends a raw prompt and returns the model's text
+func (g *GeminiProvider) Complete(ctx context.Context, prompt string) (string, error) {
+	return g.callGemini(ctx, RenderedPrompt{User: prompt})
+}
 
-Return ONLY valid JSON with no markdown formatting:
-{
-  "title": "Quest title",
-  "description": "Quest instructions",
-  "solution": "One example solution"
-}`, language, userLevel, wordRequirement)
+func (g *GeminiProvider) GenerateQuest(ctx context.Context, userLevel string, language string, ghostWords []string) (*QuestResult, error) {
+	prompt, err := renderPrompt(ctx, string(TaskQuestGeneration), PromptData{Language: language, Level: userLevel, GhostWords: ghostWords})
+	if err != nil {
+		return nil, err
+	}
 
-	return g.callGemini(ctx, prompt)
-}
+	response, err := g.callGemini(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
 
-func (g *GeminiProvider) ValidateQuestSubmission(ctx context.Context, quest string, userText string, language string) (bool, string, error) {
-	prompt := fmt.Sprintf(`Evaluate this %s language submission:
+	var result QuestResult
+	if err := decodeStructured(ctx, g, QuestSchema, response, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse quest: %w", err)
+	}
 
-Quest: %s
-Student's answer: %s
+	return &result, nil
+}
 
-Return ONLY valid JSON:
-{
-  "is_valid": true/false,
-  "feedback": "Brief feedback"
-}`, language, quest, userText)
+func (g *GeminiProvider) ValidateQuestSubmission(ctx context.Context, quest string, userText string, language string) (*ValidationResult, error) {
+	prompt, err := renderPrompt(ctx, string(TaskQuestValidation), PromptData{Language: language, Quest: quest, Text: userText})
+	if err != nil {
+		return nil, err
+	}
 
 	response, err := g.callGemini(ctx, prompt)
 	if err != nil {
-		return false, "", err
+		return nil, err
 	}
 
-	var result struct {
-		IsValid  bool   `json:"is_valid"`
-		Feedback string `json:"feedback"`
-	}
-	if err := json.Unmarshal([]byte(response), &result); err != nil {
-		return false, "", fmt.Errorf("failed to parse validation response: %w", err)
+	var result ValidationResult
+	if err := decodeStructured(ctx, g, ValidationSchema, response, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse validation response: %w", err)
 	}
 
-	return result.IsValid, result.Feedback, nil
+	return &result, nil
 }
 
 func (g *GeminiProvider) GenerateSocraticFeedback(ctx context.Context, userText string, language string) (string, error) {
-	prompt := fmt.Sprintf(`Provide brief encouraging feedback for this %s text: "%s"`, language, userText)
+	prompt, err := renderPrompt(ctx, string(TaskSocraticFeedback), PromptData{Language: language, Text: userText})
+	if err != nil {
+		return "", err
+	}
+
 	return g.callGemini(ctx, prompt)
 }
 
 func (g *GeminiProvider) Translate(ctx context.Context, text string, fromLang string, toLang string) (string, error) {
-	prompt := fmt.Sprintf(`Translate from %s to %s: "%s"
+	prompt, err := renderPrompt(ctx, string(TaskTranslation), PromptData{Language: fromLang, FromLanguage: fromLang, ToLanguage: toLang, Text: text})
+	if err != nil {
+		return "", err
+	}
 
-Return ONLY the translation.`, fromLang, toLang, text)
 	return g.callGemini(ctx, prompt)
 }
 
-func (g *GeminiProvider) AnalyzeGrammar(ctx context.Context, text string, language string) (map[string]interface{}, error) {
-	prompt := fmt.Sprintf(`Analyze grammar of this %s text: "%s"
-
-Return valid JSON:
-{
-  "correct": true/false,
-  "errors": ["errors"],
-  "suggestions": ["suggestions"]
-}`, language, text)
+func (g *GeminiProvider) AnalyzeGrammar(ctx context.Context, text string, language string) (*GrammarResult, error) {
+	prompt, err := renderPrompt(ctx, string(TaskGrammar), PromptData{Language: language, Text: text})
+	if err != nil {
+		return nil, err
+	}
 
 	response, err := g.callGemini(ctx, prompt)
 	if err != nil {
 		return nil, err
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal([]byte(response), &result); err != nil {
+	var result GrammarResult
+	if err := decodeStructured(ctx, g, GrammarSchema, response, &result); err != nil {
 		return nil, fmt.Errorf("failed to parse grammar analysis: %w", err)
 	}
 
-	return result, nil
-}
-
-func (g *GeminiProvider) GetWordDefinition(ctx context.Context, word string, language string) (definition string, partOfSpeech string, examples []string, err error) {
-	prompt := fmt.Sprintf(`You are a dictionary for %s language. Provide a definition for the word "%s".
-
-Return ONLY valid JSON with no markdown formatting:
-{
-  "definition": "Clear, concise definition in English",
-  "part_of_speech": "noun/verb/adjective/etc",
-  "examples": ["Example sentence 1", "Example sentence 2"]
+	return &result, nil
 }
 
-Guidelines:
-- definition: A single clear sentence explaining what the word means
-- part_of_speech: The word's grammatical category (noun, verb, adjective, adverb, etc.)
-- examples: 2-3 realistic example sentences showing how to use the word in context`, language, word)
-
-	response, err := g.callGemini(ctx, prompt)
+func (g *GeminiProvider) GetWordDefinition(ctx context.Context, word string, language string) (*DefinitionResult, error) {
+	prompt, err := renderPrompt(ctx, string(TaskDefinition), PromptData{Language: language, Word: word})
 	if err != nil {
-		return "", "", nil, fmt.Errorf("failed to get AI definition: %w", err)
-	}
-
-	var result struct {
-		Definition   string   `json:"definition"`
-		PartOfSpeech string   `json:"part_of_speech"`
-		Examples     []string `json:"examples"`
+		return nil, err
 	}
 
-	if err := json.Unmarshal([]byte(response), &result); err != nil {
-		return "", "", nil, fmt.Errorf("failed to parse AI definition response: %w", err)
+	response, err := g.callGemini(ctx, prompt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AI definition: %w", err)
 	}
 
-	// Validate we got meaningful data
-	if result.Definition == "" {
-		return "", "", nil, fmt.Errorf("AI returned empty definition")
+	// The schema rejects an empty definition, so a success is always meaningful
+	var result DefinitionResult
+	if err := decodeStructured(ctx, g, DefinitionSchema, response, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse AI definition response: %w", err)
 	}
 
-	return result.Definition, result.PartOfSpeech, result.Examples, nil
+	return &result, nil
 }
 
 func (g *GeminiProvider) Close() error {
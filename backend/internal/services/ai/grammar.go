@@ -0,0 +1,150 @@
+package ai
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// GrammarCategory classifies a grammar issue
+type GrammarCategory string
+
+const (
+	CategoryCase               GrammarCategory = "case"
+	CategoryVowelHarmony       GrammarCategory = "vowel_harmony"
+	CategoryWordOrder          GrammarCategory = "word_order"
+	CategoryConjugation        GrammarCategory = "conjugation"
+	CategoryConsonantGradation GrammarCategory = "consonant_gradation"
+	CategoryAgreement          GrammarCategory = "agreement"
+	CategorySpelling           GrammarCategory = "spelling"
+	CategoryVocabulary         GrammarCategory = "vocabulary"
+	CategoryOther              GrammarCategory = "other"
+)
+
+// Severity says how much an issue matters
+type Severity string
+
+const (
+	SeverityError      Severity = "error"      // ungrammatical
+	SeverityWarning    Severity = "warning"    // understandable but unidiomatic
+	SeveritySuggestion Severity = "suggestion" // correct, could be better
+)
+
+// GrammarResult is a grammar check of a piece of text
+type GrammarResult struct {
+	Correct bool           `json:"correct"`
+	Issues  []GrammarIssue `json:"issues"`
+}
+
+// GrammarIssue is one problem in the checked text. The model quotes the
+// problematic text in Excerpt; Span is computed from it.
+type GrammarIssue struct {
+	Excerpt     string          `json:"excerpt"`
+	Span        *TextSpan       `json:"span,omitempty"` // nil if the excerpt was not found in the text
+	Category    GrammarCategory `json:"category"`
+	Severity    Severity        `json:"severity"`
+	Replacement string          `json:"replacement"`
+	Explanation string          `json:"explanation"`
+}
+
+// TextSpan locates an excerpt in the checked text as half-open ranges, both
+// in bytes (for Go) and in runes (Unicode code points, for clients)
+type TextSpan struct {
+	ByteStart int `json:"byte_start"`
+	ByteEnd   int `json:"byte_end"`
+	RuneStart int `json:"rune_start"`
+	RuneEnd   int `json:"rune_end"`
+}
+
+// locateIssues fills in the span of every issue. Issues are expected in
+// reading order, so each excerpt is searched after the previous one first;
+// a repeated word then gets the right occurrence.
+func locateIssues(text string, issues []GrammarIssue) {
+	from := 0
+	for i := range issues {
+		issue := &issues[i]
+		issue.Span = nil
+
+		start, end := findExcerpt(text, issue.Excerpt, from)
+		if start < 0 {
+			start, end = findExcerpt(text, issue.Excerpt, 0)
+		}
+		if start < 0 {
+			continue
+		}
+		issue.Span = &TextSpan{
+			ByteStart: start,
+			ByteEnd:   end,
+			RuneStart: utf8.RuneCountInString(text[:start]),
+			RuneEnd:   utf8.RuneCountInString(text[:end]),
+		}
+		from = end
+	}
+}
+
+// findExcerpt returns the byte range of excerpt in text at or after from,
+// matching whole words only so "on" is not found inside "kotona". Models
+// tend to change case and surrounding punctuation when quoting, so a
+// case-insensitive match of the trimmed excerpt is tried if the exact one
+// fails. It returns -1, -1 if nothing matches.
+func findExcerpt(text, excerpt string, from int) (int, int) {
+	if excerpt == "" || from > len(text) {
+		return -1, -1
+	}
+	if start, end := scanExcerpt(text, []rune(excerpt), from, false); start >= 0 {
+		return start, end
+	}
+
+	trimmed := strings.Trim(excerpt, " \t\n\"'.,!?;:«»“”")
+	if trimmed == "" {
+		return -1, -1
+	}
+	return scanExcerpt(text, []rune(trimmed), from, true)
+}
+
+// scanExcerpt looks for needle at word boundaries, rune by rune so byte
+// offsets stay valid for the original text
+func scanExcerpt(text string, needle []rune, from int, fold bool) (int, int) {
+	for start := from; start < len(text); {
+		if end, ok := matchAt(text, start, needle, fold); ok && atWordBoundary(text, start, end) {
+			return start, end
+		}
+		_, size := utf8.DecodeRuneInString(text[start:])
+		start += size
+	}
+	return -1, -1
+}
+
+// matchAt reports whether needle matches text at byte offset start and
+// returns the byte offset where the match ends
+func matchAt(text string, start int, needle []rune, fold bool) (int, bool) {
+	pos := start
+	for _, want := range needle {
+		if pos >= len(text) {
+			return 0, false
+		}
+		got, size := utf8.DecodeRuneInString(text[pos:])
+		if got != want && !(fold && unicode.ToLower(got) == unicode.ToLower(want)) {
+			return 0, false
+		}
+		pos += size
+	}
+	return pos, true
+}
+
+// atWordBoundary reports whether text[start:end] does not cut a word in two
+func atWordBoundary(text string, start, end int) bool {
+	first, _ := utf8.DecodeRuneInString(text[start:])
+	if before, _ := utf8.DecodeLastRuneInString(text[:start]); start > 0 && isWordRune(first) && isWordRune(before) {
+		return false
+	}
+	last, _ := utf8.DecodeLastRuneInString(text[:end])
+	if after, _ := utf8.DecodeRuneInString(text[end:]); end < len(text) && isWordRune(last) && isWordRune(after) {
+		return false
+	}
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
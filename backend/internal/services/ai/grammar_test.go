@@ -0,0 +1,72 @@
+package ai
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLocateIssues(t *testing.T) {
+	text := "Minä on kotona. Sinä on töissä, ja hän on Äänekoskella."
+	issues := []GrammarIssue{
+		{Excerpt: "on"},           // first "on", after "Minä"
+		{Excerpt: "on"},           // second "on", after "Sinä"
+		{Excerpt: "äänekoskella"}, // model changed the case
+		{Excerpt: "talossa"},      // not in the text
+	}
+	locateIssues(text, issues)
+
+	want := []*TextSpan{
+		{ByteStart: 6, ByteEnd: 8, RuneStart: 5, RuneEnd: 7},
+		{ByteStart: 23, ByteEnd: 25, RuneStart: 21, RuneEnd: 23},
+		{ByteStart: 47, ByteEnd: 61, RuneStart: 42, RuneEnd: 54},
+		nil,
+	}
+	for i, issue := range issues {
+		got := issue.Span
+		if (got == nil) != (want[i] == nil) || (got != nil && *got != *want[i]) {
+			t.Errorf("issue %d (%q): span = %+v, want %+v", i, issue.Excerpt, got, want[i])
+			continue
+		}
+		if got != nil && !equalFoldString(text[got.ByteStart:got.ByteEnd], issue.Excerpt) {
+			t.Errorf("issue %d: span covers %q", i, text[got.ByteStart:got.ByteEnd])
+		}
+		if got != nil && string([]rune(text)[got.RuneStart:got.RuneEnd]) != text[got.ByteStart:got.ByteEnd] {
+			t.Errorf("issue %d: rune and byte spans disagree", i)
+		}
+	}
+}
+
+func TestFindExcerptTrimsQuotes(t *testing.T) {
+	start, end := findExcerpt("Ostin kaksi kirja.", `"kirja."`, 0)
+	if start != 12 || end != 17 {
+		t.Errorf("findExcerpt = %d, %d; want 12, 17", start, end)
+	}
+}
+
+func TestServiceAnalyzeGrammarAddsSpans(t *testing.T) {
+	fake := NewFakeProvider("fake").Script(TaskGrammar, Reply(`{
+		"correct": true,
+		"issues": [{"excerpt": "on", "category": "conjugation", "severity": "error", "replacement": "olen", "explanation": "Minä takes the 1st person form."}]
+	}`))
+	service, err := NewFakeService(fake)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := service.AnalyzeGrammar(context.Background(), "Minä on väsynyt.", "finnish")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Correct {
+		t.Error("a text with an error-level issue must not be correct")
+	}
+	issue := result.Issues[0]
+	if issue.Category != CategoryConjugation || issue.Span == nil || issue.Span.RuneStart != 5 || issue.Span.RuneEnd != 7 {
+		t.Errorf("issue = %+v, span %+v", issue, issue.Span)
+	}
+}
+
+func equalFoldString(a, b string) bool {
+	_, ok := matchAt(a, 0, []rune(b), true)
+	return ok && len([]rune(a)) == len([]rune(b))
+}
@@ -0,0 +1,71 @@
+package ai
+
+import (
+	"regexp"
+	"strings"
+)
+
+// injectionPatterns match text that talks to the model instead of doing the
+// exercise: attempts to override the instructions, to dictate the verdict or
+// to break out of the tags that delimit user content. English and Finnish
+// phrasings are covered.
+var injectionPatterns = []*regexp.Regexp{
+	// Overriding the instructions
+	regexp.MustCompile(`(?i)\b(ignore|disregard|forget|override|bypass)\b.{0,40}\b(instructions?|prompts?|rules|guidelines|directions)\b`),
+	regexp.MustCompile(`(?i)\bnew\s+(instructions?|rules|task)\s*:`),
+	regexp.MustCompile(`(?i)\b(system|developer)\s+(prompt|message|mode)\b`),
+	regexp.MustCompile(`(?i)\byou\s+are\s+(now|no\s+longer)\b`),
+	regexp.MustCompile(`(?i)\bpretend\s+(to\s+be|you\s+are)\b`),
+	regexp.MustCompile(`(?i)(unohda|ohita|sivuuta|älä\s+välitä)\P{L}.{0,40}(ohje|sään|käsky|kehot)`),
+	regexp.MustCompile(`(?i)\buudet\s+ohjeet\b`),
+	regexp.MustCompile(`(?i)\bjärjestelmä(kehot|viest)`),
+
+	// Dictating the verdict
+	regexp.MustCompile(`(?i)\bis_valid\b`),
+	regexp.MustCompile(`(?i)"(feedback|correct|issues)"\s*:`),
+	regexp.MustCompile(`(?i)\bverdict\s*:`),
+	regexp.MustCompile(`(?i)\b(mark|grade|return|output|respond\s+with)\b.{0,20}\b(valid|passed|true)\b`),
+	regexp.MustCompile(`(?i)\b(hyväksy|merkitse|arvioi)\w*\b.{0,30}\b(oikeaksi|oikein|hyväksytyksi|läpäistyksi|läpi)\b`),
+
+	// Breaking out of the delimiters or forging chat turns
+	regexp.MustCompile(`(?i)</?\s*(submission|quest|text|words?|sentence|senses|article|summary|conversation)\s*>`),
+	regexp.MustCompile(`(?i)&lt;/?\s*(submission|quest|text|words?|sentence|senses|article|summary|conversation)\s*&gt;`),
+	regexp.MustCompile(`<\|im_(start|end)\|>|\[/?INST\]|<<SYS>>`),
+	regexp.MustCompile(`(?im)^\s*(#{2,}\s*)?(system|assistant)\s*:`),
+}
+
+// looksLikeInjection reports whether user text tries to instruct the model
+func looksLikeInjection(text string) bool {
+	for _, pattern := range injectionPatterns {
+		if pattern.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// minSubmissionWords is the shortest submission that can complete a quest
+const minSubmissionWords = 2
+
+const (
+	injectionFeedback = "Your submission talks to the grader instead of answering the quest. Write your answer in the language you are learning and submit it again."
+	shortFeedback     = "Your submission is too short to complete the quest. Write at least one full sentence and submit it again."
+)
+
+// checkVerdict overrules a passing verdict that the submission cannot
+// support: text that tries to instruct the grader, or that is too short to
+// answer any quest. A model that passed such text may have been manipulated,
+// so its feedback is replaced as well.
+func checkVerdict(submission string, result *ValidationResult) {
+	if result == nil || !result.IsValid {
+		return
+	}
+	switch {
+	case looksLikeInjection(submission):
+		result.IsValid = false
+		result.Feedback = injectionFeedback
+	case len(strings.Fields(submission)) < minSubmissionWords:
+		result.IsValid = false
+		result.Feedback = shortFeedback
+	}
+}
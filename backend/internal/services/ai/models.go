@@ -0,0 +1,90 @@
+package ai
+
+import (
+	"context"
+	"time"
+)
+
+// TaskModel tunes the model calls made for one task
+type TaskModel struct {
+	// Models maps a provider name to the model it uses for the task.
+	// Providers not listed use their default model.
+	Models      map[string]string
+	MaxTokens   int           // 0 keeps the provider default
+	Temperature float64       // always applied when the task is configured
+	Timeout     time.Duration // per attempt; 0 keeps the service-wide timeout
+}
+
+// ModelSettings are the generation parameters of a single provider call
+type ModelSettings struct {
+	Model       string
+	MaxTokens   int
+	Temperature float64
+}
+
+type modelSettingsKey struct{}
+
+// withModelSettings passes the task's settings for one provider to its calls
+func withModelSettings(ctx context.Context, settings ModelSettings) context.Context {
+	return context.WithValue(ctx, modelSettingsKey{}, settings)
+}
+
+// modelSettings returns the settings for a provider call: the provider's
+// defaults, overridden by whatever the task configures
+func modelSettings(ctx context.Context, defaults ModelSettings) ModelSettings {
+	settings, ok := ctx.Value(modelSettingsKey{}).(ModelSettings)
+	if !ok {
+		return defaults
+	}
+	if settings.Model == "" {
+		settings.Model = defaults.Model
+	}
+	if settings.MaxTokens <= 0 {
+		settings.MaxTokens = defaults.MaxTokens
+	}
+	return settings
+}
+
+// SetTaskModels replaces the per-task model settings. Tasks without an
+// entry use each provider's defaults.
+func (s *Service) SetTaskModels(models map[Task]TaskModel) {
+	s.taskModels = models
+}
+
+// callSettings returns what a provider is told to use for task, and whether
+// the task is configured at all
+func (s *Service) callSettings(task Task, name string) (ModelSettings, bool) {
+	tm, ok := s.taskModels[task]
+	if !ok {
+		return ModelSettings{}, false
+	}
+	return ModelSettings{
+		Model:       tm.Models[name],
+		MaxTokens:   tm.MaxTokens,
+		Temperature: tm.Temperature,
+	}, true
+}
+
+// attemptTimeout is the deadline for a single provider call made for task
+func (s *Service) attemptTimeout(task Task, rc ResilienceConfig) time.Duration {
+	if tm, ok := s.taskModels[task]; ok && tm.Timeout > 0 {
+		return tm.Timeout
+	}
+	return rc.AttemptTimeout
+}
+
+// modelNamer is implemented by providers that know which model they call
+type modelNamer interface {
+	Model() string
+}
+
+// modelOf identifies the model a provider uses for task, for cache keys
+func (s *Service) modelOf(task Task, name string, provider AIProvider) string {
+	if model := s.taskModels[task].Models[name]; model != "" {
+		return name + "/" + model
+	}
+	if m, ok := provider.(modelNamer); ok {
+		return name + "/" + m.Model()
+	}
+	return name
+}
@@ -0,0 +1,133 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTaskModelsReachProviders(t *testing.T) {
+	small := NewFakeProvider("small").Script(TaskDefinition, Reply(`{"definition": "house", "part_of_speech": "noun", "examples": []}`))
+	service, err := NewFakeService(small)
+	if err != nil {
+		t.Fatal(err)
+	}
+	service.SetTaskModels(map[Task]TaskModel{
+		TaskDefinition: {Models: map[string]string{"small": "fake-mini"}, MaxTokens: 256, Temperature: 0.1},
+	})
+
+	if _, err := service.GetWordDefinition(context.Background(), "talo", "finnish"); err != nil {
+		t.Fatal(err)
+	}
+	want := ModelSettings{Model: "fake-mini", MaxTokens: 256, Temperature: 0.1}
+	if got := small.Calls()[0].Settings; got != want {
+		t.Errorf("settings = %+v, want %+v", got, want)
+	}
+}
+
+func TestUnconfiguredTaskUsesProviderDefaults(t *testing.T) {
+	fake := NewFakeProvider("fake").Script(TaskTranslation, Reply("house"))
+	service, err := NewFakeService(fake)
+	if err != nil {
+		t.Fatal(err)
+	}
+	service.SetTaskModels(map[Task]TaskModel{
+		TaskDefinition: {Models: map[string]string{"fake": "fake-mini"}, MaxTokens: 256},
+	})
+
+	if _, err := service.Translate(context.Background(), "talo", "finnish", "english"); err != nil {
+		t.Fatal(err)
+	}
+	want := ModelSettings{Model: "fake-1", MaxTokens: 1024, Temperature: 0.7}
+	if got := fake.Calls()[0].Settings; got != want {
+		t.Errorf("settings = %+v, want %+v", got, want)
+	}
+}
+
+// slowProvider answers translations after a delay unless its context ends first
+type slowProvider struct {
+	namedProvider
+	delay time.Duration
+}
+
+func (p *slowProvider) Translate(ctx context.Context, text string, fromLang string, toLang string) (string, error) {
+	select {
+	case <-time.After(p.delay):
+		return "house", nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func TestTaskTimeoutOverridesAttemptTimeout(t *testing.T) {
+	registry := NewRegistry()
+	if err := registry.Register("slow", &slowProvider{namedProvider: namedProvider{name: "slow"}, delay: time.Second}); err != nil {
+		t.Fatal(err)
+	}
+	service, err := NewServiceWithRegistry(registry, nil, "slow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	service.SetResilience(ResilienceConfig{MaxAttempts: 1, AttemptTimeout: time.Minute})
+	service.SetTaskModels(map[Task]TaskModel{TaskTranslation: {Timeout: 20 * time.Millisecond}})
+
+	start := time.Now()
+	_, err = service.Translate(context.Background(), "talo", "finnish", "english")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want a deadline error", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("call took %v, the task timeout was ignored", elapsed)
+	}
+}
+
+func TestClaudeSendsTaskModel(t *testing.T) {
+	transport := &requestCapturingTransport{body: `{"content": [{"type": "text", "text": "house"}], "usage": {"input_tokens": 10, "output_tokens": 1}}`}
+	provider, err := NewClaudeProvider("test-key", WithHTTPClient(&http.Client{Transport: transport}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry := NewRegistry()
+	if err := registry.Register("claude", provider); err != nil {
+		t.Fatal(err)
+	}
+	service, err := NewServiceWithRegistry(registry, nil, "claude")
+	if err != nil {
+		t.Fatal(err)
+	}
+	service.SetTaskModels(map[Task]TaskModel{
+		TaskTranslation: {Models: map[string]string{"claude": "claude-3-5-haiku-20241022"}, MaxTokens: 300, Temperature: 0.3},
+	})
+
+	if _, err := service.Translate(context.Background(), "talo", "finnish", "english"); err != nil {
+		t.Fatal(err)
+	}
+	var sent claudeRequest
+	if err := json.Unmarshal(transport.request, &sent); err != nil {
+		t.Fatal(err)
+	}
+	if sent.Model != "claude-3-5-haiku-20241022" || sent.MaxTokens != 300 || sent.Temperature != 0.3 {
+		t.Errorf("request = model %q, max_tokens %d, temperature %v", sent.Model, sent.MaxTokens, sent.Temperature)
+	}
+	if provider.Model() != claudeModel {
+		t.Errorf("default model = %q, want %q", provider.Model(), claudeModel)
+	}
+}
+
+func TestCacheKeyUsesTaskModel(t *testing.T) {
+	fake := NewFakeProvider("fake")
+	service, err := NewFakeService(fake)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := service.modelOf(TaskDefinition, "fake", fake); got != "fake/fake-1" {
+		t.Errorf("model = %q, want the provider default", got)
+	}
+	service.SetTaskModels(map[Task]TaskModel{TaskDefinition: {Models: map[string]string{"fake": "fake-mini"}}})
+	if got := service.modelOf(TaskDefinition, "fake", fake); got != "fake/fake-mini" {
+		t.Errorf("model = %q, want the task model", got)
+	}
+}
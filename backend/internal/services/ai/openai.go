@@ -0,0 +1,268 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/BachirKhiati/lexia/internal/config"
+)
+
+const (
+	defaultOpenAIBaseURL = "https://api.openai.com/v1"
+	defaultOpenAIModel   = "gpt-4o-mini"
+)
+
+// OpenAIProvider talks to any server implementing the OpenAI chat-completions
+// API: OpenAI itself, or a self-hosted model server such as Ollama, llama.cpp
+// or vLLM pointed to by baseURL.
+type OpenAIProvider struct {
+	apiKey     string
+	baseURL    string
+	model      string
+	httpClient *http.Client
+}
+
+type openAIRequest struct {
+	Model       string          `json:"model"`
+	Messages    []openAIMessage `json:"messages"`
+	MaxTokens   int             `json:"max_tokens,omitempty"`
+	Temperature float64         `json:"temperature"`
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIResponse struct {
+	Choices []struct {
+		Message openAIMessage `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+}
+
+func init() {
+	RegisterBackend("openai", func(cfg config.AIConfig) (AIProvider, error) {
+		// A base URL alone is enough: local servers usually don't check keys
+		if cfg.OpenAIAPIKey == "" && cfg.OpenAIBaseURL == "" {
+			return nil, nil
+		}
+		provider, err := NewOpenAIProvider(cfg.OpenAIAPIKey, cfg.OpenAIBaseURL, cfg.OpenAIModel)
+		if err != nil {
+			return nil, err
+		}
+		return provider, nil
+	}, AllTasks...)
+}
+
+// NewOpenAIProvider creates a provider for an OpenAI-compatible endpoint.
+// The API key may be empty for local servers that do not check it.
+func NewOpenAIProvider(apiKey, baseURL, model string, opts ...ProviderOption) (*OpenAIProvider, error) {
+	if apiKey == "" && baseURL == "" {
+		return nil, fmt.Errorf("OpenAI API key or base URL is required")
+	}
+	if baseURL == "" {
+		baseURL = defaultOpenAIBaseURL
+	}
+	if model == "" {
+		model = defaultOpenAIModel
+	}
+
+	options := applyProviderOptions(opts)
+	return &OpenAIProvider{
+		apiKey:     apiKey,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		model:      model,
+		httpClient: options.httpClient,
+	}, nil
+}
+
+// callOpenAI sends a rendered prompt with its instructions as a system message
+func (o *OpenAIProvider) callOpenAI(ctx context.Context, prompt RenderedPrompt) (string, error) {
+	return o.Chat(ctx, prompt.System, []Message{{Role: RoleUser, Content: prompt.User}})
+}
+
+// send posts a chat completion request and returns the text of the reply
+func (o *OpenAIProvider) send(ctx context.Context, messages []openAIMessage) (string, error) {
+	settings := modelSettings(ctx, ModelSettings{Model: o.model, MaxTokens: 1024, Temperature: 0.7})
+	reqBody := openAIRequest{
+		Model:       settings.Model,
+		MaxTokens:   settings.MaxTokens,
+		Temperature: settings.Temperature,
+		Messages:    messages,
+	}
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", o.baseURL+"/chat/completions", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if o.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+o.apiKey)
+	}
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("API request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", &APIError{Provider: "openai", StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var openAIResp openAIResponse
+	if err := json.Unmarshal(body, &openAIResp); err != nil {
+		return "", fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	reportUsage(ctx, Usage{
+		Provider:     "openai",
+		Model:        reqBody.Model,
+		InputTokens:  openAIResp.Usage.PromptTokens,
+		OutputTokens: openAIResp.Usage.CompletionTokens,
+	})
+
+	if len(openAIResp.Choices) == 0 || openAIResp.Choices[0].Message.Content == "" {
+		return "", fmt.Errorf("%w from OpenAI-compatible server", ErrEmptyResponse)
+	}
+
+	// Local models often wrap JSON in markdown fences even when told not to
+	return stripMarkdownCodeBlocks(openAIResp.Choices[0].Message.Content), nil
+}
+
+// Chat sends a conversation with the system prompt, if any, as the first message
+func (o *OpenAIProvider) Chat(ctx context.Context, system string, messages []Message) (string, error) {
+	var chat []openAIMessage
+	if system != "" {
+		chat = append(chat, openAIMessage{Role: "system", Content: system})
+	}
+	for _, m := range messages {
+		chat = append(chat, openAIMessage{Role: m.Role, Content: m.Content})
+	}
+	return o.send(ctx, chat)
+}
+
+// Model returns the model name used for requests
+func (o *OpenAIProvider) Model() string {
+	return o.model
+}
+
+// Complete sends a raw prompt and returns the model's text
+func (o *OpenAIProvider) Complete(ctx context.Context, prompt string) (string, error) {
+	return o.callOpenAI(ctx, RenderedPrompt{User: prompt})
+}
+
+func (o *OpenAIProvider) GenerateQuest(ctx context.Context, userLevel string, language string, ghostWords []string) (*QuestResult, error) {
+	prompt, err := renderPrompt(ctx, string(TaskQuestGeneration), PromptData{Language: language, Level: userLevel, GhostWords: ghostWords})
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := o.callOpenAI(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	var result QuestResult
+	if err := decodeStructured(ctx, o, QuestSchema, response, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse quest: %w", err)
+	}
+
+	return &result, nil
+}
+
+func (o *OpenAIProvider) ValidateQuestSubmission(ctx context.Context, quest string, userText string, language string) (*ValidationResult, error) {
+	prompt, err := renderPrompt(ctx, string(TaskQuestValidation), PromptData{Language: language, Quest: quest, Text: userText})
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := o.callOpenAI(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ValidationResult
+	if err := decodeStructured(ctx, o, ValidationSchema, response, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse validation response: %w", err)
+	}
+
+	return &result, nil
+}
+
+func (o *OpenAIProvider) GenerateSocraticFeedback(ctx context.Context, userText string, language string) (string, error) {
+	prompt, err := renderPrompt(ctx, string(TaskSocraticFeedback), PromptData{Language: language, Text: userText})
+	if err != nil {
+		return "", err
+	}
+
+	return o.callOpenAI(ctx, prompt)
+}
+
+func (o *OpenAIProvider) Translate(ctx context.Context, text string, fromLang string, toLang string) (string, error) {
+	prompt, err := renderPrompt(ctx, string(TaskTranslation), PromptData{Language: fromLang, FromLanguage: fromLang, ToLanguage: toLang, Text: text})
+	if err != nil {
+		return "", err
+	}
+
+	return o.callOpenAI(ctx, prompt)
+}
+
+func (o *OpenAIProvider) AnalyzeGrammar(ctx context.Context, text string, language string) (*GrammarResult, error) {
+	prompt, err := renderPrompt(ctx, string(TaskGrammar), PromptData{Language: language, Text: text})
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := o.callOpenAI(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	var result GrammarResult
+	if err := decodeStructured(ctx, o, GrammarSchema, response, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse grammar analysis: %w", err)
+	}
+
+	return &result, nil
+}
+
+func (o *OpenAIProvider) GetWordDefinition(ctx context.Context, word string, language string) (*DefinitionResult, error) {
+	prompt, err := renderPrompt(ctx, string(TaskDefinition), PromptData{Language: language, Word: word})
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := o.callOpenAI(ctx, prompt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AI definition: %w", err)
+	}
+
+	// The schema rejects an empty definition, so a success is always meaningful
+	var result DefinitionResult
+	if err := decodeStructured(ctx, o, DefinitionSchema, response, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse AI definition response: %w", err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,67 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newOpenAITestServer(t *testing.T, content string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat/completions" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		var req openAIRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		if req.Model != "llama3" {
+			t.Errorf("model = %s, want llama3", req.Model)
+		}
+		if got := r.Header.Get("Authorization"); got != "" {
+			t.Errorf("Authorization header should be empty without a key, got %q", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]string{"role": "assistant", "content": content}},
+			},
+		})
+	}))
+}
+
+func TestOpenAIProvider_GetWordDefinition(t *testing.T) {
+	server := newOpenAITestServer(t, "```json\n{\"definition\": \"house\", \"part_of_speech\": \"noun\", \"examples\": [\"Talo on iso.\"]}\n```")
+	defer server.Close()
+
+	provider, err := NewOpenAIProvider("", server.URL+"/", "llama3")
+	if err != nil {
+		t.Fatalf("NewOpenAIProvider failed: %v", err)
+	}
+
+	result, err := provider.GetWordDefinition(context.Background(), "talo", "finnish")
+	if err != nil {
+		t.Fatalf("GetWordDefinition failed: %v", err)
+	}
+	if result.Definition != "house" || result.PartOfSpeech != "noun" || len(result.Examples) != 1 {
+		t.Errorf("got %+v", result)
+	}
+}
+
+func TestNewOpenAIProvider_RequiresKeyOrBaseURL(t *testing.T) {
+	if _, err := NewOpenAIProvider("", "", ""); err == nil {
+		t.Error("expected error when neither key nor base URL is set")
+	}
+
+	provider, err := NewOpenAIProvider("sk-test", "", "")
+	if err != nil {
+		t.Fatalf("NewOpenAIProvider failed: %v", err)
+	}
+	if provider.baseURL != defaultOpenAIBaseURL || provider.model != defaultOpenAIModel {
+		t.Errorf("defaults not applied: baseURL=%s model=%s", provider.baseURL, provider.model)
+	}
+}
@@ -0,0 +1,276 @@
+package ai
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+//go:embed prompts/*.tmpl prompts/*/*.tmpl
+var embeddedPrompts embed.FS
+
+// PromptQuestValidationStream names the streamed validation prompt. Other
+// prompts are named after their Task.
+const PromptQuestValidationStream = "quest_validation_stream"
+
+// PromptData is what templates can refer to. Only the fields relevant to a
+// prompt are set.
+type PromptData struct {
+	Language      string // target language, e.g. "finnish"
+	Level         string // beginner, intermediate or advanced
+	FromLanguage  string
+	ToLanguage    string
+	Text          string // learner text, or text to translate or check
+	Quest         string
+	Word          string
+	GhostWords    []string
+	VerdictMarker string
+	Article       string   // article the learner is reading
+	Summary       string   // summary of earlier conversation turns
+	Senses        []string // candidate meanings of Word, for sense selection
+}
+
+// Prompt is one parsed template
+type Prompt struct {
+	Name     string `json:"name"`
+	Language string `json:"language,omitempty"` // empty for the default template
+	Version  string `json:"version"`
+	Override bool   `json:"override"`
+	tmpl     *template.Template
+}
+
+// ID identifies the exact revision, e.g. "finnish/grammar@2". It is stored
+// with generated content and used in cache keys.
+func (p *Prompt) ID() string {
+	scope := p.Language
+	if scope == "" {
+		scope = "default"
+	}
+	id := scope + "/" + p.Name + "@" + p.Version
+	if p.Override {
+		id += "+override"
+	}
+	return id
+}
+
+// PromptSet holds every template, keyed by language and name
+type PromptSet struct {
+	prompts map[string]*Prompt
+}
+
+var versionHeader = regexp.MustCompile(`^\{\{-?\s*/\*\s*version:\s*([\w.-]+)\s*\*/\s*-?\}\}`)
+
+var promptFuncs = template.FuncMap{
+	"join":    strings.Join,
+	"inc":     func(i int) int { return i + 1 },
+	"escape":  escapeUntrusted,
+	"delimit": delimit,
+}
+
+// untrustedEscaper keeps user content from opening or closing the tags that
+// delimit it
+var untrustedEscaper = strings.NewReplacer("<", "&lt;", ">", "&gt;")
+
+// escapeUntrusted escapes text written by users, or fetched on their behalf,
+// before it goes into a prompt
+func escapeUntrusted(text string) string {
+	return untrustedEscaper.Replace(text)
+}
+
+// delimit encloses escaped user content in <tag> and </tag> lines, so the
+// instructions can tell the model where data starts and ends
+func delimit(tag, text string) string {
+	return "<" + tag + ">\n" + escapeUntrusted(text) + "\n</" + tag + ">"
+}
+
+// RenderedPrompt is a filled-in template. System holds the instructions and
+// User the content to work on; learner text only ever appears in User,
+// delimited and escaped. Templates without a "system" block render
+// everything into User.
+type RenderedPrompt struct {
+	System string
+	User   string
+}
+
+// Flatten joins both parts for providers and calls without a separate
+// system prompt
+func (r RenderedPrompt) Flatten() string {
+	if r.System == "" {
+		return r.User
+	}
+	if r.User == "" {
+		return r.System
+	}
+	return r.System + "\n\n" + r.User
+}
+
+// LoadPrompts parses the embedded templates, then any templates in
+// overrideDir (same layout), which replace embedded ones of the same name
+func LoadPrompts(overrideDir string) (*PromptSet, error) {
+	set := &PromptSet{prompts: make(map[string]*Prompt)}
+	if err := set.load(embeddedPrompts, "prompts", false); err != nil {
+		return nil, err
+	}
+	if overrideDir != "" {
+		if err := set.load(os.DirFS(overrideDir), ".", true); err != nil {
+			return nil, err
+		}
+	}
+	return set, nil
+}
+
+func (ps *PromptSet) load(fsys fs.FS, root string, override bool) error {
+	return fs.WalkDir(fsys, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || path.Ext(p) != ".tmpl" {
+			return nil
+		}
+
+		rel := strings.TrimPrefix(strings.TrimPrefix(p, root), "/")
+		language, file := path.Split(rel)
+		language = strings.TrimSuffix(language, "/")
+		if strings.Contains(language, "/") {
+			return fmt.Errorf("prompt %s: templates may only be nested one directory deep", p)
+		}
+
+		raw, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		prompt, err := parsePrompt(strings.TrimSuffix(file, ".tmpl"), language, string(raw))
+		if err != nil {
+			return fmt.Errorf("prompt %s: %w", p, err)
+		}
+		prompt.Override = override
+		ps.prompts[promptKey(prompt.Name, language)] = prompt
+		return nil
+	})
+}
+
+func parsePrompt(name, language, raw string) (*Prompt, error) {
+	match := versionHeader.FindStringSubmatch(raw)
+	if match == nil {
+		return nil, fmt.Errorf("missing {{/* version: N */}} header")
+	}
+	tmpl, err := template.New(name).Funcs(promptFuncs).Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &Prompt{Name: name, Language: language, Version: match[1], tmpl: tmpl}, nil
+}
+
+func promptKey(name, language string) string {
+	return language + "/" + name
+}
+
+// Lookup returns the template for a language, falling back to the default
+func (ps *PromptSet) Lookup(name, language string) (*Prompt, error) {
+	if p, ok := ps.prompts[promptKey(name, strings.ToLower(language))]; ok {
+		return p, nil
+	}
+	if p, ok := ps.prompts[promptKey(name, "")]; ok {
+		return p, nil
+	}
+	return nil, fmt.Errorf("no prompt template named %q", name)
+}
+
+// Render fills in the template for data.Language
+func (ps *PromptSet) Render(name string, data PromptData) (RenderedPrompt, *Prompt, error) {
+	prompt, err := ps.Lookup(name, data.Language)
+	if err != nil {
+		return RenderedPrompt{}, nil, err
+	}
+
+	var rendered RenderedPrompt
+	var b strings.Builder
+	if system := prompt.tmpl.Lookup("system"); system != nil {
+		if err := system.Execute(&b, data); err != nil {
+			return RenderedPrompt{}, nil, fmt.Errorf("failed to render prompt %s: %w", prompt.ID(), err)
+		}
+		rendered.System = strings.TrimSpace(b.String())
+		b.Reset()
+	}
+	if err := prompt.tmpl.Execute(&b, data); err != nil {
+		return RenderedPrompt{}, nil, fmt.Errorf("failed to render prompt %s: %w", prompt.ID(), err)
+	}
+	rendered.User = strings.TrimSpace(b.String())
+	return rendered, prompt, nil
+}
+
+// Version returns the ID of the template used for a language, or "" if none
+func (ps *PromptSet) Version(name, language string) string {
+	prompt, err := ps.Lookup(name, language)
+	if err != nil {
+		return ""
+	}
+	return prompt.ID()
+}
+
+// List returns every template, sorted by name then language
+func (ps *PromptSet) List() []*Prompt {
+	list := make([]*Prompt, 0, len(ps.prompts))
+	for _, p := range ps.prompts {
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Name != list[j].Name {
+			return list[i].Name < list[j].Name
+		}
+		return list[i].Language < list[j].Language
+	})
+	return list
+}
+
+var (
+	defaultPromptsOnce sync.Once
+	defaultPromptSet   *PromptSet
+)
+
+// DefaultPrompts returns the embedded templates
+func DefaultPrompts() *PromptSet {
+	defaultPromptsOnce.Do(func() {
+		set, err := LoadPrompts("")
+		if err != nil {
+			panic(fmt.Sprintf("ai: invalid embedded prompts: %v", err))
+		}
+		defaultPromptSet = set
+	})
+	return defaultPromptSet
+}
+
+type promptSetKey struct{}
+
+// withPrompts makes the service's templates available to providers
+func withPrompts(ctx context.Context, set *PromptSet) context.Context {
+	return context.WithValue(ctx, promptSetKey{}, set)
+}
+
+// renderPrompt is used by providers to build a prompt from the templates of
+// the calling service, or the embedded ones when called directly
+func renderPrompt(ctx context.Context, name string, data PromptData) (RenderedPrompt, error) {
+	set, ok := ctx.Value(promptSetKey{}).(*PromptSet)
+	if !ok {
+		set = DefaultPrompts()
+	}
+	prompt, _, err := set.Render(name, data)
+	return prompt, err
+}
+
+// complete sends a rendered prompt to provider, with the instructions as a
+// separate system prompt when the provider supports one
+func complete(ctx context.Context, provider AIProvider, prompt RenderedPrompt) (string, error) {
+	if chatter, ok := provider.(Chatter); ok && prompt.System != "" {
+		return chatter.Chat(ctx, prompt.System, []Message{{Role: RoleUser, Content: prompt.User}})
+	}
+	return provider.Complete(ctx, prompt.Flatten())
+}
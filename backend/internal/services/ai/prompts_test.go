@@ -0,0 +1,148 @@
+package ai
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedPromptsRender(t *testing.T) {
+	set := DefaultPrompts()
+	data := PromptData{
+		Language:      "finnish",
+		Level:         "beginner",
+		FromLanguage:  "finnish",
+		ToLanguage:    "english",
+		Text:          "Minä olen opiskelija.",
+		Quest:         "Introduce yourself",
+		Word:          "talo",
+		GhostWords:    []string{"talo", "kissa"},
+		VerdictMarker: verdictMarker,
+		Article:       "Helsinki on Suomen pääkaupunki.",
+		Summary:       "The student is practising the partitive.",
+		Senses:        []string{"to take", "to pick up"},
+	}
+
+	names := []string{PromptQuestValidationStream, PromptTutorSummary}
+	for _, task := range AllTasks {
+		names = append(names, string(task))
+	}
+	for _, language := range []string{"finnish", "swedish"} {
+		data.Language = language
+		for _, name := range names {
+			rendered, prompt, err := set.Render(name, data)
+			if err != nil {
+				t.Fatalf("Render(%s, %s): %v", name, language, err)
+			}
+			text := rendered.Flatten()
+			if strings.Contains(text, "{{") || strings.Contains(text, "<no value>") {
+				t.Errorf("Render(%s, %s) left template syntax: %q", name, language, text)
+			}
+			if prompt.Version == "" {
+				t.Errorf("%s has no version", prompt.ID())
+			}
+		}
+	}
+}
+
+func TestPromptsPreferLanguageTemplate(t *testing.T) {
+	set := DefaultPrompts()
+
+	rendered, prompt, err := set.Render(string(TaskQuestGeneration), PromptData{Language: "finnish", Level: "intermediate", GhostWords: []string{"kauppa"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prompt.ID() != "finnish/quest_generation@2" {
+		t.Errorf("ID = %q, want finnish/quest_generation@2", prompt.ID())
+	}
+	if !strings.Contains(rendered.System, "consonant gradation") || !strings.Contains(rendered.User, "kauppa") {
+		t.Errorf("intermediate Finnish quest prompt missing level guidance or ghost words:\n%s", rendered.Flatten())
+	}
+
+	if got := set.Version(string(TaskQuestGeneration), "swedish"); got != "default/quest_generation@2" {
+		t.Errorf("Version for swedish = %q, want the default template", got)
+	}
+}
+
+func TestPromptOverrides(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "finnish"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	override := "{{/* version: 7 */ -}}\nKäännä: {{.Text}}\n"
+	if err := os.WriteFile(filepath.Join(dir, "finnish", "translation.tmpl"), []byte(override), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	set, err := LoadPrompts(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rendered, prompt, err := set.Render(string(TaskTranslation), PromptData{Language: "finnish", Text: "house"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text := rendered.Flatten(); text != "Käännä: house" || prompt.ID() != "finnish/translation@7+override" {
+		t.Errorf("override rendered %+v as %s", rendered, prompt.ID())
+	}
+
+	// Templates not overridden keep their embedded revision
+	if got := set.Version(string(TaskGrammar), "finnish"); got != "finnish/grammar@3" {
+		t.Errorf("grammar version = %q, want finnish/grammar@3", got)
+	}
+}
+
+func TestPromptOverrideRequiresVersion(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "grammar.tmpl"), []byte("Check {{.Text}}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadPrompts(dir); err == nil || !strings.Contains(err.Error(), "version") {
+		t.Errorf("LoadPrompts error = %v, want missing version header", err)
+	}
+}
+
+// promptCapturingProvider records the last prompt it was sent
+type promptCapturingProvider struct {
+	namedProvider
+	prompt string
+}
+
+func (p *promptCapturingProvider) Translate(ctx context.Context, text string, fromLang string, toLang string) (string, error) {
+	prompt, err := renderPrompt(ctx, string(TaskTranslation), PromptData{Language: fromLang, FromLanguage: fromLang, ToLanguage: toLang, Text: text})
+	p.prompt = prompt.Flatten()
+	return p.name, err
+}
+
+func TestServicePassesPromptsToProviders(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "translation.tmpl"), []byte("{{/* version: 2 */ -}}\nTranslate {{.Text}} into {{.ToLanguage}}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	set, err := LoadPrompts(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider := &promptCapturingProvider{namedProvider: namedProvider{name: "capturing"}}
+	registry := NewRegistry()
+	if err := registry.Register("capturing", provider); err != nil {
+		t.Fatal(err)
+	}
+	service, err := NewServiceWithRegistry(registry, nil, "capturing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	service.SetPrompts(set)
+
+	if _, err := service.Translate(context.Background(), "talo", "finnish", "english"); err != nil {
+		t.Fatal(err)
+	}
+	if provider.prompt != "Translate talo into english" {
+		t.Errorf("provider got prompt %q", provider.prompt)
+	}
+}
@@ -2,138 +2,421 @@ package ai
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/BachirKhiati/lexia/internal/config"
 )
 
 // AIProvider is the interface that all AI providers must implement
 type AIProvider interface {
-	GenerateQuest(ctx context.Context, userLevel string, language string, ghostWords []string) (string, error)
-	ValidateQuestSubmission(ctx context.Context, quest string, userText string, language string) (bool, string, error)
+	// Complete sends a raw prompt; used for structured-output repair
+	Complete(ctx context.Context, prompt string) (string, error)
+	GenerateQuest(ctx context.Context, userLevel string, language string, ghostWords []string) (*QuestResult, error)
+	ValidateQuestSubmission(ctx context.Context, quest string, userText string, language string) (*ValidationResult, error)
 	GenerateSocraticFeedback(ctx context.Context, userText string, language string) (string, error)
 	Translate(ctx context.Context, text string, fromLang string, toLang string) (string, error)
-	AnalyzeGrammar(ctx context.Context, text string, language string) (map[string]interface{}, error)
-	GetWordDefinition(ctx context.Context, word string, language string) (definition string, partOfSpeech string, examples []string, err error)
+	AnalyzeGrammar(ctx context.Context, text string, language string) (*GrammarResult, error)
+	GetWordDefinition(ctx context.Context, word string, language string) (*DefinitionResult, error)
+}
+
+// ProviderOption customizes a provider at construction
+type ProviderOption func(*providerOptions)
+
+type providerOptions struct {
+	httpClient *http.Client
+	model      string
+}
+
+// WithHTTPClient sends a provider's requests through client, e.g. one using
+// a Recorder to replay captured API exchanges in tests
+func WithHTTPClient(client *http.Client) ProviderOption {
+	return func(o *providerOptions) {
+		o.httpClient = client
+	}
+}
+
+// WithModel replaces the provider's default model. Tasks can still pick
+// another one through their model settings.
+func WithModel(model string) ProviderOption {
+	return func(o *providerOptions) {
+		o.model = model
+	}
+}
+
+func applyProviderOptions(opts []ProviderOption) providerOptions {
+	options := providerOptions{httpClient: &http.Client{}}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
 }
 
-// Service manages multiple AI providers
+// Service manages multiple AI providers and routes each task to one of them
 type Service struct {
-	claude          *ClaudeProvider
-	gemini          *GeminiProvider
+	registry        *Registry
+	routes          map[Task][]string
 	defaultProvider string
+
+	resilience ResilienceConfig
+	breakersMu sync.Mutex
+	breakers   map[string]*CircuitBreaker
+
+	cache      *responseCache
+	usage      *usageTracker
+	prompts    *PromptSet
+	taskModels map[Task]TaskModel
 }
 
-// NewService creates a new multi-provider AI service
-func NewService(claudeAPIKey, geminiAPIKey, defaultProvider string) (*Service, error) {
-	var claude *ClaudeProvider
-	var gemini *GeminiProvider
-	var err error
+// NewService creates a new multi-provider AI service from every configured backend
+func NewService(cfg config.AIConfig) (*Service, error) {
+	registry, err := registryFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
 
-	if claudeAPIKey != "" {
-		claude, err = NewClaudeProvider(claudeAPIKey)
-		if err != nil {
-			return nil, fmt.Errorf("failed to initialize Claude: %w", err)
+	routes := make(map[Task][]string, len(cfg.TaskRoutes))
+	for task, names := range cfg.TaskRoutes {
+		routes[Task(task)] = names
+	}
+	taskModels := make(map[Task]TaskModel, len(cfg.TaskModels))
+	for task, tm := range cfg.TaskModels {
+		taskModels[Task(task)] = TaskModel{
+			Models:      tm.Models,
+			MaxTokens:   tm.MaxTokens,
+			Temperature: tm.Temperature,
+			Timeout:     tm.Timeout,
 		}
 	}
 
-	if geminiAPIKey != "" {
-		gemini, err = NewGeminiProvider(geminiAPIKey)
+	service, err := NewServiceWithRegistry(registry, routes, cfg.DefaultProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	resilience := DefaultResilienceConfig()
+	resilience.MaxAttempts = cfg.MaxAttempts
+	resilience.AttemptTimeout = cfg.RequestTimeout
+	resilience.FailureThreshold = cfg.BreakerThreshold
+	resilience.Cooldown = cfg.BreakerCooldown
+	service.SetResilience(resilience)
+	service.SetTaskModels(taskModels)
+
+	if cfg.PromptsDir != "" {
+		prompts, err := LoadPrompts(cfg.PromptsDir)
 		if err != nil {
-			return nil, fmt.Errorf("failed to initialize Gemini: %w", err)
+			return nil, fmt.Errorf("failed to load prompt overrides: %w", err)
 		}
+		service.SetPrompts(prompts)
 	}
 
-	if claude == nil && gemini == nil {
+	return service, nil
+}
+
+// NewServiceWithRegistry creates a service around an already populated registry.
+// routes lists, per task, the provider names to try in order of preference.
+func NewServiceWithRegistry(registry *Registry, routes map[Task][]string, defaultProvider string) (*Service, error) {
+	if registry.Len() == 0 {
 		return nil, fmt.Errorf("at least one AI provider must be configured")
 	}
+	if routes == nil {
+		routes = make(map[Task][]string)
+	}
 
 	return &Service{
-		claude:          claude,
-		gemini:          gemini,
+		registry:        registry,
+		routes:          routes,
 		defaultProvider: defaultProvider,
+		resilience:      DefaultResilienceConfig(),
+		breakers:        make(map[string]*CircuitBreaker),
+		prompts:         DefaultPrompts(),
 	}, nil
 }
 
+// SetPrompts replaces the prompt templates used for every task
+func (s *Service) SetPrompts(prompts *PromptSet) {
+	s.prompts = prompts
+}
+
+// Prompts returns the prompt templates in use
+func (s *Service) Prompts() *PromptSet {
+	return s.prompts
+}
+
+// SetResilience replaces the retry and circuit breaker settings and resets
+// every breaker. Zero fields keep their defaults.
+func (s *Service) SetResilience(rc ResilienceConfig) {
+	defaults := DefaultResilienceConfig()
+	if rc.MaxAttempts <= 0 {
+		rc.MaxAttempts = defaults.MaxAttempts
+	}
+	if rc.BaseDelay <= 0 {
+		rc.BaseDelay = defaults.BaseDelay
+	}
+	if rc.MaxDelay <= 0 {
+		rc.MaxDelay = defaults.MaxDelay
+	}
+	if rc.AttemptTimeout <= 0 {
+		rc.AttemptTimeout = defaults.AttemptTimeout
+	}
+	if rc.FailureThreshold <= 0 {
+		rc.FailureThreshold = defaults.FailureThreshold
+	}
+	if rc.Cooldown <= 0 {
+		rc.Cooldown = defaults.Cooldown
+	}
+
+	s.breakersMu.Lock()
+	defer s.breakersMu.Unlock()
+	s.resilience = rc
+	s.breakers = make(map[string]*CircuitBreaker)
+}
+
+// Registry returns the provider registry backing this service
+func (s *Service) Registry() *Registry {
+	return s.registry
+}
+
 // GetProvider returns the specified provider or default
 func (s *Service) GetProvider(name string) (AIProvider, error) {
 	if name == "" {
 		name = s.defaultProvider
 	}
+	return s.registry.Get(name)
+}
 
-	switch name {
-	case "claude":
-		if s.claude == nil {
-			return nil, fmt.Errorf("Claude provider not configured")
-		}
-		return s.claude, nil
-	case "gemini":
-		if s.gemini == nil {
-			return nil, fmt.Errorf("Gemini provider not configured")
+// candidates returns the provider names to try for a task: the configured
+// route first, then the default provider, then anything else that can serve it
+func (s *Service) candidates(task Task) []string {
+	seen := make(map[string]bool)
+	var names []string
+
+	add := func(name string) {
+		if name == "" || seen[name] || !s.registry.Supports(name, task) {
+			return
 		}
-		return s.gemini, nil
-	default:
-		return nil, fmt.Errorf("unknown provider: %s", name)
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	for _, name := range s.routes[task] {
+		add(name)
 	}
+	add(s.defaultProvider)
+	for _, name := range s.registry.Names() {
+		add(name)
+	}
+
+	return names
 }
 
-// GenerateQuest uses Claude (best for creative, Socratic teaching)
-func (s *Service) GenerateQuest(ctx context.Context, userLevel string, language string, ghostWords []string) (string, error) {
-	provider, err := s.GetProvider("claude")
-	if err != nil {
-		// Fallback to Gemini
-		provider, err = s.GetProvider("gemini")
+// breaker returns the circuit breaker for a provider, creating it on first use
+func (s *Service) breaker(name string) *CircuitBreaker {
+	s.breakersMu.Lock()
+	defer s.breakersMu.Unlock()
+
+	b, ok := s.breakers[name]
+	if !ok {
+		b = NewCircuitBreaker(s.resilience.FailureThreshold, s.resilience.Cooldown)
+		s.breakers[name] = b
+	}
+	return b
+}
+
+// ProviderStatuses reports the circuit breaker state of every registered provider
+func (s *Service) ProviderStatuses() []ProviderStatus {
+	names := s.registry.Names()
+	statuses := make([]ProviderStatus, 0, len(names))
+	for _, name := range names {
+		statuses = append(statuses, s.breaker(name).Status(name))
+	}
+	return statuses
+}
+
+// execute runs call against the providers routed for task. Each provider gets
+// a few attempts with backoff for transient errors; when it still fails (or its
+// breaker is open) the next healthy provider is tried.
+func (s *Service) execute(ctx context.Context, task Task, call func(ctx context.Context, provider AIProvider) error) error {
+	return s.executeNamed(ctx, task, func(ctx context.Context, name string, provider AIProvider) error {
+		return call(ctx, provider)
+	})
+}
+
+// executeNamed is execute for callers that need to know which provider answered
+func (s *Service) executeNamed(ctx context.Context, task Task, call func(ctx context.Context, name string, provider AIProvider) error) error {
+	names := s.candidates(task)
+	if len(names) == 0 {
+		return fmt.Errorf("no AI provider configured for %s", task)
+	}
+
+	// Meter every model call made on behalf of a user, failed ones included
+	if userID, ok := userIDFromContext(ctx); ok && s.usage != nil {
+		if err := s.usage.checkQuota(ctx, userID); err != nil {
+			return err
+		}
+		meter := &usageMeter{}
+		ctx = context.WithValue(ctx, usageMeterKey{}, meter)
+		defer func() {
+			if err := s.usage.record(ctx, userID, task, meter); err != nil {
+				log.Printf("⚠️  Failed to record AI usage for user %d: %v", userID, err)
+			}
+		}()
+	}
+
+	ctx = withTask(withPrompts(ctx, s.prompts), task)
+
+	var errs []error
+	for _, name := range names {
+		breaker := s.breaker(name)
+		if !breaker.Allow() {
+			errs = append(errs, fmt.Errorf("%s: circuit breaker open", name))
+			continue
+		}
+
+		provider, err := s.registry.Get(name)
 		if err != nil {
-			return "", err
+			errs = append(errs, err)
+			continue
+		}
+
+		providerCtx := ctx
+		if settings, ok := s.callSettings(task, name); ok {
+			providerCtx = withModelSettings(ctx, settings)
 		}
+		err = s.callWithRetry(providerCtx, task, func(ctx context.Context) error {
+			return call(ctx, name, provider)
+		})
+		if err == nil {
+			breaker.RecordSuccess()
+			return nil
+		}
+
+		// The caller went away: not the provider's fault, and no point failing over
+		if ctx.Err() != nil {
+			breaker.Release()
+			return ctx.Err()
+		}
+
+		breaker.RecordFailure(err)
+		log.Printf("⚠️  AI provider %s failed for %s: %v", name, task, err)
+		errs = append(errs, fmt.Errorf("%s: %w", name, err))
 	}
-	return provider.GenerateQuest(ctx, userLevel, language, ghostWords)
+
+	return fmt.Errorf("%w for %s: %w", ErrAllProvidersFailed, task, errors.Join(errs...))
 }
 
-// ValidateQuestSubmission uses Claude (best for nuanced feedback)
-func (s *Service) ValidateQuestSubmission(ctx context.Context, quest string, userText string, language string) (bool, string, error) {
-	provider, err := s.GetProvider("claude")
-	if err != nil {
-		provider, err = s.GetProvider("gemini")
-		if err != nil {
-			return false, "", err
+// callWithRetry calls a single provider, retrying transient errors with backoff
+func (s *Service) callWithRetry(ctx context.Context, task Task, call func(ctx context.Context) error) error {
+	s.breakersMu.Lock()
+	rc := s.resilience
+	s.breakersMu.Unlock()
+	timeout := s.attemptTimeout(task, rc)
+
+	var err error
+	for attempt := 1; attempt <= rc.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			if sleepErr := sleep(ctx, rc.backoff(attempt-1)); sleepErr != nil {
+				return sleepErr
+			}
+		}
+
+		attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+		err = call(attemptCtx)
+		cancel()
+
+		if err == nil || ctx.Err() != nil || !isRetryable(err) {
+			return err
 		}
 	}
-	return provider.ValidateQuestSubmission(ctx, quest, userText, language)
+	return err
 }
 
-// Translate uses Gemini (faster, cheaper for simple translations)
-func (s *Service) Translate(ctx context.Context, text string, fromLang string, toLang string) (string, error) {
-	provider, err := s.GetProvider("gemini")
+// GenerateQuest creates a writing quest for the learner
+func (s *Service) GenerateQuest(ctx context.Context, userLevel string, language string, ghostWords []string) (*QuestResult, error) {
+	var quest *QuestResult
+	err := s.execute(ctx, TaskQuestGeneration, func(ctx context.Context, provider AIProvider) error {
+		var err error
+		quest, err = provider.GenerateQuest(ctx, userLevel, language, ghostWords)
+		return err
+	})
 	if err != nil {
-		// Fallback to Claude
-		provider, err = s.GetProvider("claude")
-		if err != nil {
-			return "", err
-		}
+		return nil, err
 	}
-	return provider.Translate(ctx, text, fromLang, toLang)
+	quest.PromptVersion = s.promptVersion(string(TaskQuestGeneration), language)
+	return quest, nil
 }
 
-// AnalyzeGrammar uses Gemini (good for structured analysis)
-func (s *Service) AnalyzeGrammar(ctx context.Context, text string, language string) (map[string]interface{}, error) {
-	provider, err := s.GetProvider("gemini")
+// ValidateQuestSubmission checks a quest submission and returns Socratic
+// feedback. A pass the submission cannot support is overruled.
+func (s *Service) ValidateQuestSubmission(ctx context.Context, quest string, userText string, language string) (*ValidationResult, error) {
+	var result *ValidationResult
+	err := s.execute(ctx, TaskQuestValidation, func(ctx context.Context, provider AIProvider) error {
+		var err error
+		result, err = provider.ValidateQuestSubmission(ctx, quest, userText, language)
+		return err
+	})
 	if err != nil {
-		provider, err = s.GetProvider("claude")
-		if err != nil {
-			return nil, err
-		}
+		return nil, err
 	}
-	return provider.AnalyzeGrammar(ctx, text, language)
+	checkVerdict(userText, result)
+	return result, nil
+}
+
+// GenerateSocraticFeedback gives free-form guidance on a piece of learner text
+func (s *Service) GenerateSocraticFeedback(ctx context.Context, userText string, language string) (string, error) {
+	var feedback string
+	err := s.execute(ctx, TaskSocraticFeedback, func(ctx context.Context, provider AIProvider) error {
+		var err error
+		feedback, err = provider.GenerateSocraticFeedback(ctx, userText, language)
+		return err
+	})
+	return feedback, err
+}
+
+// Translate translates text between two languages
+func (s *Service) Translate(ctx context.Context, text string, fromLang string, toLang string) (string, error) {
+	version := s.promptVersion(string(TaskTranslation), fromLang)
+	return withCache(ctx, s, TaskTranslation, fromLang+">"+toLang, text, version, func(ctx context.Context, provider AIProvider) (string, error) {
+		return provider.Translate(ctx, text, fromLang, toLang)
+	})
 }
 
-// GetWordDefinition uses Gemini (fast and good for dictionary lookups)
-func (s *Service) GetWordDefinition(ctx context.Context, word string, language string) (definition string, partOfSpeech string, examples []string, err error) {
-	provider, err := s.GetProvider("gemini")
+// AnalyzeGrammar checks the grammar of a piece of text. Every issue gets the
+// span of its excerpt in text; a text with an error-level issue is never
+// reported as correct.
+func (s *Service) AnalyzeGrammar(ctx context.Context, text string, language string) (*GrammarResult, error) {
+	version := s.promptVersion(string(TaskGrammar), language)
+	result, err := withCache(ctx, s, TaskGrammar, language, text, version, func(ctx context.Context, provider AIProvider) (*GrammarResult, error) {
+		return provider.AnalyzeGrammar(ctx, text, language)
+	})
 	if err != nil {
-		// Fallback to Claude
-		provider, err = s.GetProvider("claude")
-		if err != nil {
-			return "", "", nil, err
+		return nil, err
+	}
+
+	if result.Issues == nil {
+		result.Issues = []GrammarIssue{}
+	}
+	locateIssues(text, result.Issues)
+	for _, issue := range result.Issues {
+		if issue.Severity == SeverityError {
+			result.Correct = false
 		}
 	}
-	return provider.GetWordDefinition(ctx, word, language)
+	return result, nil
+}
+
+// GetWordDefinition looks up a dictionary-style definition for a word
+func (s *Service) GetWordDefinition(ctx context.Context, word string, language string) (*DefinitionResult, error) {
+	version := s.promptVersion(string(TaskDefinition), language)
+	result, err := withCache(ctx, s, TaskDefinition, language, word, version, func(ctx context.Context, provider AIProvider) (*DefinitionResult, error) {
+		return provider.GetWordDefinition(ctx, word, language)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.PromptVersion = version
+	return result, nil
 }
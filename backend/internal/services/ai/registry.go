@@ -0,0 +1,188 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/BachirKhiati/lexia/internal/config"
+)
+
+// Task identifies a kind of AI work so it can be routed to a provider
+type Task string
+
+const (
+	TaskQuestGeneration  Task = "quest_generation"
+	TaskQuestValidation  Task = "quest_validation"
+	TaskSocraticFeedback Task = "socratic_feedback"
+	TaskTranslation      Task = "translation"
+	TaskGrammar          Task = "grammar"
+	TaskDefinition       Task = "definition"
+	TaskTutor            Task = "tutor"
+	TaskSenseSelection   Task = "sense_selection"
+)
+
+// AllTasks lists every task an AIProvider can be asked to perform
+var AllTasks = []Task{
+	TaskQuestGeneration,
+	TaskQuestValidation,
+	TaskSocraticFeedback,
+	TaskTranslation,
+	TaskGrammar,
+	TaskDefinition,
+	TaskTutor,
+	TaskSenseSelection,
+}
+
+type taskKey struct{}
+
+// withTask records which task a provider call belongs to
+func withTask(ctx context.Context, task Task) context.Context {
+	return context.WithValue(ctx, taskKey{}, task)
+}
+
+func taskFromContext(ctx context.Context) (Task, bool) {
+	task, ok := ctx.Value(taskKey{}).(Task)
+	return task, ok
+}
+
+// BackendFactory builds a provider from configuration. It returns a nil
+// provider (and nil error) when the backend is not configured.
+type BackendFactory func(cfg config.AIConfig) (AIProvider, error)
+
+type backend struct {
+	factory      BackendFactory
+	capabilities []Task
+}
+
+var (
+	backendsMu sync.RWMutex
+	backends   = make(map[string]backend)
+)
+
+// RegisterBackend makes a provider implementation available to NewService.
+// Provider files call it from init() so adding a backend is a single new file.
+func RegisterBackend(name string, factory BackendFactory, capabilities ...Task) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+
+	if _, exists := backends[name]; exists {
+		panic(fmt.Sprintf("ai: backend %q registered twice", name))
+	}
+	backends[name] = backend{factory: factory, capabilities: capabilities}
+}
+
+// registeredProvider is a live provider together with the tasks it serves
+type registeredProvider struct {
+	provider     AIProvider
+	capabilities map[Task]bool
+}
+
+// Registry holds the configured providers keyed by name
+type Registry struct {
+	mu        sync.RWMutex
+	providers map[string]*registeredProvider
+}
+
+// NewRegistry creates an empty provider registry
+func NewRegistry() *Registry {
+	return &Registry{
+		providers: make(map[string]*registeredProvider),
+	}
+}
+
+// Register adds a provider under name. With no capabilities it serves every task.
+func (r *Registry) Register(name string, provider AIProvider, capabilities ...Task) error {
+	if provider == nil {
+		return fmt.Errorf("provider %s is nil", name)
+	}
+	if len(capabilities) == 0 {
+		capabilities = AllTasks
+	}
+
+	caps := make(map[Task]bool, len(capabilities))
+	for _, task := range capabilities {
+		caps[task] = true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.providers[name]; exists {
+		return fmt.Errorf("provider %s already registered", name)
+	}
+	r.providers[name] = &registeredProvider{provider: provider, capabilities: caps}
+	return nil
+}
+
+// Get returns the provider registered under name
+func (r *Registry) Get(name string) (AIProvider, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rp, ok := r.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("provider %s not configured", name)
+	}
+	return rp.provider, nil
+}
+
+// Supports reports whether the named provider is registered and can serve task
+func (r *Registry) Supports(name string, task Task) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rp, ok := r.providers[name]
+	return ok && rp.capabilities[task]
+}
+
+// Names returns the registered provider names in sorted order
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Len returns the number of registered providers
+func (r *Registry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.providers)
+}
+
+// registryFromConfig instantiates every registered backend that is configured
+func registryFromConfig(cfg config.AIConfig) (*Registry, error) {
+	backendsMu.RLock()
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	backendsMu.RUnlock()
+	sort.Strings(names)
+
+	registry := NewRegistry()
+	for _, name := range names {
+		backendsMu.RLock()
+		b := backends[name]
+		backendsMu.RUnlock()
+
+		provider, err := b.factory(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize %s: %w", name, err)
+		}
+		if provider == nil {
+			continue
+		}
+		if err := registry.Register(name, provider, b.capabilities...); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
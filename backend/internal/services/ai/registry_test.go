@@ -0,0 +1,94 @@
+package ai
+
+import (
+	"context"
+	"testing"
+)
+
+// namedProvider is a minimal AIProvider that reports its own name as output
+type namedProvider struct {
+	name string
+}
+
+func (p *namedProvider) Complete(ctx context.Context, prompt string) (string, error) {
+	return p.name, nil
+}
+
+func (p *namedProvider) GenerateQuest(ctx context.Context, userLevel string, language string, ghostWords []string) (*QuestResult, error) {
+	return &QuestResult{Title: p.name}, nil
+}
+
+func (p *namedProvider) ValidateQuestSubmission(ctx context.Context, quest string, userText string, language string) (*ValidationResult, error) {
+	return &ValidationResult{IsValid: true, Feedback: p.name}, nil
+}
+
+func (p *namedProvider) GenerateSocraticFeedback(ctx context.Context, userText string, language string) (string, error) {
+	return p.name, nil
+}
+
+func (p *namedProvider) Translate(ctx context.Context, text string, fromLang string, toLang string) (string, error) {
+	return p.name, nil
+}
+
+func (p *namedProvider) AnalyzeGrammar(ctx context.Context, text string, language string) (*GrammarResult, error) {
+	return &GrammarResult{Correct: true, Issues: []GrammarIssue{{Explanation: p.name}}}, nil
+}
+
+func (p *namedProvider) GetWordDefinition(ctx context.Context, word string, language string) (*DefinitionResult, error) {
+	return &DefinitionResult{Definition: p.name, PartOfSpeech: "noun"}, nil
+}
+
+func TestServiceRoutesTasksByConfig(t *testing.T) {
+	registry := NewRegistry()
+	if err := registry.Register("alpha", &namedProvider{name: "alpha"}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	// beta only knows how to translate
+	if err := registry.Register("beta", &namedProvider{name: "beta"}, TaskTranslation); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	routes := map[Task][]string{
+		TaskTranslation: {"missing", "beta", "alpha"},
+		TaskDefinition:  {"beta", "alpha"},
+	}
+	service, err := NewServiceWithRegistry(registry, routes, "alpha")
+	if err != nil {
+		t.Fatalf("NewServiceWithRegistry failed: %v", err)
+	}
+
+	ctx := context.Background()
+
+	got, err := service.Translate(ctx, "talo", "finnish", "english")
+	if err != nil || got != "beta" {
+		t.Errorf("Translate routed to %q (err %v), want beta", got, err)
+	}
+
+	// beta lacks the definition capability, so the route skips it
+	definition, err := service.GetWordDefinition(ctx, "talo", "finnish")
+	if err != nil || definition.Definition != "alpha" {
+		t.Errorf("GetWordDefinition routed to %+v (err %v), want alpha", definition, err)
+	}
+
+	// No route configured: default provider is used
+	quest, err := service.GenerateQuest(ctx, "beginner", "finnish", nil)
+	if err != nil || quest.Title != "alpha" {
+		t.Errorf("GenerateQuest routed to %+v (err %v), want alpha", quest, err)
+	}
+}
+
+func TestRegistryRejectsDuplicates(t *testing.T) {
+	registry := NewRegistry()
+	if err := registry.Register("alpha", &namedProvider{name: "alpha"}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if err := registry.Register("alpha", &namedProvider{name: "alpha"}); err == nil {
+		t.Error("expected duplicate registration to fail")
+	}
+}
+
+func TestNewServiceWithRegistryRequiresProvider(t *testing.T) {
+	if _, err := NewServiceWithRegistry(NewRegistry(), nil, "claude"); err == nil {
+		t.Error("expected error for empty registry")
+	}
+}
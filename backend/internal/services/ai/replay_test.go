@@ -0,0 +1,203 @@
+package ai
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Fixtures in testdata are replayed by default. To re-record them against the
+// real APIs run:
+//
+//	AI_RECORD=1 CLAUDE_API_KEY=... GEMINI_API_KEY=... go test ./internal/services/ai -run Replay
+func replayClient(t *testing.T, fixture, keyEnv string) (*http.Client, string) {
+	t.Helper()
+
+	mode, apiKey := ModeReplay, "test-key"
+	if os.Getenv("AI_RECORD") == "1" {
+		mode, apiKey = ModeRecord, os.Getenv(keyEnv)
+		if apiKey == "" {
+			t.Skipf("%s is required to record", keyEnv)
+		}
+	}
+
+	recorder, err := NewRecorder(filepath.Join("testdata", fixture), mode, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := recorder.Save(); err != nil {
+			t.Errorf("failed to save fixture: %v", err)
+		}
+	})
+	return recorder.Client(), apiKey
+}
+
+const (
+	fixtureQuest      = "Kirjoita kolme lausetta aamustasi. Käytä sanoja kahvi ja herätä."
+	fixtureSubmission = "Herään kello seitsemän. Juon kahvi. Sitten lähden kouluun."
+)
+
+func TestReplayClaudeFlows(t *testing.T) {
+	client, apiKey := replayClient(t, "claude_flows.json", "CLAUDE_API_KEY")
+	provider, err := NewClaudeProvider(apiKey, WithHTTPClient(client))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	quest, err := provider.GenerateQuest(ctx, "beginner", "finnish", []string{"kahvi", "herätä"})
+	if err != nil {
+		t.Fatalf("GenerateQuest: %v", err)
+	}
+	if quest.Title == "" || !strings.Contains(quest.Description, "kahvi") {
+		t.Errorf("quest = %+v", quest)
+	}
+
+	verdict, err := provider.ValidateQuestSubmission(ctx, fixtureQuest, "Herään kello seitsemän. Juon kahvia. Sitten lähden kouluun.", "finnish")
+	if err != nil {
+		t.Fatalf("ValidateQuestSubmission: %v", err)
+	}
+	if !verdict.IsValid || verdict.Feedback == "" {
+		t.Errorf("verdict = %+v, want valid with feedback", verdict)
+	}
+
+	definition, err := provider.GetWordDefinition(ctx, "talo", "finnish")
+	if err != nil {
+		t.Fatalf("GetWordDefinition: %v", err)
+	}
+	if definition.PartOfSpeech != "noun" || len(definition.Examples) == 0 {
+		t.Errorf("definition = %+v", definition)
+	}
+}
+
+func TestReplayGeminiFlows(t *testing.T) {
+	client, apiKey := replayClient(t, "gemini_flows.json", "GEMINI_API_KEY")
+	provider, err := NewGeminiProvider(apiKey, WithHTTPClient(client))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	// Gemini wraps JSON in markdown fences; the parser must cope
+	quest, err := provider.GenerateQuest(ctx, "beginner", "finnish", []string{"kahvi", "herätä"})
+	if err != nil {
+		t.Fatalf("GenerateQuest: %v", err)
+	}
+	if quest.Title == "" || quest.Solution == "" {
+		t.Errorf("quest = %+v", quest)
+	}
+
+	verdict, err := provider.ValidateQuestSubmission(ctx, fixtureQuest, fixtureSubmission, "finnish")
+	if err != nil {
+		t.Fatalf("ValidateQuestSubmission: %v", err)
+	}
+	if verdict.IsValid {
+		t.Errorf("verdict = %+v, want invalid", verdict)
+	}
+
+	definition, err := provider.GetWordDefinition(ctx, "talo", "finnish")
+	if err != nil {
+		t.Fatalf("GetWordDefinition: %v", err)
+	}
+	if !strings.Contains(definition.Definition, "house") {
+		t.Errorf("definition = %+v", definition)
+	}
+}
+
+func TestReplayClaudeStream(t *testing.T) {
+	client, apiKey := replayClient(t, "claude_stream.json", "CLAUDE_API_KEY")
+	provider, err := NewClaudeProvider(apiKey, WithHTTPClient(client))
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry := NewRegistry()
+	if err := registry.Register("claude", provider); err != nil {
+		t.Fatal(err)
+	}
+	service, err := NewServiceWithRegistry(registry, nil, "claude")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sink := &recordingSink{}
+	verdict, err := service.StreamQuestValidation(context.Background(), fixtureQuest, fixtureSubmission, "finnish", sink)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verdict.IsValid {
+		t.Error("verdict is valid, want invalid")
+	}
+	if streamed := strings.TrimSpace(sink.text.String()); streamed != verdict.Feedback {
+		t.Errorf("streamed %q, verdict feedback %q", streamed, verdict.Feedback)
+	}
+}
+
+func TestRecorderRedactsAndMatchesInOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	upstream := &cannedTransport{bodies: []string{`{"n": 1}`, `{"n": 2}`}}
+
+	recorder, err := NewRecorder(path, ModeRecord, upstream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest("GET", "https://example.com/v1/models?key=secret&alt=json", nil)
+		req.Header.Set("x-api-key", "secret")
+		if _, err := recorder.Client().Do(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Errorf("fixture leaks credentials:\n%s", data)
+	}
+
+	replayer, err := NewRecorder(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`{"n":1}`, `{"n":2}`} {
+		resp, err := replayer.Client().Get("https://example.com/v1/models?alt=json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(body) != want {
+			t.Errorf("replayed %s, want %s", body, want)
+		}
+	}
+	if _, err := replayer.Client().Get("https://example.com/v1/models?alt=json"); err == nil {
+		t.Error("expected an error once the fixture is exhausted")
+	}
+}
+
+// cannedTransport stands in for a real API, answering with JSON bodies in order
+type cannedTransport struct {
+	bodies []string
+}
+
+func (f *cannedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := f.bodies[0]
+	f.bodies = f.bodies[1:]
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
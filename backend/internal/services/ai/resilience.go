@@ -0,0 +1,194 @@
+package ai
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// BreakerState is the state of a provider's circuit breaker
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"    // healthy, requests flow
+	BreakerOpen     BreakerState = "open"      // failing, requests skip this provider
+	BreakerHalfOpen BreakerState = "half_open" // cooldown over, one probe allowed
+)
+
+// ResilienceConfig controls retries and circuit breaking for provider calls
+type ResilienceConfig struct {
+	MaxAttempts      int           // attempts per provider, including the first
+	BaseDelay        time.Duration // first backoff delay, doubled on each retry
+	MaxDelay         time.Duration // upper bound for a single backoff delay
+	AttemptTimeout   time.Duration // deadline for a single provider call
+	FailureThreshold int           // consecutive failures before the breaker opens
+	Cooldown         time.Duration // how long an open breaker rejects calls
+}
+
+// DefaultResilienceConfig returns conservative production defaults
+func DefaultResilienceConfig() ResilienceConfig {
+	return ResilienceConfig{
+		MaxAttempts:      3,
+		BaseDelay:        250 * time.Millisecond,
+		MaxDelay:         4 * time.Second,
+		AttemptTimeout:   30 * time.Second,
+		FailureThreshold: 5,
+		Cooldown:         30 * time.Second,
+	}
+}
+
+// ProviderStatus is a snapshot of a provider's breaker for monitoring
+type ProviderStatus struct {
+	Name                string       `json:"name"`
+	State               BreakerState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	TotalRequests       int64        `json:"total_requests"`
+	TotalFailures       int64        `json:"total_failures"`
+	LastError           string       `json:"last_error,omitempty"`
+	OpenedAt            *time.Time   `json:"opened_at,omitempty"`
+}
+
+// CircuitBreaker stops sending traffic to a provider after repeated failures
+// and lets a single probe through once the cooldown has passed
+type CircuitBreaker struct {
+	mu                  sync.Mutex
+	state               BreakerState
+	consecutiveFailures int
+	threshold           int
+	cooldown            time.Duration
+	openedAt            time.Time
+	probing             bool
+	totalRequests       int64
+	totalFailures       int64
+	lastError           string
+	now                 func() time.Time
+}
+
+// NewCircuitBreaker creates a closed breaker
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &CircuitBreaker{
+		state:     BreakerClosed,
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// Allow reports whether a call may be made right now
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return true
+	case BreakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// RecordSuccess closes the breaker and resets the failure count
+func (b *CircuitBreaker) RecordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.totalRequests++
+	b.consecutiveFailures = 0
+	b.probing = false
+	b.state = BreakerClosed
+}
+
+// Release ends a call that neither succeeded nor failed, because its
+// caller went away. A half-open breaker lets the next probe through
+// instead of waiting forever for this one.
+func (b *CircuitBreaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// RecordFailure counts a failure and opens the breaker at the threshold,
+// or immediately when a half-open probe fails
+func (b *CircuitBreaker) RecordFailure(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.totalRequests++
+	b.totalFailures++
+	b.consecutiveFailures++
+	if err != nil {
+		b.lastError = err.Error()
+	}
+
+	if b.state == BreakerHalfOpen || b.consecutiveFailures >= b.threshold {
+		b.state = BreakerOpen
+		b.openedAt = b.now()
+	}
+	b.probing = false
+}
+
+// Status returns a snapshot of the breaker
+func (b *CircuitBreaker) Status(name string) ProviderStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := ProviderStatus{
+		Name:                name,
+		State:               b.state,
+		ConsecutiveFailures: b.consecutiveFailures,
+		TotalRequests:       b.totalRequests,
+		TotalFailures:       b.totalFailures,
+		LastError:           b.lastError,
+	}
+	if b.state != BreakerClosed {
+		openedAt := b.openedAt
+		status.OpenedAt = &openedAt
+	}
+	return status
+}
+
+// backoff returns the delay before retry number attempt (1-based) with jitter
+func (rc ResilienceConfig) backoff(attempt int) time.Duration {
+	delay := rc.BaseDelay << uint(attempt-1)
+	if delay <= 0 || delay > rc.MaxDelay {
+		delay = rc.MaxDelay
+	}
+	// Equal jitter keeps concurrent requests from retrying in lockstep while
+	// still waiting at least half the delay
+	if delay > 0 {
+		delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+	}
+	return delay
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,192 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// overloadedProvider fails every translation with Anthropic's 529 status
+type overloadedProvider struct {
+	namedProvider
+	calls int
+}
+
+func (p *overloadedProvider) Translate(ctx context.Context, text string, fromLang string, toLang string) (string, error) {
+	p.calls++
+	return "", &APIError{Provider: p.name, StatusCode: 529, Body: "overloaded"}
+}
+
+func newFailoverService(t *testing.T) (*Service, *overloadedProvider) {
+	t.Helper()
+
+	flaky := &overloadedProvider{namedProvider: namedProvider{name: "flaky"}}
+	registry := NewRegistry()
+	if err := registry.Register("flaky", flaky); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.Register("steady", &namedProvider{name: "steady"}); err != nil {
+		t.Fatal(err)
+	}
+
+	service, err := NewServiceWithRegistry(registry, map[Task][]string{
+		TaskTranslation: {"flaky", "steady"},
+	}, "flaky")
+	if err != nil {
+		t.Fatal(err)
+	}
+	service.SetResilience(ResilienceConfig{
+		MaxAttempts:      2,
+		BaseDelay:        time.Millisecond,
+		MaxDelay:         time.Millisecond,
+		FailureThreshold: 2,
+		Cooldown:         time.Hour,
+	})
+	return service, flaky
+}
+
+func TestServiceFailsOverAndOpensBreaker(t *testing.T) {
+	service, flaky := newFailoverService(t)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		got, err := service.Translate(ctx, "talo", "finnish", "english")
+		if err != nil || got != "steady" {
+			t.Fatalf("Translate = %q, %v; want failover to steady", got, err)
+		}
+	}
+	// 2 requests x 2 attempts each
+	if flaky.calls != 4 {
+		t.Errorf("flaky provider called %d times, want 4", flaky.calls)
+	}
+
+	// Breaker is now open, so flaky is skipped entirely
+	if _, err := service.Translate(ctx, "talo", "finnish", "english"); err != nil {
+		t.Fatal(err)
+	}
+	if flaky.calls != 4 {
+		t.Errorf("open breaker still let calls through: %d calls", flaky.calls)
+	}
+
+	for _, status := range service.ProviderStatuses() {
+		switch status.Name {
+		case "flaky":
+			if status.State != BreakerOpen || status.TotalFailures != 2 {
+				t.Errorf("flaky status = %+v, want open with 2 failures", status)
+			}
+		case "steady":
+			if status.State != BreakerClosed {
+				t.Errorf("steady status = %+v, want closed", status)
+			}
+		}
+	}
+}
+
+func TestServiceReportsAllProvidersFailed(t *testing.T) {
+	flaky := &overloadedProvider{namedProvider: namedProvider{name: "flaky"}}
+	registry := NewRegistry()
+	if err := registry.Register("flaky", flaky); err != nil {
+		t.Fatal(err)
+	}
+	service, err := NewServiceWithRegistry(registry, nil, "flaky")
+	if err != nil {
+		t.Fatal(err)
+	}
+	service.SetResilience(ResilienceConfig{MaxAttempts: 1})
+
+	_, err = service.Translate(context.Background(), "talo", "finnish", "english")
+	if !errors.Is(err, ErrAllProvidersFailed) {
+		t.Errorf("err = %v, want ErrAllProvidersFailed", err)
+	}
+}
+
+func TestCircuitBreakerHalfOpenProbe(t *testing.T) {
+	now := time.Now()
+	breaker := NewCircuitBreaker(1, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	breaker.RecordFailure(errors.New("boom"))
+	if breaker.Allow() {
+		t.Fatal("breaker should be open")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if !breaker.Allow() {
+		t.Fatal("breaker should allow a probe after cooldown")
+	}
+	if breaker.Allow() {
+		t.Fatal("only one probe may be in flight")
+	}
+
+	breaker.RecordSuccess()
+	if !breaker.Allow() || breaker.Status("p").State != BreakerClosed {
+		t.Error("successful probe should close the breaker")
+	}
+}
+
+// cancellingProvider stands for a client that goes away mid-call
+type cancellingProvider struct {
+	namedProvider
+	cancel context.CancelFunc
+}
+
+func (p *cancellingProvider) Translate(ctx context.Context, text string, fromLang string, toLang string) (string, error) {
+	p.cancel()
+	return "", ctx.Err()
+}
+
+func TestCancelledProbeReleasesBreaker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	provider := &cancellingProvider{namedProvider: namedProvider{name: "slow"}, cancel: cancel}
+	registry := NewRegistry()
+	if err := registry.Register("slow", provider); err != nil {
+		t.Fatal(err)
+	}
+	service, err := NewServiceWithRegistry(registry, nil, "slow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	service.SetResilience(ResilienceConfig{MaxAttempts: 1, FailureThreshold: 1, Cooldown: time.Minute})
+
+	now := time.Now()
+	breaker := service.breaker("slow")
+	breaker.now = func() time.Time { return now }
+	breaker.RecordFailure(errors.New("boom"))
+	now = now.Add(2 * time.Minute)
+
+	// The probe is let through, and its caller cancels
+	if _, err := service.Translate(ctx, "talo", "finnish", "english"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if status := breaker.Status("slow"); status.State != BreakerHalfOpen || status.TotalFailures != 1 {
+		t.Errorf("status = %+v, want half-open with the one failure", status)
+	}
+	if !breaker.Allow() {
+		t.Error("a cancelled probe must not keep the breaker from probing again")
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&APIError{StatusCode: 529}, true},
+		{&APIError{StatusCode: 429}, true},
+		{&APIError{StatusCode: 400}, false},
+		{context.DeadlineExceeded, true},
+		{ErrEmptyResponse, true},
+		{fmt.Errorf("%w: missing field", ErrInvalidStructuredOutput), false},
+		{&json.SyntaxError{}, false},
+		{errors.New("AI returned empty definition"), false},
+	}
+
+	for _, tt := range tests {
+		if got := isRetryable(tt.err); got != tt.want {
+			t.Errorf("isRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SenseResult is the model's choice among numbered senses
+type SenseResult struct {
+	Sense int `json:"sense"` // 1-based
+}
+
+// ChooseSense asks which of senses is meant by word in sentence. It returns
+// the 0-based index of the chosen sense.
+func (s *Service) ChooseSense(ctx context.Context, word, sentence, language string, senses []string) (int, error) {
+	if len(senses) == 0 {
+		return 0, fmt.Errorf("no senses to choose from")
+	}
+
+	prompt, p, err := s.prompts.Render(string(TaskSenseSelection), PromptData{
+		Language: language,
+		Word:     word,
+		Text:     sentence,
+		Senses:   senses,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	input := word + "\n" + sentence + "\n" + strings.Join(senses, "\n")
+	result, err := withCache(ctx, s, TaskSenseSelection, language, input, p.ID(), func(ctx context.Context, provider AIProvider) (*SenseResult, error) {
+		response, err := complete(ctx, provider, prompt)
+		if err != nil {
+			return nil, err
+		}
+
+		var result SenseResult
+		if err := decodeStructured(ctx, provider, SenseSchema, response, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse sense selection: %w", err)
+		}
+		if result.Sense < 1 || result.Sense > len(senses) {
+			return nil, fmt.Errorf("%w: sense %d out of range 1-%d", ErrInvalidStructuredOutput, result.Sense, len(senses))
+		}
+		return &result, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.Sense - 1, nil
+}
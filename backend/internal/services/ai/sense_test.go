@@ -0,0 +1,33 @@
+package ai
+
+import (
+	"context"
+	"testing"
+)
+
+func TestChooseSense(t *testing.T) {
+	fake := NewFakeProvider("fake").Script(TaskSenseSelection,
+		Reply(`{"sense": 5}`), // out of range: fails the attempt
+	)
+	service, err := NewFakeService(fake)
+	if err != nil {
+		t.Fatal(err)
+	}
+	senses := []string{"six", "spruce"}
+
+	if _, err := service.ChooseSense(context.Background(), "kuusi", "Näin kuusen.", "finnish", senses); err == nil {
+		t.Error("expected an error for an out-of-range sense")
+	}
+
+	fake.Script(TaskSenseSelection, Reply(`{"sense": 1.5}`), Reply(`{"sense": 2}`))
+	got, err := service.ChooseSense(context.Background(), "kuusi", "Näin kuusen.", "finnish", senses)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != 1 {
+		t.Errorf("ChooseSense = %d, want 1 (spruce)", got)
+	}
+	if n := fake.CallCount(TaskSenseSelection); n != 3 {
+		t.Errorf("calls = %d, want 3 (including one repair)", n)
+	}
+}
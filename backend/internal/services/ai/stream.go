@@ -0,0 +1,238 @@
+package ai
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Streamer is implemented by providers that can deliver a completion as it is
+// generated. onText receives each fragment; the full text is returned at the end.
+type Streamer interface {
+	CompleteStream(ctx context.Context, prompt RenderedPrompt, onText func(fragment string) error) (string, error)
+}
+
+// StreamSink receives the text of a streamed answer
+type StreamSink interface {
+	// Text is called with each new fragment, in order
+	Text(fragment string) error
+	// Reset is called when a provider failed after sending text and the
+	// answer is being generated again; everything sent so far is void
+	Reset() error
+}
+
+// verdictMarker starts the last line of a streamed quest validation
+const verdictMarker = "VERDICT:"
+
+// StreamQuestValidation is ValidateQuestSubmission with the Socratic feedback
+// forwarded to sink while it is generated. The verdict line the model ends
+// with is held back and returned as the result.
+func (s *Service) StreamQuestValidation(ctx context.Context, quest string, userText string, language string, sink StreamSink) (*ValidationResult, error) {
+	var result *ValidationResult
+	filter := &verdictFilter{sink: sink}
+	prompt, _, err := s.prompts.Render(PromptQuestValidationStream, PromptData{
+		Language:      language,
+		Quest:         quest,
+		Text:          userText,
+		VerdictMarker: verdictMarker,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.stream(ctx, TaskQuestValidation, prompt, filter, func(text string) error {
+		verdict, err := parseVerdict(text)
+		if err != nil {
+			return err
+		}
+		result = verdict
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	checkVerdict(userText, result)
+	return result, nil
+}
+
+// StreamSocraticFeedback is GenerateSocraticFeedback with the text forwarded
+// to sink while it is generated
+func (s *Service) StreamSocraticFeedback(ctx context.Context, userText string, language string, sink StreamSink) (string, error) {
+	prompt, _, err := s.prompts.Render(string(TaskSocraticFeedback), PromptData{Language: language, Text: userText})
+	if err != nil {
+		return "", err
+	}
+
+	var feedback string
+	err = s.stream(ctx, TaskSocraticFeedback, prompt, sink, func(text string) error {
+		feedback = strings.TrimSpace(text)
+		if feedback == "" {
+			return ErrEmptyResponse
+		}
+		return nil
+	})
+	return feedback, err
+}
+
+// stream runs prompt against the providers routed for task, with the usual
+// retries and failover. Providers that cannot stream answer in one piece.
+// finish checks the complete text; an error there counts as a failed attempt.
+func (s *Service) stream(ctx context.Context, task Task, prompt RenderedPrompt, sink StreamSink, finish func(text string) error) error {
+	sent := false
+	onText := func(fragment string) error {
+		if fragment == "" {
+			return nil
+		}
+		sent = true
+		return sink.Text(fragment)
+	}
+
+	return s.execute(ctx, task, func(ctx context.Context, provider AIProvider) error {
+		// A previous attempt already showed partial text to the client
+		if sent {
+			if err := sink.Reset(); err != nil {
+				return err
+			}
+			sent = false
+		}
+
+		var text string
+		var err error
+		if streamer, ok := provider.(Streamer); ok {
+			text, err = streamer.CompleteStream(ctx, prompt, onText)
+		} else {
+			text, err = complete(ctx, provider, prompt)
+			if err == nil {
+				err = onText(text)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		return finish(text)
+	})
+}
+
+// parseVerdict splits a streamed validation into feedback and verdict
+func parseVerdict(text string) (*ValidationResult, error) {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.ToUpper(strings.TrimLeft(lines[i], " \t*"))
+		if !strings.HasPrefix(line, verdictMarker) {
+			continue
+		}
+
+		// Only the first word counts: "FAIL – does not pass" is a fail, and
+		// "NOT PASSED" no verdict at all
+		var verdict string
+		if fields := strings.Fields(line[len(verdictMarker):]); len(fields) > 0 {
+			verdict = strings.TrimRight(fields[0], "*.!,;:")
+		}
+		var valid bool
+		switch verdict {
+		case "PASS":
+			valid = true
+		case "FAIL":
+			valid = false
+		default:
+			return nil, fmt.Errorf("%w: unrecognized verdict %q", ErrInvalidStructuredOutput, strings.TrimSpace(lines[i]))
+		}
+
+		feedback := strings.TrimSpace(strings.Join(lines[:i], "\n"))
+		if feedback == "" {
+			return nil, fmt.Errorf("%w: verdict without feedback", ErrInvalidStructuredOutput)
+		}
+		return &ValidationResult{IsValid: valid, Feedback: feedback}, nil
+	}
+	return nil, fmt.Errorf("%w: missing verdict line", ErrInvalidStructuredOutput)
+}
+
+// verdictFilter forwards streamed feedback but swallows the verdict line.
+// Text at the start of a line is held back until it can no longer be the
+// marker, so the client never sees a half-written "VERD".
+type verdictFilter struct {
+	sink    StreamSink
+	pending string
+	decided bool // the current line is known not to be the verdict
+	done    bool // the verdict line has started; nothing more is forwarded
+}
+
+func (f *verdictFilter) Text(fragment string) error {
+	if f.done {
+		return nil
+	}
+	f.pending += fragment
+
+	for f.pending != "" {
+		if f.decided {
+			end := strings.IndexByte(f.pending, '\n')
+			if end < 0 {
+				out := f.pending
+				f.pending = ""
+				return f.sink.Text(out)
+			}
+			out := f.pending[:end+1]
+			f.pending = f.pending[end+1:]
+			f.decided = false
+			if err := f.sink.Text(out); err != nil {
+				return err
+			}
+			continue
+		}
+
+		candidate := strings.ToUpper(strings.TrimLeft(f.pending, " \t*"))
+		switch {
+		case strings.HasPrefix(candidate, verdictMarker):
+			f.done = true
+			f.pending = ""
+			return nil
+		case strings.HasPrefix(verdictMarker, candidate):
+			// Could still become the marker; wait for more text
+			return nil
+		default:
+			f.decided = true
+		}
+	}
+	return nil
+}
+
+func (f *verdictFilter) Reset() error {
+	f.pending = ""
+	f.decided = false
+	f.done = false
+	return f.sink.Reset()
+}
+
+// readServerSentEvents calls fn for each event in an SSE body
+func readServerSentEvents(body io.Reader, fn func(event, data string) error) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var event string
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if len(data) > 0 {
+				if err := fn(event, strings.Join(data, "\n")); err != nil {
+					return err
+				}
+			}
+			event, data = "", nil
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if len(data) > 0 {
+		return fn(event, strings.Join(data, "\n"))
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recordingSink collects what a client would see
+type recordingSink struct {
+	text   strings.Builder
+	resets int
+}
+
+func (s *recordingSink) Text(fragment string) error {
+	s.text.WriteString(fragment)
+	return nil
+}
+
+func (s *recordingSink) Reset() error {
+	s.resets++
+	s.text.Reset()
+	return nil
+}
+
+// streamingProvider streams a fixed reply in small fragments. With failAfter
+// set it breaks off after that many fragments, like a dropped connection.
+type streamingProvider struct {
+	namedProvider
+	reply     string
+	failAfter int
+}
+
+func (p *streamingProvider) CompleteStream(ctx context.Context, prompt RenderedPrompt, onText func(fragment string) error) (string, error) {
+	for i := 0; i < len(p.reply); i += 3 {
+		if p.failAfter > 0 && i/3 == p.failAfter {
+			return "", &APIError{Provider: p.name, StatusCode: 529, Body: "overloaded"}
+		}
+		end := min(i+3, len(p.reply))
+		if err := onText(p.reply[i:end]); err != nil {
+			return "", err
+		}
+	}
+	return p.reply, nil
+}
+
+func TestStreamQuestValidationHidesVerdict(t *testing.T) {
+	provider := &streamingProvider{
+		namedProvider: namedProvider{name: "streaming"},
+		reply:         "What happens to the verb after \"minä\"?\nTry again.\n**VERDICT: FAIL**",
+	}
+	registry := NewRegistry()
+	if err := registry.Register("streaming", provider); err != nil {
+		t.Fatal(err)
+	}
+	service, err := NewServiceWithRegistry(registry, nil, "streaming")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sink := &recordingSink{}
+	verdict, err := service.StreamQuestValidation(context.Background(), "quest", "minä olla", "finnish", sink)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if verdict.IsValid {
+		t.Error("verdict is valid, want invalid")
+	}
+	if want := "What happens to the verb after \"minä\"?\nTry again."; verdict.Feedback != want {
+		t.Errorf("feedback = %q, want %q", verdict.Feedback, want)
+	}
+	if strings.Contains(strings.ToUpper(sink.text.String()), "VERD") {
+		t.Errorf("streamed text leaks the verdict: %q", sink.text.String())
+	}
+}
+
+func TestStreamResetsAfterMidStreamFailover(t *testing.T) {
+	flaky := &streamingProvider{
+		namedProvider: namedProvider{name: "flaky"},
+		reply:         "Half an answer that never finishes",
+		failAfter:     2,
+	}
+	registry := NewRegistry()
+	if err := registry.Register("flaky", flaky); err != nil {
+		t.Fatal(err)
+	}
+	// steady cannot stream, so its answer arrives in one piece
+	if err := registry.Register("steady", &namedProvider{name: "Good work!\nVERDICT: PASS"}); err != nil {
+		t.Fatal(err)
+	}
+	service, err := NewServiceWithRegistry(registry, map[Task][]string{
+		TaskQuestValidation: {"flaky", "steady"},
+	}, "flaky")
+	if err != nil {
+		t.Fatal(err)
+	}
+	service.SetResilience(ResilienceConfig{
+		MaxAttempts:      1,
+		FailureThreshold: 5,
+		Cooldown:         time.Hour,
+	})
+
+	sink := &recordingSink{}
+	verdict, err := service.StreamQuestValidation(context.Background(), "quest", "Minä olen opiskelija.", "finnish", sink)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !verdict.IsValid || verdict.Feedback != "Good work!" {
+		t.Errorf("verdict = %+v, want valid with steady's feedback", verdict)
+	}
+	if sink.resets != 1 {
+		t.Errorf("resets = %d, want 1", sink.resets)
+	}
+	if got := sink.text.String(); got != "Good work!\n" {
+		t.Errorf("client text after reset = %q", got)
+	}
+}
+
+func TestParseVerdict(t *testing.T) {
+	tests := []struct {
+		text    string
+		valid   bool
+		wantErr bool
+	}{
+		{"Hyvä!\nVERDICT: PASS", true, false},
+		{"Almost.\n\nverdict: fail\n", false, false},
+		{"No verdict here", false, true},
+		{"VERDICT: PASS", false, true},
+		{"Hmm.\nVERDICT: maybe", false, true},
+		{"Try again.\n**VERDICT: FAIL**", false, false},
+		{"Try again.\nVERDICT: FAIL – does not pass", false, false},
+		{"Close.\nVERDICT: NOT PASSED", false, true},
+		{"Close.\nVERDICT: PASSABLE", false, true},
+	}
+
+	for _, tt := range tests {
+		result, err := parseVerdict(tt.text)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidStructuredOutput) {
+				t.Errorf("parseVerdict(%q) error = %v, want ErrInvalidStructuredOutput", tt.text, err)
+			}
+			continue
+		}
+		if err != nil || result.IsValid != tt.valid {
+			t.Errorf("parseVerdict(%q) = %+v, %v", tt.text, result, err)
+		}
+	}
+}
+
+func TestReadServerSentEvents(t *testing.T) {
+	body := "event: ping\ndata: {}\n\n: comment\nevent: content_block_delta\ndata: {\"a\":1}\n\ndata: tail"
+
+	var got []string
+	err := readServerSentEvents(strings.NewReader(body), func(event, data string) error {
+		got = append(got, event+"="+data)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"ping={}", "content_block_delta={\"a\":1}", "=tail"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("events = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,285 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidStructuredOutput means the model's reply could not be turned into
+// the expected JSON shape, even after a repair round-trip
+var ErrInvalidStructuredOutput = errors.New("invalid structured output")
+
+// QuestResult is a generated writing quest
+type QuestResult struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Solution    string `json:"solution"`
+	// PromptVersion is the template revision that produced the quest
+	PromptVersion string `json:"-"`
+}
+
+// ValidationResult is the verdict on a quest submission
+type ValidationResult struct {
+	IsValid  bool   `json:"is_valid"`
+	Feedback string `json:"feedback"`
+}
+
+// DefinitionResult is a dictionary entry produced by a model
+type DefinitionResult struct {
+	Definition   string   `json:"definition"`
+	PartOfSpeech string   `json:"part_of_speech"`
+	Examples     []string `json:"examples"`
+	// PromptVersion is the template revision that produced the definition
+	PromptVersion string `json:"-"`
+}
+
+// Schema is the JSON schema a task's reply must satisfy. Only the subset we
+// need is enforced: object/string/boolean/integer/array types, required
+// properties, minLength and enum for strings and item types for arrays.
+type Schema struct {
+	Name string
+	Raw  string
+	doc  map[string]interface{}
+}
+
+func mustSchema(name, raw string) *Schema {
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &doc); err != nil {
+		panic(fmt.Sprintf("ai: invalid %s schema: %v", name, err))
+	}
+	return &Schema{Name: name, Raw: raw, doc: doc}
+}
+
+var (
+	QuestSchema = mustSchema("quest", `{
+  "type": "object",
+  "required": ["title", "description", "solution"],
+  "properties": {
+    "title": {"type": "string", "minLength": 1},
+    "description": {"type": "string", "minLength": 1},
+    "solution": {"type": "string"}
+  }
+}`)
+
+	ValidationSchema = mustSchema("validation", `{
+  "type": "object",
+  "required": ["is_valid", "feedback"],
+  "properties": {
+    "is_valid": {"type": "boolean"},
+    "feedback": {"type": "string", "minLength": 1}
+  }
+}`)
+
+	GrammarSchema = mustSchema("grammar", `{
+  "type": "object",
+  "required": ["correct", "issues"],
+  "properties": {
+    "correct": {"type": "boolean"},
+    "issues": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "required": ["excerpt", "category", "severity", "replacement", "explanation"],
+        "properties": {
+          "excerpt": {"type": "string", "minLength": 1},
+          "category": {"type": "string", "enum": ["case", "vowel_harmony", "word_order", "conjugation", "consonant_gradation", "agreement", "spelling", "vocabulary", "other"]},
+          "severity": {"type": "string", "enum": ["error", "warning", "suggestion"]},
+          "replacement": {"type": "string"},
+          "explanation": {"type": "string", "minLength": 1}
+        }
+      }
+    }
+  }
+}`)
+
+	SenseSchema = mustSchema("sense", `{
+  "type": "object",
+  "required": ["sense"],
+  "properties": {
+    "sense": {"type": "integer"}
+  }
+}`)
+
+	DefinitionSchema = mustSchema("definition", `{
+  "type": "object",
+  "required": ["definition", "part_of_speech"],
+  "properties": {
+    "definition": {"type": "string", "minLength": 1},
+    "part_of_speech": {"type": "string"},
+    "examples": {"type": "array", "items": {"type": "string"}}
+  }
+}`)
+)
+
+// Validate checks a decoded JSON value against the schema
+func (s *Schema) Validate(value interface{}) error {
+	return validateNode(s.doc, value, "$")
+}
+
+func validateNode(schema map[string]interface{}, value interface{}, path string) error {
+	switch schema["type"] {
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected object", path)
+		}
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, r := range required {
+				name, _ := r.(string)
+				if _, present := obj[name]; !present {
+					return fmt.Errorf("%s: missing required field %q", path, name)
+				}
+			}
+		}
+		props, _ := schema["properties"].(map[string]interface{})
+		names := make([]string, 0, len(props))
+		for name := range props {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			v, present := obj[name]
+			if !present {
+				continue
+			}
+			propSchema, _ := props[name].(map[string]interface{})
+			if err := validateNode(propSchema, v, path+"."+name); err != nil {
+				return err
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s: expected string", path)
+		}
+		if minLen, ok := schema["minLength"].(float64); ok && len(strings.TrimSpace(str)) < int(minLen) {
+			return fmt.Errorf("%s: must not be empty", path)
+		}
+		if enum, ok := schema["enum"].([]interface{}); ok && !containsValue(enum, str) {
+			return fmt.Errorf("%s: %q is not one of %v", path, str, enum)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: expected boolean", path)
+		}
+	case "integer":
+		if n, ok := value.(float64); !ok || n != float64(int64(n)) {
+			return fmt.Errorf("%s: expected integer", path)
+		}
+	case "array":
+		arr, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected array", path)
+		}
+		items, _ := schema["items"].(map[string]interface{})
+		for i, item := range arr {
+			if err := validateNode(items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// extractJSON pulls the first complete JSON object out of a model reply,
+// tolerating markdown fences and chatty text before or after it
+func extractJSON(text string) (string, error) {
+	start := strings.Index(text, "{")
+	if start < 0 {
+		return "", fmt.Errorf("no JSON object found in response")
+	}
+
+	depth := 0
+	inString := false
+	escaped := false
+	for i := start; i < len(text); i++ {
+		c := text[i]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch c {
+		case '"':
+			inString = true
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return text[start : i+1], nil
+			}
+		}
+	}
+	return "", fmt.Errorf("unterminated JSON object in response")
+}
+
+// parseStructured extracts, validates and decodes a reply into out
+func parseStructured(text string, schema *Schema, out interface{}) error {
+	raw, err := extractJSON(text)
+	if err != nil {
+		return err
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal([]byte(raw), &generic); err != nil {
+		return fmt.Errorf("response is not valid JSON: %w", err)
+	}
+	if err := schema.Validate(generic); err != nil {
+		return fmt.Errorf("response does not match %s schema: %w", schema.Name, err)
+	}
+	return json.Unmarshal([]byte(raw), out)
+}
+
+// completer is the raw text completion every provider exposes
+type completer interface {
+	Complete(ctx context.Context, prompt string) (string, error)
+}
+
+// decodeStructured parses a model reply into out. If the reply is unusable,
+// the model gets one chance to repair it before the call fails.
+func decodeStructured(ctx context.Context, c completer, schema *Schema, response string, out interface{}) error {
+	err := parseStructured(response, schema, out)
+	if err == nil {
+		return nil
+	}
+
+	repaired, repairErr := c.Complete(ctx, repairPrompt(schema, response, err))
+	if repairErr != nil {
+		return fmt.Errorf("%w: %v (repair failed: %v)", ErrInvalidStructuredOutput, err, repairErr)
+	}
+	if err := parseStructured(repaired, schema, out); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidStructuredOutput, err)
+	}
+	return nil
+}
+
+func repairPrompt(schema *Schema, response string, problem error) string {
+	return fmt.Sprintf(`Your previous reply could not be used: %v
+
+Previous reply:
+%s
+
+Return ONLY a JSON object that matches this JSON schema, with no markdown and no other text:
+%s`, problem, response, schema.Raw)
+}
@@ -0,0 +1,99 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// scriptedCompleter returns canned replies in order and records the prompts
+type scriptedCompleter struct {
+	replies []string
+	prompts []string
+}
+
+func (c *scriptedCompleter) Complete(ctx context.Context, prompt string) (string, error) {
+	c.prompts = append(c.prompts, prompt)
+	if len(c.replies) == 0 {
+		return "", errors.New("no scripted reply left")
+	}
+	reply := c.replies[0]
+	c.replies = c.replies[1:]
+	return reply, nil
+}
+
+func TestExtractJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", `{"a": 1}`, `{"a": 1}`},
+		{"fenced", "```json\n{\"a\": 1}\n```", `{"a": 1}`},
+		{"chatty", "Sure! Here is the quest:\n{\"a\": {\"b\": \"}\"}}\nGood luck!", `{"a": {"b": "}"}}`},
+		{"escaped quote", `{"a": "say \"hi\" {"}`, `{"a": "say \"hi\" {"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := extractJSON(tt.in)
+			if err != nil {
+				t.Fatalf("extractJSON failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("extractJSON = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := extractJSON("no json here"); err == nil {
+		t.Error("expected error when reply has no JSON object")
+	}
+}
+
+func TestSchemaValidate(t *testing.T) {
+	var result ValidationResult
+	if err := parseStructured(`{"is_valid": "yes", "feedback": "ok"}`, ValidationSchema, &result); err == nil {
+		t.Error("expected type error for string is_valid")
+	}
+	if err := parseStructured(`{"title": "T", "description": ""}`, QuestSchema, &QuestResult{}); err == nil {
+		t.Error("expected missing/empty field error")
+	}
+	if err := parseStructured(`{"correct": true, "issues": [1]}`, GrammarSchema, &GrammarResult{}); err == nil {
+		t.Error("expected item type error")
+	}
+	issue := `{"excerpt": "on", "category": "%s", "severity": "error", "replacement": "olen", "explanation": "1st person"}`
+	if err := parseStructured(`{"correct": false, "issues": [`+fmt.Sprintf(issue, "tense")+`]}`, GrammarSchema, &GrammarResult{}); err == nil {
+		t.Error("expected enum error for unknown category")
+	}
+	if err := parseStructured(`{"correct": false, "issues": [`+fmt.Sprintf(issue, "conjugation")+`]}`, GrammarSchema, &GrammarResult{}); err != nil {
+		t.Errorf("valid grammar result rejected: %v", err)
+	}
+}
+
+func TestDecodeStructuredRepairsOnce(t *testing.T) {
+	completer := &scriptedCompleter{replies: []string{`{"title": "Aamu", "description": "Kirjoita", "solution": "Heräsin."}`}}
+
+	var quest QuestResult
+	err := decodeStructured(context.Background(), completer, QuestSchema, "Here you go: title=Aamu", &quest)
+	if err != nil {
+		t.Fatalf("decodeStructured failed: %v", err)
+	}
+	if quest.Title != "Aamu" {
+		t.Errorf("Title = %q, want Aamu", quest.Title)
+	}
+	if len(completer.prompts) != 1 {
+		t.Errorf("expected exactly one repair round-trip, got %d", len(completer.prompts))
+	}
+}
+
+func TestDecodeStructuredFailsAfterRepair(t *testing.T) {
+	completer := &scriptedCompleter{replies: []string{"still not json"}}
+
+	var quest QuestResult
+	err := decodeStructured(context.Background(), completer, QuestSchema, "nope", &quest)
+	if !errors.Is(err, ErrInvalidStructuredOutput) {
+		t.Errorf("err = %v, want ErrInvalidStructuredOutput", err)
+	}
+}
@@ -0,0 +1,331 @@
+package ai
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrQuotaExceeded is returned (wrapped in a *QuotaError) when a user has used
+// up their AI allowance for the current period
+var ErrQuotaExceeded = errors.New("AI usage quota exceeded")
+
+// QuotaError describes which limit was hit and when it resets
+type QuotaError struct {
+	Period  string // "daily" or "monthly"
+	Limit   string // human-readable limit, e.g. "50000 tokens"
+	ResetAt time.Time
+}
+
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("%s AI usage quota of %s exceeded, resets at %s", e.Period, e.Limit, e.ResetAt.Format(time.RFC3339))
+}
+
+func (e *QuotaError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
+
+// Usage is the token count of a single model call
+type Usage struct {
+	Provider     string
+	Model        string
+	InputTokens  int
+	OutputTokens int
+}
+
+// Quotas are per-user limits. Zero means unlimited.
+type Quotas struct {
+	DailyTokens   int64   `json:"daily_tokens"`
+	MonthlyTokens int64   `json:"monthly_tokens"`
+	MonthlyBudget float64 `json:"monthly_budget_usd"`
+}
+
+// UsageRecord is one metered call, as stored
+type UsageRecord struct {
+	UserID       int
+	Task         Task
+	Provider     string
+	Model        string
+	InputTokens  int
+	OutputTokens int
+	CostUSD      float64
+}
+
+// UsageTotals aggregates usage over a period
+type UsageTotals struct {
+	Requests     int64   `json:"requests"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	CostUSD      float64 `json:"cost_usd"`
+}
+
+// Tokens returns input plus output tokens
+func (t UsageTotals) Tokens() int64 {
+	return t.InputTokens + t.OutputTokens
+}
+
+// TaskUsage is a user's usage of one task this month
+type TaskUsage struct {
+	Task Task `json:"task"`
+	UsageTotals
+}
+
+// UsageSummary is what a user sees about their own consumption
+type UsageSummary struct {
+	Today          UsageTotals `json:"today"`
+	ThisMonth      UsageTotals `json:"this_month"`
+	ByTask         []TaskUsage `json:"by_task"`
+	Quotas         Quotas      `json:"quotas"`
+	DailyResetAt   time.Time   `json:"daily_reset_at"`
+	MonthlyResetAt time.Time   `json:"monthly_reset_at"`
+}
+
+// UsageStore persists metered calls
+type UsageStore interface {
+	Record(ctx context.Context, records []UsageRecord) error
+	Totals(ctx context.Context, userID int, since time.Time) (UsageTotals, error)
+	ByTask(ctx context.Context, userID int, since time.Time) ([]TaskUsage, error)
+}
+
+// modelPrice is USD per million tokens
+type modelPrice struct {
+	input  float64
+	output float64
+}
+
+// modelPrices are list prices used for cost estimates, matched by model
+// name prefix. Unknown models (e.g. self-hosted) are treated as free.
+var modelPrices = []struct {
+	prefix string
+	price  modelPrice
+}{
+	{"claude-3-5-haiku", modelPrice{0.80, 4.00}},
+	{"claude-3-5-sonnet", modelPrice{3.00, 15.00}},
+	{"claude-3-opus", modelPrice{15.00, 75.00}},
+	{"gemini-2.0-flash", modelPrice{0.10, 0.40}},
+	{"gemini-1.5-pro", modelPrice{1.25, 5.00}},
+	{"gpt-4o-mini", modelPrice{0.15, 0.60}},
+	{"gpt-4o", modelPrice{2.50, 10.00}},
+}
+
+// EstimateCost returns the estimated USD cost of a call
+func EstimateCost(model string, inputTokens, outputTokens int) float64 {
+	for _, p := range modelPrices {
+		if strings.HasPrefix(model, p.prefix) {
+			return (float64(inputTokens)*p.price.input + float64(outputTokens)*p.price.output) / 1_000_000
+		}
+	}
+	return 0
+}
+
+type userIDKey struct{}
+
+// WithUserID attributes AI calls made with ctx to a user for metering
+func WithUserID(ctx context.Context, userID int) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+func userIDFromContext(ctx context.Context) (int, bool) {
+	userID, ok := ctx.Value(userIDKey{}).(int)
+	return userID, ok && userID > 0
+}
+
+// usageMeter collects the usage of every model call made for one task,
+// including retries, failovers and repair round-trips
+type usageMeter struct {
+	mu      sync.Mutex
+	entries []Usage
+}
+
+type usageMeterKey struct{}
+
+// reportUsage is called by providers after each model call
+func reportUsage(ctx context.Context, usage Usage) {
+	meter, ok := ctx.Value(usageMeterKey{}).(*usageMeter)
+	if !ok {
+		return
+	}
+	meter.mu.Lock()
+	meter.entries = append(meter.entries, usage)
+	meter.mu.Unlock()
+}
+
+// usageTracker enforces quotas and records usage for the service
+type usageTracker struct {
+	store  UsageStore
+	quotas Quotas
+	now    func() time.Time
+}
+
+// SetUsageTracking enables per-user metering and quota enforcement
+func (s *Service) SetUsageTracking(store UsageStore, quotas Quotas) {
+	s.usage = &usageTracker{store: store, quotas: quotas, now: time.Now}
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+func startOfMonth(t time.Time) time.Time {
+	y, m, _ := t.Date()
+	return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+}
+
+// checkQuota returns a *QuotaError if the user is over any limit
+func (u *usageTracker) checkQuota(ctx context.Context, userID int) error {
+	q := u.quotas
+	if q.DailyTokens == 0 && q.MonthlyTokens == 0 && q.MonthlyBudget == 0 {
+		return nil
+	}
+
+	now := u.now()
+	if q.DailyTokens > 0 {
+		today, err := u.store.Totals(ctx, userID, startOfDay(now))
+		if err != nil {
+			return fmt.Errorf("failed to check AI quota: %w", err)
+		}
+		if today.Tokens() >= q.DailyTokens {
+			return &QuotaError{Period: "daily", Limit: fmt.Sprintf("%d tokens", q.DailyTokens), ResetAt: startOfDay(now).AddDate(0, 0, 1)}
+		}
+	}
+
+	if q.MonthlyTokens > 0 || q.MonthlyBudget > 0 {
+		month, err := u.store.Totals(ctx, userID, startOfMonth(now))
+		if err != nil {
+			return fmt.Errorf("failed to check AI quota: %w", err)
+		}
+		resetAt := startOfMonth(now).AddDate(0, 1, 0)
+		if q.MonthlyTokens > 0 && month.Tokens() >= q.MonthlyTokens {
+			return &QuotaError{Period: "monthly", Limit: fmt.Sprintf("%d tokens", q.MonthlyTokens), ResetAt: resetAt}
+		}
+		if q.MonthlyBudget > 0 && month.CostUSD >= q.MonthlyBudget {
+			return &QuotaError{Period: "monthly", Limit: fmt.Sprintf("$%.2f", q.MonthlyBudget), ResetAt: resetAt}
+		}
+	}
+	return nil
+}
+
+// record stores what the meter collected
+func (u *usageTracker) record(ctx context.Context, userID int, task Task, meter *usageMeter) error {
+	meter.mu.Lock()
+	entries := meter.entries
+	meter.mu.Unlock()
+	if len(entries) == 0 {
+		return nil
+	}
+
+	records := make([]UsageRecord, 0, len(entries))
+	for _, e := range entries {
+		records = append(records, UsageRecord{
+			UserID:       userID,
+			Task:         task,
+			Provider:     e.Provider,
+			Model:        e.Model,
+			InputTokens:  e.InputTokens,
+			OutputTokens: e.OutputTokens,
+			CostUSD:      EstimateCost(e.Model, e.InputTokens, e.OutputTokens),
+		})
+	}
+	// The request may already be finished; usage must still be written
+	return u.store.Record(context.WithoutCancel(ctx), records)
+}
+
+// UsageSummary returns a user's consumption for today and this month
+func (s *Service) UsageSummary(ctx context.Context, userID int) (*UsageSummary, error) {
+	if s.usage == nil {
+		return nil, fmt.Errorf("AI usage tracking is not enabled")
+	}
+
+	now := s.usage.now()
+	today, err := s.usage.store.Totals(ctx, userID, startOfDay(now))
+	if err != nil {
+		return nil, err
+	}
+	month, err := s.usage.store.Totals(ctx, userID, startOfMonth(now))
+	if err != nil {
+		return nil, err
+	}
+	byTask, err := s.usage.store.ByTask(ctx, userID, startOfMonth(now))
+	if err != nil {
+		return nil, err
+	}
+
+	return &UsageSummary{
+		Today:          today,
+		ThisMonth:      month,
+		ByTask:         byTask,
+		Quotas:         s.usage.quotas,
+		DailyResetAt:   startOfDay(now).AddDate(0, 0, 1),
+		MonthlyResetAt: startOfMonth(now).AddDate(0, 1, 0),
+	}, nil
+}
+
+// PostgresUsageStore keeps metered calls in the ai_usage table
+type PostgresUsageStore struct {
+	db *sql.DB
+}
+
+// NewPostgresUsageStore creates a usage store backed by the ai_usage table
+func NewPostgresUsageStore(db *sql.DB) *PostgresUsageStore {
+	return &PostgresUsageStore{db: db}
+}
+
+func (s *PostgresUsageStore) Record(ctx context.Context, records []UsageRecord) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, r := range records {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO ai_usage (user_id, task, provider, model, input_tokens, output_tokens, cost_usd)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+		`, r.UserID, string(r.Task), r.Provider, r.Model, r.InputTokens, r.OutputTokens, r.CostUSD)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (s *PostgresUsageStore) Totals(ctx context.Context, userID int, since time.Time) (UsageTotals, error) {
+	var totals UsageTotals
+	err := s.db.QueryRowContext(ctx, `
+		SELECT COUNT(*), COALESCE(SUM(input_tokens), 0), COALESCE(SUM(output_tokens), 0), COALESCE(SUM(cost_usd), 0)
+		FROM ai_usage
+		WHERE user_id = $1 AND created_at >= $2
+	`, userID, since).Scan(&totals.Requests, &totals.InputTokens, &totals.OutputTokens, &totals.CostUSD)
+	return totals, err
+}
+
+func (s *PostgresUsageStore) ByTask(ctx context.Context, userID int, since time.Time) ([]TaskUsage, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT task, COUNT(*), SUM(input_tokens), SUM(output_tokens), SUM(cost_usd)
+		FROM ai_usage
+		WHERE user_id = $1 AND created_at >= $2
+		GROUP BY task
+		ORDER BY task
+	`, userID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var usage []TaskUsage
+	for rows.Next() {
+		var tu TaskUsage
+		var task string
+		if err := rows.Scan(&task, &tu.Requests, &tu.InputTokens, &tu.OutputTokens, &tu.CostUSD); err != nil {
+			return nil, err
+		}
+		tu.Task = Task(task)
+		usage = append(usage, tu)
+	}
+	return usage, rows.Err()
+}
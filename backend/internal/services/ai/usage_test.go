@@ -0,0 +1,140 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// memoryUsageStore is an in-process UsageStore for tests
+type memoryUsageStore struct {
+	records []UsageRecord
+}
+
+func (s *memoryUsageStore) Record(ctx context.Context, records []UsageRecord) error {
+	s.records = append(s.records, records...)
+	return nil
+}
+
+func (s *memoryUsageStore) Totals(ctx context.Context, userID int, since time.Time) (UsageTotals, error) {
+	var totals UsageTotals
+	for _, r := range s.records {
+		if r.UserID != userID {
+			continue
+		}
+		totals.Requests++
+		totals.InputTokens += int64(r.InputTokens)
+		totals.OutputTokens += int64(r.OutputTokens)
+		totals.CostUSD += r.CostUSD
+	}
+	return totals, nil
+}
+
+func (s *memoryUsageStore) ByTask(ctx context.Context, userID int, since time.Time) ([]TaskUsage, error) {
+	return nil, nil
+}
+
+// meteredProvider reports a fixed token count for every translation
+type meteredProvider struct {
+	namedProvider
+	calls int
+}
+
+func (p *meteredProvider) Translate(ctx context.Context, text string, fromLang string, toLang string) (string, error) {
+	p.calls++
+	reportUsage(ctx, Usage{Provider: p.name, Model: "gpt-4o-mini", InputTokens: 600, OutputTokens: 400})
+	return "house", nil
+}
+
+func TestServiceEnforcesDailyQuota(t *testing.T) {
+	provider := &meteredProvider{namedProvider: namedProvider{name: "metered"}}
+	registry := NewRegistry()
+	if err := registry.Register("metered", provider); err != nil {
+		t.Fatal(err)
+	}
+	service, err := NewServiceWithRegistry(registry, nil, "metered")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := &memoryUsageStore{}
+	service.SetUsageTracking(store, Quotas{DailyTokens: 1500})
+
+	ctx := WithUserID(context.Background(), 7)
+	for i := 0; i < 2; i++ {
+		if _, err := service.Translate(ctx, "talo", "finnish", "english"); err != nil {
+			t.Fatalf("call %d: %v", i+1, err)
+		}
+	}
+
+	_, err = service.Translate(ctx, "talo", "finnish", "english")
+	var quotaErr *QuotaError
+	if !errors.Is(err, ErrQuotaExceeded) || !errors.As(err, &quotaErr) {
+		t.Fatalf("third call error = %v, want quota exceeded", err)
+	}
+	if quotaErr.Period != "daily" || !quotaErr.ResetAt.After(time.Now()) {
+		t.Errorf("quota error = %+v, want daily limit resetting in the future", quotaErr)
+	}
+	if provider.calls != 2 {
+		t.Errorf("provider called %d times, want 2", provider.calls)
+	}
+
+	// Other users and anonymous calls are unaffected
+	if _, err := service.Translate(WithUserID(context.Background(), 8), "talo", "finnish", "english"); err != nil {
+		t.Errorf("other user: %v", err)
+	}
+	if _, err := service.Translate(context.Background(), "talo", "finnish", "english"); err != nil {
+		t.Errorf("anonymous call: %v", err)
+	}
+
+	summary, err := service.UsageSummary(context.Background(), 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Today.Requests != 2 || summary.Today.Tokens() != 2000 {
+		t.Errorf("summary today = %+v, want 2 requests and 2000 tokens", summary.Today)
+	}
+}
+
+func TestServiceEnforcesMonthlyBudget(t *testing.T) {
+	provider := &meteredProvider{namedProvider: namedProvider{name: "metered"}}
+	registry := NewRegistry()
+	if err := registry.Register("metered", provider); err != nil {
+		t.Fatal(err)
+	}
+	service, err := NewServiceWithRegistry(registry, nil, "metered")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := &memoryUsageStore{records: []UsageRecord{{UserID: 7, CostUSD: 5.01}}}
+	service.SetUsageTracking(store, Quotas{MonthlyBudget: 5})
+
+	_, err = service.Translate(WithUserID(context.Background(), 7), "talo", "finnish", "english")
+	var quotaErr *QuotaError
+	if !errors.As(err, &quotaErr) || quotaErr.Period != "monthly" {
+		t.Fatalf("error = %v, want monthly quota exceeded", err)
+	}
+	if provider.calls != 0 {
+		t.Errorf("provider called %d times, want 0", provider.calls)
+	}
+}
+
+func TestEstimateCost(t *testing.T) {
+	tests := []struct {
+		model string
+		want  float64
+	}{
+		{"gpt-4o-mini", 0.15 + 0.60},
+		{"gpt-4o-2024-08-06", 2.50 + 10.00},
+		{"claude-3-5-sonnet-20241022", 3.00 + 15.00},
+		{"llama3.1:8b", 0},
+	}
+
+	for _, tt := range tests {
+		got := EstimateCost(tt.model, 1_000_000, 1_000_000)
+		if math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("EstimateCost(%q) = %v, want %v", tt.model, got, tt.want)
+		}
+	}
+}
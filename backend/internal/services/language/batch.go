@@ -0,0 +1,109 @@
+package language
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/BachirKhiati/lexia/internal/models"
+	"github.com/BachirKhiati/lexia/internal/services/ai"
+)
+
+const (
+	// MaxBatchTokens is the most distinct words one batch may contain
+	MaxBatchTokens = 200
+	// batchWorkers bounds how many words are analyzed at the same time
+	batchWorkers = 4
+	// batchTimeout is the deadline shared by all words of a batch
+	batchTimeout = 45 * time.Second
+)
+
+// ErrTooManyTokens is returned when a text has more than MaxBatchTokens distinct words
+var ErrTooManyTokens = errors.New("text has too many distinct words")
+
+// Token is a distinct word of a text
+type Token struct {
+	Word        string // Lower-cased form that is analyzed
+	Sentence    string // Sentence of the first occurrence
+	Occurrences int
+}
+
+// AnalyzeBatch analyzes every distinct word of a text. Words are analyzed
+// concurrently by a bounded pool of workers under one shared deadline; a
+// word that fails, or is not reached before the deadline, gets an error
+// entry instead of failing the batch. The results have no audio: synthesis
+// would use up the shared deadline, so clips are only made when a word is
+// analyzed on its own. An unsupported language is an
+// ErrUnsupportedLanguage. When no word could be analyzed because the user's
+// AI quota is spent, the quota error is returned instead of the entries.
+func (s *Service) AnalyzeBatch(ctx context.Context, text string, language string) ([]models.BatchTokenResult, error) {
+	lang, err := s.languages.Resolve(language)
+	if err != nil {
+		return nil, err
+	}
+	language = lang.Name()
+
+	tokens := lang.Tokenize(text)
+	if len(tokens) > MaxBatchTokens {
+		return nil, fmt.Errorf("%w: %d, the limit is %d", ErrTooManyTokens, len(tokens), MaxBatchTokens)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, batchTimeout)
+	defer cancel()
+
+	results := make([]models.BatchTokenResult, len(tokens))
+	errs := make([]error, len(tokens))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(batchWorkers, len(tokens)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i], errs[i] = s.analyzeToken(ctx, tokens[i], language)
+			}
+		}()
+	}
+	for i := range tokens {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var quotaErr error
+	for _, err := range errs {
+		if err == nil {
+			return results, nil
+		}
+		if quotaErr == nil && errors.Is(err, ai.ErrQuotaExceeded) {
+			quotaErr = err
+		}
+	}
+	if quotaErr != nil {
+		return nil, quotaErr
+	}
+	return results, nil
+}
+
+// analyzeToken returns the entry of one word and, if it failed, why
+func (s *Service) analyzeToken(ctx context.Context, token Token, language string) (models.BatchTokenResult, error) {
+	result := models.BatchTokenResult{Token: token.Word, Occurrences: token.Occurrences}
+	if err := ctx.Err(); err != nil {
+		result.Error = "not analyzed before the deadline"
+		return result, err
+	}
+
+	analysis, err := s.analyzeWord(ctx, token.Word, language, token.Sentence, false)
+	if err != nil {
+		if ctx.Err() != nil {
+			result.Error = "not analyzed before the deadline"
+		} else {
+			result.Error = err.Error()
+		}
+		return result, err
+	}
+	result.Analysis = analysis
+	return result, nil
+}
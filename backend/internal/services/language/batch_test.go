@@ -0,0 +1,185 @@
+package language
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/BachirKhiati/lexia/internal/services/wiktionary"
+)
+
+func TestTokenize(t *testing.T) {
+	tokens := Tokenize("Kissa istuu. Kissa nukkuu linja-autossa, EU:n 3 päivää! Se on 'kiva'.")
+
+	want := []struct {
+		word        string
+		sentence    string
+		occurrences int
+	}{
+		{"kissa", "Kissa istuu.", 2},
+		{"istuu", "Kissa istuu.", 1},
+		{"nukkuu", "Kissa nukkuu linja-autossa, EU:n 3 päivää!", 1},
+		{"linja-autossa", "Kissa nukkuu linja-autossa, EU:n 3 päivää!", 1},
+		{"eu:n", "Kissa nukkuu linja-autossa, EU:n 3 päivää!", 1},
+		{"päivää", "Kissa nukkuu linja-autossa, EU:n 3 päivää!", 1},
+		{"se", "Se on 'kiva'.", 1},
+		{"on", "Se on 'kiva'.", 1},
+		{"kiva", "Se on 'kiva'.", 1},
+	}
+	if len(tokens) != len(want) {
+		t.Fatalf("Tokenize returned %d tokens, want %d: %+v", len(tokens), len(want), tokens)
+	}
+	for i, w := range want {
+		got := tokens[i]
+		if got.Word != w.word || got.Sentence != w.sentence || got.Occurrences != w.occurrences {
+			t.Errorf("token %d = %+v, want %+v", i, got, w)
+		}
+	}
+}
+
+// newWiktionaryServer answers every word with one noun sense, except the
+// words in missing, which are not found
+func newWiktionaryServer(t *testing.T, handle func(word string), missing ...string) *wiktionary.Service {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		word := path.Base(r.URL.Path)
+		if handle != nil {
+			handle(word)
+		}
+		for _, m := range missing {
+			if word == m {
+				http.NotFound(w, r)
+				return
+			}
+		}
+		w.Write([]byte(`{"fi": [{"partOfSpeech": "Noun", "definitions": [{"definition": "meaning of ` + word + `"}]}]}`))
+	}))
+	t.Cleanup(server.Close)
+	return wiktionary.NewServiceWithBaseURL(server.URL)
+}
+
+func TestAnalyzeBatchReportsFailuresPerToken(t *testing.T) {
+	service := NewService(newWiktionaryServer(t, nil, "xyzzy"), nil)
+
+	results, err := service.AnalyzeBatch(context.Background(), "Talo on xyzzy. Talo!", "finnish")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3: %+v", len(results), results)
+	}
+
+	if r := results[0]; r.Token != "talo" || r.Occurrences != 2 || r.Analysis == nil || r.Analysis.Definition != "meaning of talo" {
+		t.Errorf("talo = %+v", r)
+	}
+	if r := results[0]; r.Analysis != nil && r.Analysis.Context != "Talo on xyzzy." {
+		t.Errorf("talo context = %q", r.Analysis.Context)
+	}
+	if r := results[1]; r.Token != "on" || r.Analysis == nil {
+		t.Errorf("on = %+v", r)
+	}
+	if r := results[2]; r.Token != "xyzzy" || r.Analysis != nil || r.Error == "" {
+		t.Errorf("xyzzy = %+v, want an error entry", r)
+	}
+}
+
+// countingSpeaker counts the clips it is asked for
+type countingSpeaker struct {
+	clips atomic.Int32
+}
+
+func (s *countingSpeaker) AudioURL(ctx context.Context, text string, language string) (string, error) {
+	s.clips.Add(1)
+	return "/audio/" + text, nil
+}
+
+func TestAnalyzeBatchSkipsAudio(t *testing.T) {
+	service := NewService(newWiktionaryServer(t, nil), nil)
+	speaker := &countingSpeaker{}
+	service.SetSpeaker(speaker)
+
+	results, err := service.AnalyzeBatch(context.Background(), "Talo on iso.", "finnish")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results {
+		if r.Analysis == nil || r.Analysis.AudioURL != "" {
+			t.Errorf("%s = %+v, want an analysis without audio", r.Token, r.Analysis)
+		}
+	}
+	if n := speaker.clips.Load(); n != 0 {
+		t.Errorf("batch synthesized %d clips", n)
+	}
+}
+
+func TestAnalyzeBatchBoundsConcurrency(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	wikt := newWiktionaryServer(t, func(string) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	})
+	service := NewService(wikt, nil)
+
+	text := "yksi kaksi kolme neljä viisi kuusi seitsemän kahdeksan yhdeksän kymmenen"
+	results, err := service.AnalyzeBatch(context.Background(), text, "finnish")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results {
+		if r.Error != "" {
+			t.Errorf("%s failed: %s", r.Token, r.Error)
+		}
+	}
+	if p := peak.Load(); p > batchWorkers {
+		t.Errorf("%d lookups ran at once, want at most %d", p, batchWorkers)
+	}
+}
+
+func TestAnalyzeBatchSharedDeadline(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	service := NewService(newWiktionaryServer(t, func(string) { <-release }), nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	results, err := service.AnalyzeBatch(ctx, "yksi kaksi kolme neljä viisi kuusi", "finnish")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("batch took %v, the deadline was not shared", elapsed)
+	}
+	for _, r := range results {
+		if r.Analysis != nil || !strings.Contains(r.Error, "deadline") {
+			t.Errorf("%s = %+v, want a deadline error", r.Token, r)
+		}
+	}
+}
+
+func TestAnalyzeBatchRejectsTooManyTokens(t *testing.T) {
+	service := NewService(nil, nil)
+
+	words := make([]string, MaxBatchTokens+1)
+	for i := range words {
+		words[i] = "sana" + strings.Repeat("a", i)
+	}
+	_, err := service.AnalyzeBatch(context.Background(), strings.Join(words, " "), "finnish")
+	if !errors.Is(err, ErrTooManyTokens) {
+		t.Errorf("err = %v, want ErrTooManyTokens", err)
+	}
+}
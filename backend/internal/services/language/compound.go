@@ -0,0 +1,225 @@
+package language
+
+import (
+	"strings"
+)
+
+// CompoundPart is one of the words a compound is made of: the form it has
+// in the compound and its lemma. Työpaikkahaastattelussa is työ, paikka
+// and haastattelussa, whose lemma is haastattelu.
+type CompoundPart struct {
+	Form  string `json:"form"`
+	Lemma string `json:"lemma"`
+}
+
+// minCompoundPart is the fewest letters a part of a compound may have, so
+// that endings and short function words are not taken for parts
+const minCompoundPart = 3
+
+// splitCompound splits a compound into its parts. Every part but the last
+// is a modifier, in the form words take at the start of a compound, and
+// the last is the head, which carries the inflection of the whole word;
+// the functions return the lemma of a part, or "" if it cannot be one.
+// Hyphens always divide parts (linja-autossa), and a piece that is not
+// known keeps its own form as its lemma. The split with the fewest parts
+// wins, then the one with the longest head. A word that is not a compound
+// returns nil.
+func splitCompound(word string, modifier, head func(part string) string) []CompoundPart {
+	word = strings.ToLower(strings.TrimSpace(word))
+	pieces := strings.Split(word, "-")
+
+	var parts []CompoundPart
+	for i, piece := range pieces {
+		if piece == "" {
+			continue
+		}
+		last := modifier
+		if i == len(pieces)-1 {
+			last = head
+		}
+		if split := splitPiece(piece, modifier, last); split != nil {
+			parts = append(parts, split...)
+			continue
+		}
+		lemma := last(piece)
+		if lemma == "" {
+			lemma = piece
+		}
+		parts = append(parts, CompoundPart{Form: piece, Lemma: lemma})
+	}
+	if len(parts) < 2 {
+		return nil
+	}
+	return parts
+}
+
+// splitPiece splits a word without hyphens into at least two parts, or
+// returns nil
+func splitPiece(word string, modifier, last func(part string) string) []CompoundPart {
+	runes := []rune(word)
+	n := len(runes)
+	if n < 2*minCompoundPart {
+		return nil
+	}
+
+	// fewest[i] is the fewest modifiers runes[:i] splits into, or -1, and
+	// from[i] where the last of them starts. Trying the starts in order
+	// keeps the longest last modifier among equal splits.
+	fewest := make([]int, n+1)
+	from := make([]int, n+1)
+	for i := 1; i <= n; i++ {
+		fewest[i] = -1
+	}
+	for end := minCompoundPart; end <= n-minCompoundPart; end++ {
+		for start := 0; start <= end-minCompoundPart; start++ {
+			if fewest[start] < 0 || (fewest[end] >= 0 && fewest[start]+1 >= fewest[end]) {
+				continue
+			}
+			if modifier(string(runes[start:end])) != "" {
+				fewest[end] = fewest[start] + 1
+				from[end] = start
+			}
+		}
+	}
+
+	headStart, headLemma := -1, ""
+	for start := minCompoundPart; start <= n-minCompoundPart; start++ {
+		if fewest[start] <= 0 || (headStart >= 0 && fewest[start] >= fewest[headStart]) {
+			continue
+		}
+		if lemma := last(string(runes[start:])); lemma != "" {
+			headStart, headLemma = start, lemma
+		}
+	}
+	if headStart < 0 {
+		return nil
+	}
+
+	parts := []CompoundPart{{Form: string(runes[headStart:]), Lemma: headLemma}}
+	for end := headStart; end > 0; end = from[end] {
+		form := string(runes[from[end]:end])
+		parts = append([]CompoundPart{{Form: form, Lemma: modifier(form)}}, parts...)
+	}
+	return parts
+}
+
+// compoundAnalyses returns the readings of a compound split into parts:
+// those its head has in readings, with the whole compound for lemma.
+// Työpaikassa is työpaikka in the inessive, as paikassa is paikka.
+func compoundAnalyses(word string, parts []CompoundPart, readings func(part string) []Analysis) []Analysis {
+	if len(parts) == 0 {
+		return nil
+	}
+	head := parts[len(parts)-1]
+	prefix := strings.TrimSuffix(word, head.Form)
+
+	var analyses []Analysis
+	for _, a := range readings(head.Form) {
+		if a.Lemma == head.Lemma {
+			a.Lemma = prefix + a.Lemma
+			analyses = append(analyses, a)
+		}
+	}
+	return analyses
+}
+
+// SplitCompound returns the parts of a Finnish compound with their lemmas.
+// Words of the lexicon are not split, nor are words whose parts are not
+// all in it.
+func (l *Lemmatizer) SplitCompound(word string) []CompoundPart {
+	word = strings.ToLower(strings.TrimSpace(word))
+	if len(l.known(word)) > 0 {
+		return nil
+	}
+	return splitCompound(word, l.compoundModifier, l.compoundHead)
+}
+
+// compoundModifier returns the lemma of the first part of a Finnish
+// compound: a noun, adjective or numeral in the nominative singular
+// (työpaikka) or the genitive (kaupungintalo), the old genitive plural in
+// -in (kansainvälinen), or the stem of a word in -nen (ihmisoikeus).
+func (l *Lemmatizer) compoundModifier(part string) string {
+	for _, a := range l.forms[part] {
+		if isFinnishNominal(a.PartOfSpeech) && (a.Case == CaseGenitive || a.Case == CaseNominative && a.Number == NumberSingular) {
+			return a.Lemma
+		}
+	}
+	if stem, ok := strings.CutSuffix(part, "in"); ok {
+		for _, a := range l.forms[stem] {
+			if isFinnishNominal(a.PartOfSpeech) && a.Case == CaseNominative && a.Number == NumberSingular {
+				return a.Lemma
+			}
+		}
+	}
+	if stem, ok := strings.CutSuffix(part, "s"); ok {
+		lemma := stem + "nen"
+		for _, a := range l.forms[lemma] {
+			if a.Lemma == lemma && isFinnishNominal(a.PartOfSpeech) {
+				return lemma
+			}
+		}
+	}
+	return ""
+}
+
+// compoundHead returns the lemma of the last part of a Finnish compound,
+// a noun or adjective of the lexicon in any form
+func (l *Lemmatizer) compoundHead(part string) string {
+	for _, a := range l.known(part) {
+		if isFinnishNominal(a.PartOfSpeech) {
+			return a.Lemma
+		}
+	}
+	return ""
+}
+
+func isFinnishNominal(partOfSpeech string) bool {
+	return partOfSpeech == "noun" || partOfSpeech == "adjective" || partOfSpeech == "numeral"
+}
+
+// SplitCompound returns the parts of a Swedish compound with their lemmas,
+// like the Finnish one
+func (l *SwedishLemmatizer) SplitCompound(word string) []CompoundPart {
+	word = strings.ToLower(strings.TrimSpace(word))
+	if len(l.forms[word]) > 0 {
+		return nil
+	}
+	return splitCompound(word, l.compoundModifier, l.compoundHead)
+}
+
+// compoundModifier returns the lemma of the first part of a Swedish
+// compound: a noun or adjective as it is (bilnyckel), with the linking -s
+// (köksbord, arbetsdag), without its final vowel (skolbok, pojkrum), or
+// with -o or -u for -a (kvinnodag, gatukök)
+func (l *SwedishLemmatizer) compoundModifier(part string) string {
+	candidates := []string{part}
+	if stem, ok := strings.CutSuffix(part, "s"); ok {
+		candidates = append(candidates, stem, stem+"e")
+	}
+	candidates = append(candidates, part+"a", part+"e")
+	for _, vowel := range []string{"o", "u"} {
+		if stem, ok := strings.CutSuffix(part, vowel); ok {
+			candidates = append(candidates, stem+"a")
+		}
+	}
+
+	for _, lemma := range candidates {
+		for _, a := range l.forms[lemma] {
+			if a.Lemma == lemma && (a.PartOfSpeech == "noun" || a.PartOfSpeech == "adjective") {
+				return lemma
+			}
+		}
+	}
+	return ""
+}
+
+// compoundHead returns the lemma of the last part of a Swedish compound, a
+// noun or adjective of the lexicon in any form
+func (l *SwedishLemmatizer) compoundHead(part string) string {
+	for _, a := range l.forms[part] {
+		if a.PartOfSpeech == "noun" || a.PartOfSpeech == "adjective" {
+			return a.Lemma
+		}
+	}
+	return ""
+}
@@ -0,0 +1,101 @@
+package language
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitFinnishCompounds(t *testing.T) {
+	lemmatizer := NewLemmatizer()
+
+	tests := []struct {
+		word string
+		want string
+	}{
+		{"kansainvälinen", "kansain:kansa välinen:välinen"},
+		{"työpaikkahaastattelu", "työ:työ paikka:paikka haastattelu:haastattelu"},
+		{"työpaikassa", "työ:työ paikassa:paikka"},
+		{"Kaupungintalo", "kaupungin:kaupunki talo:talo"},
+		{"linja-autossa", "linja:linja autossa:auto"},
+		{"ihmiskieli", "ihmis:ihminen kieli:kieli"},
+		{"rautatieasema", "rauta:rauta tie:tie asema:asema"},
+		{"tietokone", "tieto:tieto kone:kone"},
+		{"matkalippu", "matka:matka lippu:lippu"},
+		{"lentokoneessa", "lento:lento koneessa:kone"},
+		// Unknown pieces of a hyphenated word keep their form
+		{"EU-maissa", "eu:eu maissa:maa"},
+	}
+
+	for _, tt := range tests {
+		if got := formatParts(lemmatizer.SplitCompound(tt.word)); got != tt.want {
+			t.Errorf("SplitCompound(%q) = %q, want %q", tt.word, got, tt.want)
+		}
+	}
+
+	for _, word := range []string{"talo", "talossa", "kirjoittaa", "asemalla", "xyzzy"} {
+		if parts := lemmatizer.SplitCompound(word); parts != nil {
+			t.Errorf("SplitCompound(%q) = %+v, want nil", word, parts)
+		}
+	}
+}
+
+func TestAnalyzeCompounds(t *testing.T) {
+	tests := []struct {
+		lemmatizer interface{ Analyze(string) []Analysis }
+		word       string
+		want       Analysis
+	}{
+		{NewLemmatizer(), "työpaikassa", Analysis{Lemma: "työpaikka", PartOfSpeech: "noun", Case: CaseInessive, Number: NumberSingular}},
+		{NewLemmatizer(), "rautatieasemalla", Analysis{Lemma: "rautatieasema", PartOfSpeech: "noun", Case: CaseAdessive, Number: NumberSingular}},
+		{NewLemmatizer(), "linja-autossakin", Analysis{Lemma: "linja-auto", PartOfSpeech: "noun", Case: CaseInessive, Number: NumberSingular, Clitics: []string{"kin"}}},
+		{NewLemmatizer(), "Kaupungintalolla", Analysis{Lemma: "kaupungintalo", PartOfSpeech: "noun", Case: CaseAdessive, Number: NumberSingular}},
+		{NewSwedishLemmatizer(), "tågstationen", Analysis{Lemma: "tågstation", PartOfSpeech: "noun", Case: CaseNominative, Number: NumberSingular, Definiteness: Definite}},
+	}
+
+	for _, tt := range tests {
+		analyses := tt.lemmatizer.Analyze(tt.word)
+		if len(analyses) == 0 {
+			t.Errorf("Analyze(%q) found nothing", tt.word)
+			continue
+		}
+		if got := analyses[0]; !sameAnalysis(got, tt.want) {
+			t.Errorf("Analyze(%q)[0] = %+v, want %+v", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestSplitSwedishCompounds(t *testing.T) {
+	lemmatizer := NewSwedishLemmatizer()
+
+	tests := []struct {
+		word string
+		want string
+	}{
+		{"bilnyckel", "bil:bil nyckel:nyckel"},
+		{"skolbok", "skol:skola bok:bok"},
+		{"köksbordet", "köks:kök bordet:bord"},
+		{"kvinnorummen", "kvinno:kvinna rummen:rum"},
+		{"gatukök", "gatu:gata kök:kök"},
+		{"tågstationen", "tåg:tåg stationen:station"},
+	}
+
+	for _, tt := range tests {
+		if got := formatParts(lemmatizer.SplitCompound(tt.word)); got != tt.want {
+			t.Errorf("SplitCompound(%q) = %q, want %q", tt.word, got, tt.want)
+		}
+	}
+
+	for _, word := range []string{"bil", "huset", "station", "tvättade"} {
+		if parts := lemmatizer.SplitCompound(word); parts != nil {
+			t.Errorf("SplitCompound(%q) = %+v, want nil", word, parts)
+		}
+	}
+}
+
+func formatParts(parts []CompoundPart) string {
+	formatted := make([]string, len(parts))
+	for i, p := range parts {
+		formatted[i] = p.Form + ":" + p.Lemma
+	}
+	return strings.Join(formatted, " ")
+}
@@ -0,0 +1,40 @@
+package language
+
+import (
+	"github.com/BachirKhiati/lexia/internal/models"
+	"github.com/BachirKhiati/lexia/internal/services/wiktionary"
+)
+
+// English looks words up as they are written: it has no lemmatizer,
+// inflection tables or compound splitting
+type English struct {
+	segmenter *Segmenter
+}
+
+func NewEnglish() *English {
+	return &English{segmenter: NewSegmenter(englishAbbreviations...)}
+}
+
+func (e *English) Code() string { return "en" }
+
+func (e *English) Name() string { return "english" }
+
+func (e *English) Tokenize(text string) []Token {
+	return e.segmenter.Tokenize(text)
+}
+
+func (e *English) Sentences(text string) []string {
+	return e.segmenter.Sentences(text)
+}
+
+func (e *English) Analyze(word string) []Analysis { return nil }
+
+func (e *English) GuessPartOfSpeech(lemma string) string { return "" }
+
+func (e *English) SplitCompound(word string) []CompoundPart { return nil }
+
+func (e *English) Inflect(response *models.AnalyzerResponse) {}
+
+func (e *English) Wiktionary() wiktionary.Edition {
+	return wiktionary.Edition{Code: "en", Sections: []string{"en", "English"}}
+}
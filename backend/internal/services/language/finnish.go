@@ -1,122 +1,86 @@
 package language
 
 import (
-	"context"
-	"fmt"
-	"log"
+	"strings"
 
 	"github.com/BachirKhiati/lexia/internal/models"
 	"github.com/BachirKhiati/lexia/internal/services/wiktionary"
 )
 
-// AIService is the interface for AI-based word definitions
-type AIService interface {
-	GetWordDefinition(ctx context.Context, word string, language string) (definition string, partOfSpeech string, examples []string, err error)
+// Finnish analyzes Finnish words with the lexicon-backed lemmatizer, and
+// declines and conjugates their lemmas
+type Finnish struct {
+	conjugator *VerbConjugator
+	decliner   *NounDecliner
+	lemmatizer *Lemmatizer
+	segmenter  *Segmenter
 }
 
-// Service handles language-specific operations
-type Service struct {
-	conjugator        *VerbConjugator
-	wiktionaryService *wiktionary.Service
-	aiService         AIService
+func NewFinnish() *Finnish {
+	return &Finnish{
+		conjugator: NewVerbConjugator(),
+		decliner:   NewNounDecliner(),
+		lemmatizer: NewLemmatizer(),
+		segmenter:  NewSegmenter(finnishAbbreviations...),
+	}
 }
 
-func NewService(wiktionaryService *wiktionary.Service, aiService AIService) *Service {
-	return &Service{
-		conjugator:        NewVerbConjugator(),
-		wiktionaryService: wiktionaryService,
-		aiService:         aiService,
-	}
+func (f *Finnish) Code() string { return "fi" }
+
+func (f *Finnish) Name() string { return "finnish" }
+
+func (f *Finnish) Tokenize(text string) []Token {
+	return f.segmenter.Tokenize(text)
 }
 
-// AnalyzeWord performs deep analysis of a word
-func (s *Service) AnalyzeWord(ctx context.Context, word string, language string) (*models.AnalyzerResponse, error) {
-	// Initialize response
-	response := &models.AnalyzerResponse{
-		Word:      word,
-		Lemma:     word, // TODO: Proper lemmatization
-		AudioURL:  "",
-		InSynapse: false,
-	}
+func (f *Finnish) Sentences(text string) []string {
+	return f.segmenter.Sentences(text)
+}
 
-	// Track if we successfully got a definition
-	gotDefinition := false
-
-	// Try to fetch real definition from Wiktionary first
-	if s.wiktionaryService != nil {
-		definition, partOfSpeech, examples, err := s.wiktionaryService.ExtractBestDefinition(word, language)
-		if err == nil && definition != "" {
-			// Successfully got definition from Wiktionary
-			response.Definition = definition
-			response.PartOfSpeech = partOfSpeech
-			if len(examples) > 0 {
-				response.Examples = examples
-			}
-			gotDefinition = true
-			log.Printf("✅ Fetched definition from Wiktionary for '%s': %s", word, definition)
-		} else {
-			// Wiktionary failed - log but continue to AI fallback
-			log.Printf("⚠️  Wiktionary lookup failed for '%s': %v (trying AI fallback)", word, err)
-		}
-	}
+func (f *Finnish) Analyze(word string) []Analysis {
+	return f.lemmatizer.Analyze(word)
+}
 
-	// If Wiktionary failed, try AI as fallback
-	if !gotDefinition && s.aiService != nil {
-		definition, partOfSpeech, examples, err := s.aiService.GetWordDefinition(ctx, word, language)
-		if err == nil && definition != "" {
-			response.Definition = definition
-			response.PartOfSpeech = partOfSpeech
-			if len(examples) > 0 {
-				response.Examples = examples
-			}
-			gotDefinition = true
-			log.Printf("✅ Fetched definition from AI for '%s': %s", word, definition)
-		} else {
-			// AI also failed
-			log.Printf("⚠️  AI lookup also failed for '%s': %v", word, err)
-		}
+// GuessPartOfSpeech recognizes verbs by their infinitive ending
+func (f *Finnish) GuessPartOfSpeech(lemma string) string {
+	if isLikelyFinnishVerb(lemma) {
+		return "verb"
 	}
+	return ""
+}
 
-	// If both Wiktionary and AI failed, return error instead of placeholder data
-	if !gotDefinition {
-		return nil, fmt.Errorf("unable to find definition for '%s' - both Wiktionary and AI sources failed", word)
-	}
+func (f *Finnish) SplitCompound(word string) []CompoundPart {
+	return f.lemmatizer.SplitCompound(word)
+}
 
-	// If it looks like a Finnish verb, conjugate it
-	if language == "finnish" && s.isLikelyFinnishVerb(word) {
-		response.PartOfSpeech = "verb" // Override if detected as verb
-		conjugations := s.conjugator.ConjugateVerb(word)
-
-		// Convert to models.WordConjugation
-		var modelConjugations []models.WordConjugation
-		for i, conj := range conjugations {
-			modelConjugations = append(modelConjugations, models.WordConjugation{
-				ID:       i + 1,
-				WordID:   0, // Not saved yet
-				Tense:    conj.Tense,
-				Person:   conj.Person,
-				Form:     conj.Form,
-				Language: language,
-			})
-		}
-		response.Conjugations = modelConjugations
+// Inflect declines nouns and adjectives and conjugates verbs
+func (f *Finnish) Inflect(response *models.AnalyzerResponse) {
+	switch {
+	case isNominal(response.PartOfSpeech):
+		response.Declensions = f.Declensions(response.Lemma)
+		response.KotusType = f.decliner.KotusType(response.Lemma)
+	case strings.EqualFold(response.PartOfSpeech, "verb"):
+		response.Conjugations = f.Conjugations(response.Lemma)
 	}
+}
 
-	return response, nil
+// Wiktionary looks Finnish words up in the Finnish Wiktionary
+func (f *Finnish) Wiktionary() wiktionary.Edition {
+	return wiktionary.Edition{Code: "fi", Sections: []string{"fi", "Finnish"}}
 }
 
 // isLikelyFinnishVerb checks if a word looks like a Finnish verb infinitive
-func (s *Service) isLikelyFinnishVerb(word string) bool {
+func isLikelyFinnishVerb(word string) bool {
 	finnishVerbEndings := []string{
-		"a", "ä",           // Type 1
-		"da", "dä",         // Type 2
-		"lla", "llä",       // Type 3
-		"nna", "nnä",       // Type 3
-		"rra", "rrä",       // Type 3
-		"sta", "stä",       // Type 3
-		"ata", "ätä",       // Type 4
-		"ita", "itä",       // Type 5
-		"eta", "etä",       // Type 6
+		"a", "ä", // Type 1
+		"da", "dä", // Type 2
+		"lla", "llä", // Type 3
+		"nna", "nnä", // Type 3
+		"rra", "rrä", // Type 3
+		"sta", "stä", // Type 3
+		"ata", "ätä", // Type 4
+		"ita", "itä", // Type 5
+		"eta", "etä", // Type 6
 	}
 
 	for _, ending := range finnishVerbEndings {
@@ -127,16 +91,41 @@ func (s *Service) isLikelyFinnishVerb(word string) bool {
 	return false
 }
 
-// GetConjugations returns all conjugations for a Finnish verb
-func (s *Service) GetConjugations(word string) ([]models.WordConjugation, error) {
-	// Placeholder - implement proper Finnish verb conjugation
-	// Finnish has 6 tenses and complex conjugation rules
-	return []models.WordConjugation{
-		{Tense: "present", Person: "1sg", Form: word + "n"},
-		{Tense: "present", Person: "2sg", Form: word + "t"},
-		{Tense: "present", Person: "3sg", Form: word},
-		{Tense: "past", Person: "1sg", Form: word + "in"},
-		{Tense: "past", Person: "2sg", Form: word + "it"},
-		{Tense: "past", Person: "3sg", Form: word + "i"},
-	}, nil
+// Conjugations returns the full paradigm of a Finnish verb
+func (f *Finnish) Conjugations(word string) []models.WordConjugation {
+	conjugations := f.conjugator.ConjugateVerb(word)
+
+	// Convert to models.WordConjugation
+	var modelConjugations []models.WordConjugation
+	for i, conj := range conjugations {
+		modelConjugations = append(modelConjugations, models.WordConjugation{
+			ID:       i + 1,
+			WordID:   0, // Not saved yet
+			Mood:     string(conj.Mood),
+			Tense:    string(conj.Tense),
+			Voice:    string(conj.Voice),
+			Polarity: string(conj.Polarity),
+			Person:   conj.Person,
+			Variant:  conj.Variant,
+			Form:     conj.Form,
+			Language: f.Name(),
+		})
+	}
+	return modelConjugations
+}
+
+// Declensions returns the singular and plural case forms of a Finnish noun
+// or adjective
+func (f *Finnish) Declensions(word string) []models.WordDeclension {
+	declensions := f.decliner.DeclineNoun(word)
+
+	modelDeclensions := make([]models.WordDeclension, len(declensions))
+	for i, d := range declensions {
+		modelDeclensions[i] = models.WordDeclension{
+			Case:   string(d.Case),
+			Number: string(d.Number),
+			Form:   d.Form,
+		}
+	}
+	return modelDeclensions
 }
@@ -11,18 +11,61 @@ const (
 	Type1 VerbType = iota + 1 // -A/Ä verbs (sanoa, puhua)
 	Type2                     // -DA verbs (syödä, juoda)
 	Type3                     // -LA/-NA/-RA/-STA verbs (tulla, mennä)
-	Type4                     // -ATA/-ÄTA verbs (haluta, pelätä)
+	Type4                     // vowel + -TA/-TÄ verbs (haluta, pelätä)
 	Type5                     // -ITA/-ITÄ verbs (tarvita, häiritä)
 	Type6                     // -ETA/-ETÄ verbs (vanheta, paeta)
 )
 
-// Conjugation represents a verb conjugation
+// Conjugation is one form of a verb. Finite forms have a person; the
+// infinitives and participles have none and name the form in Variant
+// (third illative, agent...).
 type Conjugation struct {
-	Tense  string `json:"tense"`
-	Person string `json:"person"`
-	Form   string `json:"form"`
+	Mood     Mood     `json:"mood"`
+	Tense    Tense    `json:"tense"`
+	Voice    Voice    `json:"voice"`
+	Polarity Polarity `json:"polarity"`
+	Person   string   `json:"person"`
+	Variant  string   `json:"variant,omitempty"`
+	Form     string   `json:"form"`
 }
 
+// Mood of a form. Infinitives and participles are listed as moods of
+// their own so that every form can be filtered the same way.
+type Mood string
+
+const (
+	MoodIndicative  Mood = "indicative"
+	MoodConditional Mood = "conditional"
+	MoodImperative  Mood = "imperative"
+	MoodPotential   Mood = "potential"
+	MoodInfinitive  Mood = "infinitive"
+	MoodParticiple  Mood = "participle"
+)
+
+// Tense of a form. Perfect and pluperfect are built with olla.
+type Tense string
+
+const (
+	TensePresent    Tense = "present"
+	TensePast       Tense = "past"
+	TensePerfect    Tense = "perfect"
+	TensePluperfect Tense = "pluperfect"
+)
+
+type Voice string
+
+const (
+	VoiceActive  Voice = "active"
+	VoicePassive Voice = "passive"
+)
+
+type Polarity string
+
+const (
+	PolarityAffirmative Polarity = "affirmative"
+	PolarityNegative    Polarity = "negative"
+)
+
 // VerbConjugator handles Finnish verb conjugation
 type VerbConjugator struct{}
 
@@ -30,196 +73,294 @@ func NewVerbConjugator() *VerbConjugator {
 	return &VerbConjugator{}
 }
 
-// ConjugateVerb returns all conjugations for a Finnish verb
+// ConjugateVerb returns the full paradigm of a Finnish verb: the simple
+// tenses first (indicative present, past, conditional, imperative,
+// potential), then negative and compound forms, the passive, and the
+// infinitives and participles
 func (vc *VerbConjugator) ConjugateVerb(infinitive string) []Conjugation {
+	v := vc.analyze(infinitive)
+	simple := vc.simpleForms(v)
+
+	conjugations := append([]Conjugation{}, simple...)
+	conjugations = append(conjugations, vc.negativeForms(v)...)
+	conjugations = append(conjugations, vc.compoundForms(v)...)
+	conjugations = append(conjugations, vc.passiveForms(v)...)
+	conjugations = append(conjugations, vc.nonFiniteForms(v)...)
+
+	return conjugations
+}
+
+// analyze determines the type, stem and vowel harmony of infinitive
+func (vc *VerbConjugator) analyze(infinitive string) verb {
 	verbType := vc.determineVerbType(infinitive)
-	stem := vc.extractStem(infinitive, verbType)
+	return verb{
+		infinitive: infinitive,
+		verbType:   verbType,
+		stem:       vc.extractStem(infinitive, verbType),
+		back:       vc.usesBackVowels(infinitive),
+	}
+}
 
+// simpleForms returns the affirmative active forms that are not built from
+// other forms
+func (vc *VerbConjugator) simpleForms(v verb) []Conjugation {
 	var conjugations []Conjugation
 
 	// Present tense
-	conjugations = append(conjugations, vc.conjugatePresent(stem, verbType)...)
+	conjugations = append(conjugations, vc.conjugatePresent(v)...)
 
 	// Past tense
-	conjugations = append(conjugations, vc.conjugatePast(stem, verbType)...)
+	conjugations = append(conjugations, vc.conjugatePast(v)...)
 
 	// Conditional
-	conjugations = append(conjugations, vc.conjugateConditional(stem, verbType)...)
+	conjugations = append(conjugations, vc.conjugateConditional(v)...)
 
-	return conjugations
-}
+	conjugations = append(conjugations, vc.conjugateImperative(v)...)
+	conjugations = append(conjugations, vc.conjugatePotential(v)...)
 
-// determineVerbType identifies which of the 6 verb types
-func (vc *VerbConjugator) determineVerbType(infinitive string) VerbType {
-	if strings.HasSuffix(infinitive, "da") || strings.HasSuffix(infinitive, "dä") {
-		return Type2
+	for i, c := range conjugations {
+		key := string(c.Mood) + " " + string(c.Tense) + " " + c.Person
+		if form, ok := irregularForms[v.infinitive][key]; ok {
+			conjugations[i].Form = form
+		}
 	}
 
-	if strings.HasSuffix(infinitive, "lla") || strings.HasSuffix(infinitive, "llä") ||
-		strings.HasSuffix(infinitive, "nna") || strings.HasSuffix(infinitive, "nnä") ||
-		strings.HasSuffix(infinitive, "rra") || strings.HasSuffix(infinitive, "rrä") ||
-		strings.HasSuffix(infinitive, "sta") || strings.HasSuffix(infinitive, "stä") {
-		return Type3
-	}
+	return conjugations
+}
 
-	if strings.HasSuffix(infinitive, "ata") || strings.HasSuffix(infinitive, "ätä") {
-		return Type4
-	}
+// verb is an infinitive analyzed for conjugation
+type verb struct {
+	infinitive string
+	verbType   VerbType
+	stem       string // present stem in the strong grade: otta-, tule-, halua-
+	back       bool   // back-vowel endings: -vat rather than -vät
+}
+
+// conjugatesLikeType1 reports whether v takes the endings of type 1 and
+// consonant gradation between strong third persons and weak other persons
+func (v verb) conjugatesLikeType1() bool {
+	return v.verbType == Type1 || consonantStemVerbs[v.infinitive]
+}
 
-	if strings.HasSuffix(infinitive, "ita") || strings.HasSuffix(infinitive, "itä") {
-		return Type5
+// determineVerbType identifies which of the 6 verb types from the letters
+// before the final a/ä
+func (vc *VerbConjugator) determineVerbType(infinitive string) VerbType {
+	runes := []rune(infinitive)
+	n := len(runes)
+	if n < 3 {
+		return Type1
 	}
+	before, prev := runes[n-2], runes[n-3]
 
-	if strings.HasSuffix(infinitive, "eta") || strings.HasSuffix(infinitive, "etä") {
-		return Type6
+	switch {
+	case isFinnishVowel(before):
+		return Type1 // sanoa, lukea
+	case before == 'd':
+		return Type2 // syödä, tehdä
+	case before == 't' && prev == 's':
+		return Type3 // nousta, pestä
+	case (before == 'l' || before == 'n' || before == 'r') && prev == before:
+		return Type3 // tulla, mennä, purra
+	case before == 't' && prev == 'i':
+		return Type5 // tarvita
+	case before == 't' && prev == 'e':
+		return Type6 // vanheta
+	case before == 't' && isFinnishVowel(prev):
+		return Type4 // haluta, pelätä, pudota
 	}
 
 	// Default to Type 1
 	return Type1
 }
 
-// extractStem removes the infinitive ending
+// extractStem returns the present stem in the strong grade. Types 3, 4 and
+// 6 have the weak grade in the infinitive: ajatella → ajattele-,
+// pudota → putoa-.
 func (vc *VerbConjugator) extractStem(infinitive string, verbType VerbType) string {
+	if stem, ok := irregularStems[infinitive]; ok {
+		return stem
+	}
+	runes := []rune(infinitive)
+	if len(runes) < 3 {
+		return infinitive
+	}
+	a := string(runes[len(runes)-1])
+	// root drops the two-letter ending: -da, -la, -na, -ra, -ta
+	root := string(runes[:len(runes)-2])
+
 	switch verbType {
 	case Type1:
-		return strings.TrimSuffix(strings.TrimSuffix(infinitive, "a"), "ä")
+		return string(runes[:len(runes)-1]) // otta-
 	case Type2:
-		return strings.TrimSuffix(strings.TrimSuffix(infinitive, "da"), "dä")
+		return root // syö-
 	case Type3:
-		if strings.HasSuffix(infinitive, "lla") || strings.HasSuffix(infinitive, "llä") {
-			return strings.TrimSuffix(strings.TrimSuffix(infinitive, "lla"), "llä") + "le"
-		}
-		if strings.HasSuffix(infinitive, "nna") || strings.HasSuffix(infinitive, "nnä") {
-			return strings.TrimSuffix(strings.TrimSuffix(infinitive, "nna"), "nnä") + "ne"
-		}
-		if strings.HasSuffix(infinitive, "rra") || strings.HasSuffix(infinitive, "rrä") {
-			return strings.TrimSuffix(strings.TrimSuffix(infinitive, "rra"), "rrä") + "re"
-		}
-		if strings.HasSuffix(infinitive, "sta") || strings.HasSuffix(infinitive, "stä") {
-			return strings.TrimSuffix(strings.TrimSuffix(infinitive, "sta"), "stä") + "se"
-		}
+		return strongGrade(root) + "e" // tule-, nouse-, ajattele-
 	case Type4:
-		return strings.TrimSuffix(strings.TrimSuffix(infinitive, "ata"), "ätä") + "a"
+		return strongGrade(root) + a // halua-, putoa-
 	case Type5:
-		return strings.TrimSuffix(strings.TrimSuffix(infinitive, "ita"), "itä") + "itse"
+		return root + "tse" // tarvitse-
 	case Type6:
-		return strings.TrimSuffix(strings.TrimSuffix(infinitive, "eta"), "etä") + "ene"
+		return strongGrade(root) + "ne" // vanhene-
 	}
 	return infinitive
 }
 
+// active returns the metadata of an affirmative active tense
+func active(mood Mood, tense Tense) Conjugation {
+	return Conjugation{Mood: mood, Tense: tense, Voice: VoiceActive, Polarity: PolarityAffirmative}
+}
+
+// personForms builds the six persons of a tense. The first and second
+// persons add their endings to stem; the third persons are given whole.
+func (vc *VerbConjugator) personForms(tense Conjugation, stem, third, thirdPlural string) []Conjugation {
+	endings := []string{"n", "t", "", "mme", "tte", ""}
+	conjugations := make([]Conjugation, len(persons))
+	for i, person := range persons {
+		c := tense
+		c.Person = person
+		c.Form = stem + endings[i]
+		conjugations[i] = c
+	}
+	conjugations[2].Form = third
+	conjugations[5].Form = thirdPlural
+	return conjugations
+}
+
 // conjugatePresent conjugates present tense
-func (vc *VerbConjugator) conjugatePresent(stem string, verbType VerbType) []Conjugation {
-	var conjugations []Conjugation
-	backVowel := vc.usesBackVowels(stem)
+func (vc *VerbConjugator) conjugatePresent(v verb) []Conjugation {
+	vat := vc.vowel(v.back, "vat", "vät")
 
-	switch verbType {
-	case Type1:
-		// Type 1: sanoa (sano-) → sanon, sanot, sanoo...
-		conjugations = []Conjugation{
-			{Tense: "present", Person: "1sg", Form: stem + "n"},
-			{Tense: "present", Person: "2sg", Form: stem + "t"},
-			{Tense: "present", Person: "3sg", Form: stem + vc.vowel(backVowel, "o", "ö")},
-			{Tense: "present", Person: "1pl", Form: stem + "mme"},
-			{Tense: "present", Person: "2pl", Form: stem + "tte"},
-			{Tense: "present", Person: "3pl", Form: stem + "vat"},
-		}
-	case Type2:
+	switch {
+	case v.conjugatesLikeType1():
+		// Type 1: ottaa (otta-) → otan, otat, ottaa... The endings close
+		// the last syllable of the first and second persons, which take
+		// the weak grade; the third persons lengthen the stem vowel.
+		return vc.personForms(active(MoodIndicative, TensePresent), weakGrade(v.stem), v.stem+lastRune(v.stem), v.stem+vat)
+	case v.verbType == Type2:
 		// Type 2: syödä (syö-) → syön, syöt, syö...
-		conjugations = []Conjugation{
-			{Tense: "present", Person: "1sg", Form: stem + "n"},
-			{Tense: "present", Person: "2sg", Form: stem + "t"},
-			{Tense: "present", Person: "3sg", Form: stem},
-			{Tense: "present", Person: "1pl", Form: stem + "mme"},
-			{Tense: "present", Person: "2pl", Form: stem + "tte"},
-			{Tense: "present", Person: "3pl", Form: stem + "vät"},
-		}
-	case Type3:
-		// Type 3: tulla (tule-) → tulen, tulet, tulee...
-		conjugations = []Conjugation{
-			{Tense: "present", Person: "1sg", Form: stem + "n"},
-			{Tense: "present", Person: "2sg", Form: stem + "t"},
-			{Tense: "present", Person: "3sg", Form: stem + "e"},
-			{Tense: "present", Person: "1pl", Form: stem + "mme"},
-			{Tense: "present", Person: "2pl", Form: stem + "tte"},
-			{Tense: "present", Person: "3pl", Form: stem + "vat"},
-		}
-	case Type4:
-		// Type 4: haluta (halu-a-) → haluan, haluat, haluaa...
-		a := vc.vowel(backVowel, "a", "ä")
-		conjugations = []Conjugation{
-			{Tense: "present", Person: "1sg", Form: stem + "n"},
-			{Tense: "present", Person: "2sg", Form: stem + "t"},
-			{Tense: "present", Person: "3sg", Form: stem + a},
-			{Tense: "present", Person: "1pl", Form: stem + "mme"},
-			{Tense: "present", Person: "2pl", Form: stem + "tte"},
-			{Tense: "present", Person: "3pl", Form: stem + "vat"},
-		}
-	case Type5:
-		// Type 5: tarvita (tarvitse-) → tarvitsen, tarvitset...
-		conjugations = []Conjugation{
-			{Tense: "present", Person: "1sg", Form: stem + "n"},
-			{Tense: "present", Person: "2sg", Form: stem + "t"},
-			{Tense: "present", Person: "3sg", Form: stem + "e"},
-			{Tense: "present", Person: "1pl", Form: stem + "mme"},
-			{Tense: "present", Person: "2pl", Form: stem + "tte"},
-			{Tense: "present", Person: "3pl", Form: stem + "vat"},
-		}
-	case Type6:
-		// Type 6: vanheta (vanhene-) → vanhenen, vanhenet...
-		conjugations = []Conjugation{
-			{Tense: "present", Person: "1sg", Form: stem + "n"},
-			{Tense: "present", Person: "2sg", Form: stem + "t"},
-			{Tense: "present", Person: "3sg", Form: stem + "e"},
-			{Tense: "present", Person: "1pl", Form: stem + "mme"},
-			{Tense: "present", Person: "2pl", Form: stem + "tte"},
-			{Tense: "present", Person: "3pl", Form: stem + "vat"},
+		return vc.personForms(active(MoodIndicative, TensePresent), v.stem, v.stem, v.stem+vat)
+	case v.verbType == Type4:
+		// Type 4: haluta (halua-) → haluan, haluat, haluaa...
+		// tavata (tapaa-) → tapaan, tapaat, tapaa...
+		third := v.stem
+		if !endsInLongVowel(v.stem) {
+			third += lastRune(v.stem)
 		}
+		return vc.personForms(active(MoodIndicative, TensePresent), v.stem, third, v.stem+vat)
+	default:
+		// Types 3, 5 and 6: tulla (tule-) → tulen, tulet, tulee...
+		return vc.personForms(active(MoodIndicative, TensePresent), v.stem, v.stem+"e", v.stem+vat)
 	}
-
-	return conjugations
 }
 
 // conjugatePast conjugates past tense
-func (vc *VerbConjugator) conjugatePast(stem string, verbType VerbType) []Conjugation {
-	backVowel := vc.usesBackVowels(stem)
-	pastMarker := vc.vowel(backVowel, "i", "i") // Always 'i' for past
-
-	// Simplified past tense (doesn't handle all consonant gradation)
-	pastStem := stem + pastMarker
-
-	return []Conjugation{
-		{Tense: "past", Person: "1sg", Form: pastStem + "n"},
-		{Tense: "past", Person: "2sg", Form: pastStem + "t"},
-		{Tense: "past", Person: "3sg", Form: pastStem},
-		{Tense: "past", Person: "1pl", Form: pastStem + "mme"},
-		{Tense: "past", Person: "2pl", Form: pastStem + "tte"},
-		{Tense: "past", Person: "3pl", Form: pastStem + "vat"},
+func (vc *VerbConjugator) conjugatePast(v verb) []Conjugation {
+	vat := vc.vowel(v.back, "vat", "vät")
+
+	var past string
+	switch {
+	case v.conjugatesLikeType1():
+		// Type 1: ottaa → otin, otit, otti... with the same gradation as
+		// the present, except in the s-past: tietää → tiesin, tiesi
+		past = vc.type1PastStem(v)
+		weak := past
+		if !sPastVerbs[v.infinitive] {
+			weak = weakGrade(past)
+		}
+		return vc.personForms(active(MoodIndicative, TensePast), weak, past, past+vat)
+	case v.verbType == Type2:
+		// Type 2: syödä → söin, söit, söi...
+		past = vc.type2PastStem(v)
+	case v.verbType == Type4:
+		// Type 4: haluta → halusin, halusit, halusi...
+		past = dropLastRune(v.stem) + "si"
+	default:
+		// Types 3, 5 and 6 drop the e: tulla → tulin, tulit, tuli...
+		past = dropLastRune(v.stem) + "i"
 	}
+	return vc.personForms(active(MoodIndicative, TensePast), past, past, past+vat)
 }
 
-// conjugateConditional conjugates conditional mood
-func (vc *VerbConjugator) conjugateConditional(stem string, verbType VerbType) []Conjugation {
-	backVowel := vc.usesBackVowels(stem)
-	condMarker := vc.vowel(backVowel, "isi", "isi")
+// type1PastStem returns the past stem of a type 1 verb in the strong grade.
+// The i replaces e, i and ä; o, u, y and ö stay; a becomes o in two-syllable
+// verbs whose first vowel is a, e or i (antaa → antoi) and is lost
+// otherwise (ottaa → otti).
+func (vc *VerbConjugator) type1PastStem(v verb) string {
+	base := dropLastRune(v.stem)
+	if sPastVerbs[v.infinitive] {
+		return strings.TrimSuffix(base, "t") + "si"
+	}
 
-	condStem := stem + condMarker
+	switch lastRune(v.stem) {
+	case "e", "i", "ä":
+		return base + "i"
+	case "a":
+		if syllables(v.stem) == 2 && strings.ContainsRune("aei", firstVowel(v.stem)) {
+			return base + "oi"
+		}
+		return base + "i"
+	}
+	return v.stem + "i"
+}
 
-	return []Conjugation{
-		{Tense: "conditional", Person: "1sg", Form: condStem + "n"},
-		{Tense: "conditional", Person: "2sg", Form: condStem + "t"},
-		{Tense: "conditional", Person: "3sg", Form: condStem},
-		{Tense: "conditional", Person: "1pl", Form: condStem + "mme"},
-		{Tense: "conditional", Person: "2pl", Form: condStem + "tte"},
-		{Tense: "conditional", Person: "3pl", Form: condStem + "vat"},
+// type2PastStem returns the past stem of a type 2 verb. A long vowel or a
+// diphthong loses its first vowel before the i: saa- → sai, juo- → joi.
+func (vc *VerbConjugator) type2PastStem(v verb) string {
+	if past, ok := irregularPastStems[v.infinitive]; ok {
+		return past
+	}
+	runes := []rune(v.stem)
+	n := len(runes)
+	if lastRune(v.stem) == "i" {
+		return v.stem // voi-
 	}
+	if n >= 2 && isFinnishVowel(runes[n-2]) && isFinnishVowel(runes[n-1]) {
+		return string(runes[:n-2]) + string(runes[n-1]) + "i"
+	}
+	return v.stem + "i"
+}
+
+// conjugateConditional conjugates conditional mood. The conditional keeps
+// the strong grade throughout: ottaisin, lukisin.
+func (vc *VerbConjugator) conjugateConditional(v verb) []Conjugation {
+	vat := vc.vowel(v.back, "vat", "vät")
+	cond := vc.conditionalStem(v)
+	return vc.personForms(active(MoodConditional, TensePresent), cond, cond, cond+vat)
+}
+
+// conditionalStem returns the stem in -isi, which is also the third person
+// singular and the form used after the negation verb
+func (vc *VerbConjugator) conditionalStem(v verb) string {
+	var cond string
+	switch {
+	case v.conjugatesLikeType1():
+		// e and i are lost before -isi: lukea → lukisi, oppia → oppisi
+		cond = v.stem
+		if last := lastRune(cond); last == "e" || last == "i" {
+			cond = dropLastRune(cond)
+		}
+	case v.verbType == Type2:
+		// syödä → söisi, voida → voisi
+		cond = strings.TrimSuffix(vc.type2PastStem(v), "i")
+	case v.verbType == Type4:
+		// haluta → haluaisi, tavata → tapaisi
+		cond = v.stem
+		if endsInLongVowel(cond) {
+			cond = dropLastRune(cond)
+		}
+	default:
+		// tulla → tulisi
+		cond = dropLastRune(v.stem)
+	}
+	return cond + "isi"
 }
 
 // usesBackVowels determines if the word uses back vowels (a, o, u) or front vowels (ä, ö, y)
 func (vc *VerbConjugator) usesBackVowels(word string) bool {
 	// Check last vowels for vowel harmony
-	for i := len(word) - 1; i >= 0; i-- {
-		switch word[i] {
+	runes := []rune(word)
+	for i := len(runes) - 1; i >= 0; i-- {
+		switch runes[i] {
 		case 'a', 'o', 'u':
 			return true
 		case 'ä', 'ö', 'y':
@@ -237,21 +378,141 @@ func (vc *VerbConjugator) vowel(backVowel bool, back, front string) string {
 	return front
 }
 
+// lastRune returns the last letter of word as a string
+func lastRune(word string) string {
+	runes := []rune(word)
+	if len(runes) == 0 {
+		return ""
+	}
+	return string(runes[len(runes)-1])
+}
+
+func dropLastRune(word string) string {
+	runes := []rune(word)
+	if len(runes) == 0 {
+		return word
+	}
+	return string(runes[:len(runes)-1])
+}
+
+// endsInLongVowel reports whether word ends in a doubled vowel: tapaa
+func endsInLongVowel(word string) bool {
+	runes := []rune(word)
+	n := len(runes)
+	return n >= 2 && runes[n-1] == runes[n-2] && isFinnishVowel(runes[n-1])
+}
+
+// syllables counts the vowel groups of word, which is the number of
+// syllables for verb stems
+func syllables(word string) int {
+	count := 0
+	inVowel := false
+	for _, r := range word {
+		vowel := isFinnishVowel(r)
+		if vowel && !inVowel {
+			count++
+		}
+		inVowel = vowel
+	}
+	return count
+}
+
+func firstVowel(word string) rune {
+	for _, r := range word {
+		if isFinnishVowel(r) {
+			return r
+		}
+	}
+	return 0
+}
+
+// irregularStems gives the present stem of verbs whose stem cannot be
+// derived from the infinitive, mostly where a v or a lost k alternates
+var irregularStems = map[string]string{
+	"tehdä":   "teke",
+	"nähdä":   "näke",
+	"juosta":  "juokse",
+	"pelätä":  "pelkää",
+	"hylätä":  "hylkää",
+	"maata":   "makaa",
+	"tavata":  "tapaa",
+	"luvata":  "lupaa",
+	"kaivata": "kaipaa",
+	"levätä":  "lepää",
+	"paeta":   "pakene",
+	"turvota": "turpoa",
+	"kalveta": "kalpene",
+	"halveta": "halpene",
+	"kelvata": "kelpaa",
+}
+
+// consonantStemVerbs are type 2 verbs conjugated on a stem in -e like type 1
+// verbs in -eA: tehdä (teke-) → teen, tekee, tein, teki
+var consonantStemVerbs = map[string]bool{
+	"tehdä": true,
+	"nähdä": true,
+}
+
+// sPastVerbs are type 1 verbs whose past tense turns the t of the stem
+// into s: tietää → tiesi, tuntea → tunsi
+var sPastVerbs = map[string]bool{
+	"tietää":   true,
+	"huutaa":   true,
+	"löytää":   true,
+	"pyytää":   true,
+	"lentää":   true,
+	"tuntea":   true,
+	"kieltää":  true,
+	"rakentaa": true,
+	"kääntää":  true,
+	"ymmärtää": true,
+	"murtaa":   true,
+	"kiertää":  true,
+	"työntää":  true,
+}
+
+// irregularPastStems gives the past stem of type 2 verbs that do not
+// follow the vowel rules: käydä → kävi
+var irregularPastStems = map[string]string{
+	"käydä": "kävi",
+}
+
+// irregularForms overrides single forms, keyed by mood, tense and person
+var irregularForms = map[string]map[string]string{
+	"olla": {
+		"indicative present 3sg": "on",
+		"indicative present 3pl": "ovat",
+	},
+}
+
+// irregularParticipleStems gives the stem of the past active participle
+// and the potential where it does not follow the verb type: tietää →
+// tiennyt, tiennee
+var irregularParticipleStems = map[string]string{
+	"tietää": "tienn",
+}
+
+// irregularPotentialStems gives potential stems that are not built on
+// the participle: olla → lienen
+var irregularPotentialStems = map[string]string{
+	"olla": "liene",
+}
+
 // Common Finnish verbs for testing
 var CommonVerbs = map[string]string{
-	"olla":     "to be",
-	"sanoa":    "to say",
-	"tehdä":    "to do/make",
-	"tulla":    "to come",
-	"mennä":    "to go",
-	"haluta":   "to want",
-	"voida":    "to be able",
-	"puhua":    "to speak",
+	"olla":       "to be",
+	"sanoa":      "to say",
+	"tehdä":      "to do/make",
+	"tulla":      "to come",
+	"mennä":      "to go",
+	"haluta":     "to want",
+	"voida":      "to be able",
+	"puhua":      "to speak",
 	"kirjoittaa": "to write",
-	"lukea":    "to read",
-	"oppia":    "to learn",
-	"tietää":   "to know",
-	"nähdä":    "to see",
-	"syödä":    "to eat",
-	"juoda":    "to drink",
+	"lukea":      "to read",
+	"oppia":      "to learn",
+	"tietää":     "to know",
+	"nähdä":      "to see",
+	"syödä":      "to eat",
+	"juoda":      "to drink",
 }
@@ -1,6 +1,7 @@
 package language
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -64,3 +65,91 @@ func TestVowelHarmony(t *testing.T) {
 		}
 	}
 }
+
+// paradigms lists present, past and conditional forms in person order:
+// 1sg 2sg 3sg 1pl 2pl 3pl
+var paradigms = map[string][3]string{
+	"olla":       {"olen olet on olemme olette ovat", "olin olit oli olimme olitte olivat", "olisin olisit olisi olisimme olisitte olisivat"},
+	"sanoa":      {"sanon sanot sanoo sanomme sanotte sanovat", "sanoin sanoit sanoi sanoimme sanoitte sanoivat", "sanoisin sanoisit sanoisi sanoisimme sanoisitte sanoisivat"},
+	"tehdä":      {"teen teet tekee teemme teette tekevät", "tein teit teki teimme teitte tekivät", "tekisin tekisit tekisi tekisimme tekisitte tekisivät"},
+	"tulla":      {"tulen tulet tulee tulemme tulette tulevat", "tulin tulit tuli tulimme tulitte tulivat", "tulisin tulisit tulisi tulisimme tulisitte tulisivat"},
+	"mennä":      {"menen menet menee menemme menette menevät", "menin menit meni menimme menitte menivät", "menisin menisit menisi menisimme menisitte menisivät"},
+	"haluta":     {"haluan haluat haluaa haluamme haluatte haluavat", "halusin halusit halusi halusimme halusitte halusivat", "haluaisin haluaisit haluaisi haluaisimme haluaisitte haluaisivat"},
+	"voida":      {"voin voit voi voimme voitte voivat", "voin voit voi voimme voitte voivat", "voisin voisit voisi voisimme voisitte voisivat"},
+	"puhua":      {"puhun puhut puhuu puhumme puhutte puhuvat", "puhuin puhuit puhui puhuimme puhuitte puhuivat", "puhuisin puhuisit puhuisi puhuisimme puhuisitte puhuisivat"},
+	"kirjoittaa": {"kirjoitan kirjoitat kirjoittaa kirjoitamme kirjoitatte kirjoittavat", "kirjoitin kirjoitit kirjoitti kirjoitimme kirjoititte kirjoittivat", "kirjoittaisin kirjoittaisit kirjoittaisi kirjoittaisimme kirjoittaisitte kirjoittaisivat"},
+	"lukea":      {"luen luet lukee luemme luette lukevat", "luin luit luki luimme luitte lukivat", "lukisin lukisit lukisi lukisimme lukisitte lukisivat"},
+	"oppia":      {"opin opit oppii opimme opitte oppivat", "opin opit oppi opimme opitte oppivat", "oppisin oppisit oppisi oppisimme oppisitte oppisivat"},
+	"tietää":     {"tiedän tiedät tietää tiedämme tiedätte tietävät", "tiesin tiesit tiesi tiesimme tiesitte tiesivät", "tietäisin tietäisit tietäisi tietäisimme tietäisitte tietäisivät"},
+	"nähdä":      {"näen näet näkee näemme näette näkevät", "näin näit näki näimme näitte näkivät", "näkisin näkisit näkisi näkisimme näkisitte näkisivät"},
+	"syödä":      {"syön syöt syö syömme syötte syövät", "söin söit söi söimme söitte söivät", "söisin söisit söisi söisimme söisitte söisivät"},
+	"juoda":      {"juon juot juo juomme juotte juovat", "join joit joi joimme joitte joivat", "joisin joisit joisi joisimme joisitte joisivat"},
+
+	// Gradation in each verb type
+	"ottaa":     {"otan otat ottaa otamme otatte ottavat", "otin otit otti otimme otitte ottivat", "ottaisin ottaisit ottaisi ottaisimme ottaisitte ottaisivat"},
+	"antaa":     {"annan annat antaa annamme annatte antavat", "annoin annoit antoi annoimme annoitte antoivat", "antaisin antaisit antaisi antaisimme antaisitte antaisivat"},
+	"lähteä":    {"lähden lähdet lähtee lähdemme lähdette lähtevät", "lähdin lähdit lähti lähdimme lähditte lähtivät", "lähtisin lähtisit lähtisi lähtisimme lähtisitte lähtisivät"},
+	"kulkea":    {"kuljen kuljet kulkee kuljemme kuljette kulkevat", "kuljin kuljit kulki kuljimme kuljitte kulkivat", "kulkisin kulkisit kulkisi kulkisimme kulkisitte kulkisivat"},
+	"ymmärtää":  {"ymmärrän ymmärrät ymmärtää ymmärrämme ymmärrätte ymmärtävät", "ymmärsin ymmärsit ymmärsi ymmärsimme ymmärsitte ymmärsivät", "ymmärtäisin ymmärtäisit ymmärtäisi ymmärtäisimme ymmärtäisitte ymmärtäisivät"},
+	"ajatella":  {"ajattelen ajattelet ajattelee ajattelemme ajattelette ajattelevat", "ajattelin ajattelit ajatteli ajattelimme ajattelitte ajattelivat", "ajattelisin ajattelisit ajattelisi ajattelisimme ajattelisitte ajattelisivat"},
+	"kuunnella": {"kuuntelen kuuntelet kuuntelee kuuntelemme kuuntelette kuuntelevat", "kuuntelin kuuntelit kuunteli kuuntelimme kuuntelitte kuuntelivat", "kuuntelisin kuuntelisit kuuntelisi kuuntelisimme kuuntelisitte kuuntelisivat"},
+	"pudota":    {"putoan putoat putoaa putoamme putoatte putoavat", "putosin putosit putosi putosimme putositte putosivat", "putoaisin putoaisit putoaisi putoaisimme putoaisitte putoaisivat"},
+	"tavata":    {"tapaan tapaat tapaa tapaamme tapaatte tapaavat", "tapasin tapasit tapasi tapasimme tapasitte tapasivat", "tapaisin tapaisit tapaisi tapaisimme tapaisitte tapaisivat"},
+	"hypätä":    {"hyppään hyppäät hyppää hyppäämme hyppäätte hyppäävät", "hyppäsin hyppäsit hyppäsi hyppäsimme hyppäsitte hyppäsivät", "hyppäisin hyppäisit hyppäisi hyppäisimme hyppäisitte hyppäisivät"},
+	"arvata":    {"arvaan arvaat arvaa arvaamme arvaatte arvaavat", "arvasin arvasit arvasi arvasimme arvasitte arvasivat", "arvaisin arvaisit arvaisi arvaisimme arvaisitte arvaisivat"},
+	"turvota":   {"turpoan turpoat turpoaa turpoamme turpoatte turpoavat", "turposin turposit turposi turposimme turpositte turposivat", "turpoaisin turpoaisit turpoaisi turpoaisimme turpoaisitte turpoaisivat"},
+	"kalveta":   {"kalpenen kalpenet kalpenee kalpenemme kalpenette kalpenevat", "kalpenin kalpenit kalpeni kalpenimme kalpenitte kalpenivat", "kalpenisin kalpenisit kalpenisi kalpenisimme kalpenisitte kalpenisivat"},
+	"lämmetä":   {"lämpenen lämpenet lämpenee lämpenemme lämpenette lämpenevät", "lämpenin lämpenit lämpeni lämpenimme lämpenitte lämpenivät", "lämpenisin lämpenisit lämpenisi lämpenisimme lämpenisitte lämpenisivät"},
+}
+
+func TestParadigms(t *testing.T) {
+	conjugator := NewVerbConjugator()
+
+	for verb := range CommonVerbs {
+		if _, ok := paradigms[verb]; !ok {
+			t.Errorf("common verb %s has no expected paradigm", verb)
+		}
+	}
+
+	tenses := []Conjugation{
+		active(MoodIndicative, TensePresent),
+		active(MoodIndicative, TensePast),
+		active(MoodConditional, TensePresent),
+	}
+	for infinitive, want := range paradigms {
+		t.Run(infinitive, func(t *testing.T) {
+			conjugations := conjugator.ConjugateVerb(infinitive)
+			for i, tense := range tenses {
+				if forms := formsOf(conjugations, tense); forms != want[i] {
+					t.Errorf("%s %s %s:\n got %s\nwant %s", infinitive, tense.Mood, tense.Tense, forms, want[i])
+				}
+			}
+		})
+	}
+}
+
+// formsOf joins the forms in the category of want, in paradigm order
+func formsOf(conjugations []Conjugation, want Conjugation) string {
+	var forms []string
+	for _, c := range conjugations {
+		if c.Mood == want.Mood && c.Tense == want.Tense && c.Voice == want.Voice &&
+			c.Polarity == want.Polarity && c.Variant == want.Variant {
+			forms = append(forms, c.Form)
+		}
+	}
+	return strings.Join(forms, " ")
+}
+
+// lv and rv only alternate in the verbs that list it: arvata keeps its v
+func TestImperativeWithoutLabialGradation(t *testing.T) {
+	conjugator := NewVerbConjugator()
+	imperative := active(MoodImperative, TensePresent)
+	for infinitive, want := range map[string]string{
+		"arvata":  "arvaa arvatkoon arvatkaamme arvatkaa arvatkoot",
+		"turvota": "turpoa turvotkoon turvotkaamme turvotkaa turvotkoot",
+	} {
+		if got := formsOf(conjugator.ConjugateVerb(infinitive), imperative); got != want {
+			t.Errorf("imperative of %s:\n got %s\nwant %s", infinitive, got, want)
+		}
+	}
+}
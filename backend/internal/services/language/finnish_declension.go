@@ -0,0 +1,578 @@
+package language
+
+import "strings"
+
+// Case is one of the 15 Finnish grammatical cases
+type Case string
+
+const (
+	CaseNominative  Case = "nominative"
+	CaseGenitive    Case = "genitive"
+	CasePartitive   Case = "partitive"
+	CaseAccusative  Case = "accusative"
+	CaseInessive    Case = "inessive"
+	CaseElative     Case = "elative"
+	CaseIllative    Case = "illative"
+	CaseAdessive    Case = "adessive"
+	CaseAblative    Case = "ablative"
+	CaseAllative    Case = "allative"
+	CaseEssive      Case = "essive"
+	CaseTranslative Case = "translative"
+	CaseInstructive Case = "instructive"
+	CaseAbessive    Case = "abessive"
+	CaseComitative  Case = "comitative"
+)
+
+type Number string
+
+const (
+	NumberSingular Number = "singular"
+	NumberPlural   Number = "plural"
+)
+
+// Declension is one case form of a noun or adjective
+type Declension struct {
+	Case   Case   `json:"case"`
+	Number Number `json:"number"`
+	Form   string `json:"form"`
+}
+
+// NounDecliner handles Finnish noun and adjective declension
+type NounDecliner struct{}
+
+func NewNounDecliner() *NounDecliner {
+	return &NounDecliner{}
+}
+
+// nounStems holds the stems and irregular endings a declension is built
+// from. The weak stems take the endings that close a syllable (-n, -ssa,
+// -lla...), the strong ones the essive and the comitative.
+type nounStems struct {
+	strong, weak             string // singular vowel stem: katu-, kadu-
+	partitive, illative      string // whole forms
+	pluralStrong, pluralWeak string // plural stem in -i: katui-, kadui-
+	pluralGenitive           string
+	pluralPartitive          string
+	pluralIllative           string
+}
+
+// DeclineNoun returns the singular and plural of every case. The
+// instructive and the comitative are only used in the plural; the
+// comitative is given with the third person possessive suffix it needs.
+func (nd *NounDecliner) DeclineNoun(word string) []Declension {
+	word = strings.ToLower(word)
+	back := nounUsesBackVowels(word)
+	st := nd.stems(word, nd.KotusType(word), back)
+	h := func(suffix string) string { return harmonize(suffix, back) }
+
+	singular := []struct {
+		c    Case
+		form string
+	}{
+		{CaseNominative, word},
+		{CaseGenitive, st.weak + "n"},
+		{CasePartitive, st.partitive},
+		{CaseAccusative, st.weak + "n"},
+		{CaseInessive, st.weak + h("ssa")},
+		{CaseElative, st.weak + h("sta")},
+		{CaseIllative, st.illative},
+		{CaseAdessive, st.weak + h("lla")},
+		{CaseAblative, st.weak + h("lta")},
+		{CaseAllative, st.weak + "lle"},
+		{CaseEssive, st.strong + h("na")},
+		{CaseTranslative, st.weak + "ksi"},
+		{CaseAbessive, st.weak + h("tta")},
+	}
+	plural := []struct {
+		c    Case
+		form string
+	}{
+		{CaseNominative, st.weak + "t"},
+		{CaseGenitive, st.pluralGenitive},
+		{CasePartitive, st.pluralPartitive},
+		{CaseAccusative, st.weak + "t"},
+		{CaseInessive, st.pluralWeak + h("ssa")},
+		{CaseElative, st.pluralWeak + h("sta")},
+		{CaseIllative, st.pluralIllative},
+		{CaseAdessive, st.pluralWeak + h("lla")},
+		{CaseAblative, st.pluralWeak + h("lta")},
+		{CaseAllative, st.pluralWeak + "lle"},
+		{CaseEssive, st.pluralStrong + h("na")},
+		{CaseTranslative, st.pluralWeak + "ksi"},
+		{CaseInstructive, st.pluralWeak + "n"},
+		{CaseAbessive, st.pluralWeak + h("tta")},
+		{CaseComitative, st.pluralStrong + "neen"},
+	}
+
+	declensions := make([]Declension, 0, len(singular)+len(plural))
+	for _, f := range singular {
+		declensions = append(declensions, Declension{Case: f.c, Number: NumberSingular, Form: f.form})
+	}
+	for _, f := range plural {
+		declensions = append(declensions, Declension{Case: f.c, Number: NumberPlural, Form: f.form})
+	}
+	return declensions
+}
+
+// KotusType returns the inflection type of word in the numbering of the
+// Kotus dictionary (1 valo, 9 kala, 38 nainen...). Types are read from the
+// ending and syllable count, with common exceptions listed in nounTypes.
+func (nd *NounDecliner) KotusType(word string) int {
+	word = strings.ToLower(word)
+	if t, ok := nounTypes[word]; ok {
+		return t
+	}
+	runes := []rune(word)
+	n := len(runes)
+	if n < 2 {
+		return 18
+	}
+	last, prev := runes[n-1], runes[n-2]
+	syl := syllables(word)
+	hasSuffix := func(suffixes ...string) bool {
+		for _, s := range suffixes {
+			if strings.HasSuffix(word, s) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case hasSuffix("nen"):
+		return 38 // nainen
+	case hasSuffix("ton", "tön"):
+		return 34 // onneton
+	case hasSuffix("mpi"):
+		return 16 // vanhempi
+	case hasSuffix("ut", "yt"):
+		// Participles: kuollut, väsynyt, noussut
+		if n >= 4 && (runes[n-3] == 'n' || runes[n-3] == runes[n-4]) {
+			return 47
+		}
+		return 43 // ohut
+	case hasSuffix("in"):
+		return 33 // puhelin
+	case hasSuffix("eus", "eys", "uus", "yys"):
+		return 40 // kauneus, terveys
+	case hasSuffix("us", "ys", "os", "ös"):
+		return 39 // vastaus
+	case hasSuffix("as", "äs", "is", "es"):
+		return 41 // vieras, kallis
+	case hasSuffix("ar", "är", "er", "el", "al", "äl"):
+		return 32 // sisar, tytär, askel
+	case !isFinnishVowel(last):
+		return 5 // loans take an i: golf → golfin
+	case syl == 1:
+		if prev == last || last == 'i' {
+			return 18 // maa, voi
+		}
+		return 19 // suo, työ, tie
+	case isFinnishVowel(prev) && prev == last:
+		return 17 // vapaa
+	case last == 'e':
+		return 48 // hame, perhe
+	case last == 'i':
+		if syl > 2 && hasSuffix("eri", "ori", "ari", "eli", "ali") {
+			return 6 // paperi
+		}
+		return 5 // risti, kuppi
+	case last == 'a' || last == 'ä':
+		return nd.aType(word, syl)
+	default: // o, u, y, ö
+		switch {
+		case isFinnishVowel(prev):
+			return 3 // valtio
+		case syl > 2 && hasSuffix("kko", "kkö"):
+			return 4 // laatikko
+		case syl > 2:
+			return 2 // palvelu
+		}
+		return 1 // valo, katu
+	}
+}
+
+// aType returns the type of a word in -a or -ä
+func (nd *NounDecliner) aType(word string, syl int) int {
+	hasSuffix := func(suffixes ...string) bool {
+		for _, s := range suffixes {
+			if strings.HasSuffix(word, s) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case hasSuffix("ea", "eä", "oa", "öä"):
+		return 15 // korkea, pimeä
+	case hasSuffix("ia", "iä"):
+		return 12 // historia
+	case syl == 2:
+		// The plural turns a into o after a first syllable with a, e or i
+		// (kala → kaloja), and drops it otherwise (koira → koiria)
+		if strings.HasSuffix(word, "a") && strings.ContainsRune("aei", firstVowel(word)) {
+			return 9
+		}
+		return 10
+	case hasSuffix("ija", "ijä"):
+		return 12 // kulkija
+	case hasSuffix("ja", "jä"):
+		return 10 // opettaja
+	case hasSuffix("kka", "kkä"):
+		return 14 // mansikka
+	case hasSuffix("la", "lä", "ra", "rä"):
+		return 12 // ravintola, kynttilä
+	case hasSuffix("na", "nä"):
+		return 11 // omena
+	case strings.HasSuffix(word, "a"):
+		return 13 // katiska
+	}
+	return 10 // ystävä
+}
+
+// stems builds the stems of word for its Kotus type
+func (nd *NounDecliner) stems(word string, kotus int, back bool) nounStems {
+	if st, ok := irregularNounStems[word]; ok {
+		return st
+	}
+	h := func(suffix string) string { return harmonize(suffix, back) }
+	a := h("a")
+	last := lastRune(word)
+	base := dropLastRune(word)
+	weak := weakGrade
+	if noGradation[word] {
+		weak = func(s string) string { return s }
+	}
+
+	switch kotus {
+	case 1, 2:
+		// talo → talon, taloa, taloon; taloja, talojen
+		return nounStems{
+			strong: word, weak: weak(word),
+			partitive: word + a, illative: word + last + "n",
+			pluralStrong: word + "i", pluralWeak: weak(word + "i"),
+			pluralGenitive: word + "jen", pluralPartitive: word + "j" + a, pluralIllative: word + "ihin",
+		}
+	case 3:
+		// valtio → valtiota; valtioita, valtioiden
+		return nounStems{
+			strong: word, weak: word,
+			partitive: word + "t" + a, illative: word + last + "n",
+			pluralStrong: word + "i", pluralWeak: word + "i",
+			pluralGenitive: word + "iden", pluralPartitive: word + "it" + a, pluralIllative: word + "ihin",
+		}
+	case 4:
+		// laatikko → laatikon; laatikoita, laatikoiden, laatikkoina
+		w := weak(word)
+		return nounStems{
+			strong: word, weak: w,
+			partitive: word + a, illative: word + last + "n",
+			pluralStrong: word + "i", pluralWeak: w + "i",
+			pluralGenitive: w + "iden", pluralPartitive: w + "it" + a, pluralIllative: w + "ihin",
+		}
+	case 5, 6:
+		// risti → ristin, ristiä; ristejä, ristien. Words ending in a
+		// consonant add the i: golf → golfin.
+		stem := word
+		if !isFinnishVowel([]rune(last)[0]) {
+			stem = word + "i"
+		}
+		e := dropLastRune(stem) + "e"
+		st := nounStems{
+			strong: stem, weak: weak(stem),
+			partitive: stem + a, illative: stem + "in",
+			pluralStrong: e + "i", pluralWeak: weak(e + "i"),
+			pluralGenitive: stem + "en", pluralPartitive: e + "j" + a, pluralIllative: e + "ihin",
+		}
+		if kotus == 6 {
+			st.pluralPartitive = e + "it" + a // papereita
+		}
+		return st
+	case 7:
+		// ovi → oven, ovea, oveen; ovia, ovien
+		e := base + "e"
+		return nounStems{
+			strong: e, weak: weak(e),
+			partitive: e + a, illative: e + "en",
+			pluralStrong: word, pluralWeak: weak(word),
+			pluralGenitive: word + "en", pluralPartitive: word + a, pluralIllative: word + "in",
+		}
+	case 8:
+		// nalle → nallen, nallea; nalleja, nallejen
+		return nounStems{
+			strong: word, weak: weak(word),
+			partitive: word + a, illative: word + "en",
+			pluralStrong: word + "i", pluralWeak: weak(word + "i"),
+			pluralGenitive: word + "jen", pluralPartitive: word + "j" + a, pluralIllative: word + "ihin",
+		}
+	case 9:
+		// kala → kalan, kalaa; kaloja, kalojen
+		o := base + h("o")
+		return nounStems{
+			strong: word, weak: weak(word),
+			partitive: word + a, illative: word + last + "n",
+			pluralStrong: o + "i", pluralWeak: weak(o + "i"),
+			pluralGenitive: o + "jen", pluralPartitive: o + "j" + a, pluralIllative: o + "ihin",
+		}
+	case 10:
+		// koira → koiran, koiraa; koiria, koirien
+		i := base + "i"
+		return nounStems{
+			strong: word, weak: weak(word),
+			partitive: word + a, illative: word + last + "n",
+			pluralStrong: i, pluralWeak: weak(i),
+			pluralGenitive: i + "en", pluralPartitive: i + a, pluralIllative: i + "in",
+		}
+	case 11, 12, 13:
+		// ravintola → ravintolaa; ravintoloita, ravintoloiden
+		oi := base + h("oi")
+		return nounStems{
+			strong: word, weak: word,
+			partitive: word + a, illative: word + last + "n",
+			pluralStrong: oi, pluralWeak: oi,
+			pluralGenitive: oi + "den", pluralPartitive: oi + "t" + a, pluralIllative: oi + "hin",
+		}
+	case 14:
+		// mansikka → mansikan; mansikoita, mansikoiden, mansikkoina
+		oi := base + h("oi")
+		return nounStems{
+			strong: word, weak: weak(word),
+			partitive: word + a, illative: word + last + "n",
+			pluralStrong: oi, pluralWeak: weak(oi),
+			pluralGenitive: weak(oi) + "den", pluralPartitive: weak(oi) + "t" + a, pluralIllative: weak(oi) + "hin",
+		}
+	case 15:
+		// korkea → korkeaa, korkeaan; korkeita, korkeisiin
+		return nounStems{
+			strong: word, weak: word,
+			partitive: word + a, illative: word + last + "n",
+			pluralStrong: base + "i", pluralWeak: base + "i",
+			pluralGenitive: base + "iden", pluralPartitive: base + "it" + a, pluralIllative: base + "isiin",
+		}
+	case 16:
+		// vanhempi → vanhemman, vanhempaa; vanhempia, vanhemmissa
+		stem := base + a
+		return nounStems{
+			strong: stem, weak: weak(stem),
+			partitive: stem + a, illative: stem + a + "n",
+			pluralStrong: word, pluralWeak: weak(word),
+			pluralGenitive: word + "en", pluralPartitive: word + a, pluralIllative: word + "in",
+		}
+	case 17:
+		// vapaa → vapaata, vapaaseen; vapaita, vapaisiin
+		return nounStems{
+			strong: word, weak: word,
+			partitive: word + "t" + a, illative: word + "seen",
+			pluralStrong: base + "i", pluralWeak: base + "i",
+			pluralGenitive: base + "iden", pluralPartitive: base + "it" + a, pluralIllative: base + "isiin",
+		}
+	case 18, 19:
+		// maa → maata, maahan; maita, maiden. suo → soita: a diphthong
+		// loses its first vowel before the plural i.
+		plural := word
+		runes := []rune(word)
+		if last != "i" {
+			plural = string(runes[:len(runes)-2]) + last + "i"
+			if kotus == 18 {
+				plural = base + "i"
+			}
+		}
+		return nounStems{
+			strong: word, weak: word,
+			partitive: word + "t" + a, illative: word + "h" + last + "n",
+			pluralStrong: plural, pluralWeak: plural,
+			pluralGenitive: plural + "den", pluralPartitive: plural + "t" + a, pluralIllative: plural + "hin",
+		}
+	case 23, 24, 25, 26:
+		// tiili → tiilen, tiiltä; uni → unta; lumi → lunta; pieni → pienten
+		e := base + "e"
+		consonant := base
+		if kotus == 25 {
+			consonant = strings.TrimSuffix(base, "m") + "n"
+		}
+		genitive := word + "en"
+		if kotus == 26 {
+			genitive = base + "ten"
+		}
+		return nounStems{
+			strong: e, weak: e,
+			partitive: consonant + "t" + a, illative: e + "en",
+			pluralStrong: word, pluralWeak: word,
+			pluralGenitive: genitive, pluralPartitive: word + a, pluralIllative: word + "in",
+		}
+	case 27, 28:
+		// käsi → käden, kättä, käteen; käsiä, käsien
+		root := strings.TrimSuffix(word, "si")
+		strong := root + "te"
+		return nounStems{
+			strong: strong, weak: weakGrade(strong),
+			partitive: root + "tt" + a, illative: strong + "en",
+			pluralStrong: word, pluralWeak: word,
+			pluralGenitive: word + "en", pluralPartitive: word + a, pluralIllative: word + "in",
+		}
+	case 32, 49:
+		// sisar → sisaren, sisarta; sisaria. The stem takes the strong
+		// grade: tytär → tyttären.
+		stem := strongGrade(word) + "e"
+		i := dropLastRune(stem) + "i"
+		return nounStems{
+			strong: stem, weak: stem,
+			partitive: word + "t" + a, illative: stem + "en",
+			pluralStrong: i, pluralWeak: i,
+			pluralGenitive: i + "en", pluralPartitive: i + a, pluralIllative: i + "in",
+		}
+	case 33:
+		// puhelin → puhelimen, puhelinta; puhelimia
+		stem := base + "me"
+		i := base + "mi"
+		return nounStems{
+			strong: stem, weak: stem,
+			partitive: word + "t" + a, illative: stem + "en",
+			pluralStrong: i, pluralWeak: i,
+			pluralGenitive: i + "en", pluralPartitive: i + a, pluralIllative: i + "in",
+		}
+	case 34:
+		// onneton → onnettoman, onnetonta; onnettomia
+		root := strongGrade(base)
+		stem := root + "m" + a
+		i := root + "mi"
+		return nounStems{
+			strong: stem, weak: stem,
+			partitive: word + "t" + a, illative: stem + a + "n",
+			pluralStrong: i, pluralWeak: i,
+			pluralGenitive: i + "en", pluralPartitive: i + a, pluralIllative: i + "in",
+		}
+	case 38:
+		// nainen → naisen, naista; naisia, naisten
+		root := strings.TrimSuffix(word, "nen")
+		return nounStems{
+			strong: root + "se", weak: root + "se",
+			partitive: root + "st" + a, illative: root + "seen",
+			pluralStrong: root + "si", pluralWeak: root + "si",
+			pluralGenitive: root + "sten", pluralPartitive: root + "si" + a, pluralIllative: root + "siin",
+		}
+	case 39:
+		// vastaus → vastauksen, vastausta; vastauksia, vastausten
+		i := base + "ksi"
+		return nounStems{
+			strong: base + "kse", weak: base + "kse",
+			partitive: word + "t" + a, illative: base + "kseen",
+			pluralStrong: i, pluralWeak: i,
+			pluralGenitive: word + "ten", pluralPartitive: i + a, pluralIllative: i + "in",
+		}
+	case 40:
+		// rakkaus → rakkauden, rakkautta, rakkauteen; rakkauksia
+		strong := base + "te"
+		i := base + "ksi"
+		return nounStems{
+			strong: strong, weak: weakGrade(strong),
+			partitive: base + "tt" + a, illative: strong + "en",
+			pluralStrong: i, pluralWeak: i,
+			pluralGenitive: i + "en", pluralPartitive: i + a, pluralIllative: i + "in",
+		}
+	case 41:
+		// vieras → vieraan, vierasta; vieraita. The stem takes the strong
+		// grade: rikas → rikkaan. Words in -is rarely alternate (kallis).
+		root := base
+		if !strings.HasSuffix(word, "is") {
+			root = strongGrade(base)
+		}
+		stem := root + lastRune(root)
+		i := root + "i"
+		return nounStems{
+			strong: stem, weak: stem,
+			partitive: word + "t" + a, illative: stem + "seen",
+			pluralStrong: i, pluralWeak: i,
+			pluralGenitive: i + "den", pluralPartitive: i + "t" + a, pluralIllative: i + "siin",
+		}
+	case 43:
+		// ohut → ohuen, ohutta; ohuita
+		i := base + "i"
+		return nounStems{
+			strong: base + "e", weak: base + "e",
+			partitive: word + "t" + a, illative: base + "een",
+			pluralStrong: i, pluralWeak: i,
+			pluralGenitive: i + "den", pluralPartitive: i + "t" + a, pluralIllative: i + "hin",
+		}
+	case 47:
+		// kuollut → kuolleen, kuollutta; kuolleita
+		root := strings.TrimSuffix(strings.TrimSuffix(word, "ut"), "yt")
+		stem := root + "ee"
+		i := root + "ei"
+		return nounStems{
+			strong: stem, weak: stem,
+			partitive: word + "t" + a, illative: stem + "seen",
+			pluralStrong: i, pluralWeak: i,
+			pluralGenitive: i + "den", pluralPartitive: i + "t" + a, pluralIllative: i + "siin",
+		}
+	default: // 48
+		// hame → hameen, hametta; hameita. The stem takes the strong
+		// grade: osoite → osoitteen.
+		root := strongGrade(word)
+		stem := root + "e"
+		i := root + "i"
+		return nounStems{
+			strong: stem, weak: stem,
+			partitive: word + "tt" + a, illative: stem + "seen",
+			pluralStrong: i, pluralWeak: i,
+			pluralGenitive: i + "den", pluralPartitive: i + "t" + a, pluralIllative: i + "siin",
+		}
+	}
+}
+
+// nounUsesBackVowels determines vowel harmony from the last a, o, u, ä, ö
+// or y; words with only e and i take front vowels (kiveä)
+func nounUsesBackVowels(word string) bool {
+	runes := []rune(word)
+	for i := len(runes) - 1; i >= 0; i-- {
+		switch runes[i] {
+		case 'a', 'o', 'u':
+			return true
+		case 'ä', 'ö', 'y':
+			return false
+		}
+	}
+	return false
+}
+
+// nounTypes gives the Kotus type of common words whose ending does not
+// decide it, mostly old words in -i with a stem in -e
+var nounTypes = map[string]int{
+	"ovi": 7, "kivi": 7, "järvi": 7, "joki": 7, "lehti": 7, "nimi": 7, "mäki": 7,
+	"onni": 7, "pilvi": 7, "sormi": 7, "tähti": 7, "laki": 7, "väki": 7, "hetki": 7,
+	"kaikki": 7, "lahti": 7, "kurki": 7, "suomi": 7, "arki": 7, "talvi": 7,
+	"nalle": 8, "nukke": 8, "kolme": 8, "aika": 9, "poika": 10,
+	"tiili": 23, "hiili": 23,
+	"uni": 24, "meri": 24, "veri": 24,
+	"lumi": 25, "toimi": 25, "liemi": 25, "niemi": 25, "taimi": 25,
+	"pieni": 26, "suuri": 26, "nuori": 26, "kieli": 26, "saari": 26, "tuuli": 26,
+	"juuri": 26, "sieni": 26, "kuori": 26, "sääri": 26, "jouhi": 26,
+	"käsi": 27, "vesi": 27, "uusi": 27, "kuusi": 27, "vuosi": 27, "susi": 27,
+	"täysi": 27, "köysi": 27, "mesi": 27, "viisi": 27, "hirsi": 28, "kynsi": 28,
+	"kansi": 28, "virsi": 28, "lapsi": 29, "veitsi": 30, "kaksi": 31,
+	"sydän": 33, "lämmin": 35, "mies": 42, "kevät": 44, "tuhat": 46,
+	"rakkaus": 40, "rikkaus": 40,
+}
+
+// irregularNounStems gives the stems of words that no type rule covers
+var irregularNounStems = map[string]nounStems{
+	"aika":   {"aika", "aja", "aikaa", "aikaan", "aikoi", "ajoi", "aikojen", "aikoja", "aikoihin"},
+	"poika":  {"poika", "poja", "poikaa", "poikaan", "poiki", "poji", "poikien", "poikia", "poikiin"},
+	"yksi":   {"yhte", "yhde", "yhtä", "yhteen", "yksi", "yksi", "yksien", "yksiä", "yksiin"},
+	"lapsi":  {"lapse", "lapse", "lasta", "lapseen", "lapsi", "lapsi", "lasten", "lapsia", "lapsiin"},
+	"veitsi": {"veitse", "veitse", "veistä", "veitseen", "veitsi", "veitsi", "veisten", "veitsiä", "veitsiin"},
+	"kaksi":  {"kahte", "kahde", "kahta", "kahteen", "kaksi", "kaksi", "kaksien", "kaksia", "kaksiin"},
+	"mies":   {"miehe", "miehe", "miestä", "mieheen", "miehi", "miehi", "miesten", "miehiä", "miehiin"},
+	"kevät":  {"kevää", "kevää", "kevättä", "kevääseen", "keväi", "keväi", "keväiden", "keväitä", "keväisiin"},
+	"tuhat":  {"tuhante", "tuhanne", "tuhatta", "tuhanteen", "tuhansi", "tuhansi", "tuhansien", "tuhansia", "tuhansiin"},
+	"lämmin": {"lämpimä", "lämpimä", "lämmintä", "lämpimään", "lämpimi", "lämpimi", "lämpimien", "lämpimiä", "lämpimiin"},
+}
+
+// noGradation lists loans whose stem consonants do not alternate
+var noGradation = map[string]bool{
+	"auto":    true,
+	"foto":    true,
+	"eko":     true,
+	"ateljee": true,
+}
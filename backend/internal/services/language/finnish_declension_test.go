@@ -0,0 +1,165 @@
+package language
+
+import (
+	"strings"
+	"testing"
+)
+
+// caseForm returns the form of one case and number
+func caseForm(declensions []Declension, c Case, number Number) string {
+	for _, d := range declensions {
+		if d.Case == c && d.Number == number {
+			return d.Form
+		}
+	}
+	return ""
+}
+
+func TestDeclineNounFullParadigm(t *testing.T) {
+	declensions := NewNounDecliner().DeclineNoun("katu")
+	if len(declensions) != 28 {
+		t.Fatalf("got %d forms, want 13 singular and 15 plural", len(declensions))
+	}
+
+	tests := []struct {
+		c        Case
+		singular string
+		plural   string
+	}{
+		{CaseNominative, "katu", "kadut"},
+		{CaseGenitive, "kadun", "katujen"},
+		{CasePartitive, "katua", "katuja"},
+		{CaseAccusative, "kadun", "kadut"},
+		{CaseInessive, "kadussa", "kaduissa"},
+		{CaseElative, "kadusta", "kaduista"},
+		{CaseIllative, "katuun", "katuihin"},
+		{CaseAdessive, "kadulla", "kaduilla"},
+		{CaseAblative, "kadulta", "kaduilta"},
+		{CaseAllative, "kadulle", "kaduille"},
+		{CaseEssive, "katuna", "katuina"},
+		{CaseTranslative, "kaduksi", "kaduiksi"},
+		{CaseInstructive, "", "kaduin"},
+		{CaseAbessive, "kadutta", "kaduitta"},
+		{CaseComitative, "", "katuineen"},
+	}
+
+	for _, tt := range tests {
+		if got := caseForm(declensions, tt.c, NumberSingular); got != tt.singular {
+			t.Errorf("singular %s = %q, want %q", tt.c, got, tt.singular)
+		}
+		if got := caseForm(declensions, tt.c, NumberPlural); got != tt.plural {
+			t.Errorf("plural %s = %q, want %q", tt.c, got, tt.plural)
+		}
+	}
+}
+
+// TestDeclineNounByType checks the forms where the Kotus types differ:
+// singular genitive, partitive, illative and essive, then plural
+// nominative, genitive, partitive, inessive and illative
+func TestDeclineNounByType(t *testing.T) {
+	decliner := NewNounDecliner()
+
+	tests := []struct {
+		word  string
+		kotus int
+		want  string
+	}{
+		{"talo", 1, "talon taloa taloon talona talot talojen taloja taloissa taloihin"},
+		{"palvelu", 2, "palvelun palvelua palveluun palveluna palvelut palvelujen palveluja palveluissa palveluihin"},
+		{"valtio", 3, "valtion valtiota valtioon valtiona valtiot valtioiden valtioita valtioissa valtioihin"},
+		{"laatikko", 4, "laatikon laatikkoa laatikkoon laatikkona laatikot laatikoiden laatikoita laatikoissa laatikoihin"},
+		{"kuppi", 5, "kupin kuppia kuppiin kuppina kupit kuppien kuppeja kupeissa kuppeihin"},
+		{"bussi", 5, "bussin bussia bussiin bussina bussit bussien busseja busseissa busseihin"},
+		{"golf", 5, "golfin golfia golfiin golfina golfit golfien golfeja golfeissa golfeihin"},
+		{"paperi", 6, "paperin paperia paperiin paperina paperit paperien papereita papereissa papereihin"},
+		{"joki", 7, "joen jokea jokeen jokena joet jokien jokia joissa jokiin"},
+		{"lehti", 7, "lehden lehteä lehteen lehtenä lehdet lehtien lehtiä lehdissä lehtiin"},
+		{"nukke", 8, "nuken nukkea nukkeen nukkena nuket nukkejen nukkeja nukeissa nukkeihin"},
+		{"kala", 9, "kalan kalaa kalaan kalana kalat kalojen kaloja kaloissa kaloihin"},
+		{"pata", 9, "padan pataa pataan patana padat patojen patoja padoissa patoihin"},
+		{"koira", 10, "koiran koiraa koiraan koirana koirat koirien koiria koirissa koiriin"},
+		{"kukka", 10, "kukan kukkaa kukkaan kukkana kukat kukkien kukkia kukissa kukkiin"},
+		{"pöytä", 10, "pöydän pöytää pöytään pöytänä pöydät pöytien pöytiä pöydissä pöytiin"},
+		{"opettaja", 10, "opettajan opettajaa opettajaan opettajana opettajat opettajien opettajia opettajissa opettajiin"},
+		{"omena", 11, "omenan omenaa omenaan omenana omenat omenoiden omenoita omenoissa omenoihin"},
+		{"kynttilä", 12, "kynttilän kynttilää kynttilään kynttilänä kynttilät kynttilöiden kynttilöitä kynttilöissä kynttilöihin"},
+		{"mansikka", 14, "mansikan mansikkaa mansikkaan mansikkana mansikat mansikoiden mansikoita mansikoissa mansikoihin"},
+		{"korkea", 15, "korkean korkeaa korkeaan korkeana korkeat korkeiden korkeita korkeissa korkeisiin"},
+		{"vanhempi", 16, "vanhemman vanhempaa vanhempaan vanhempana vanhemmat vanhempien vanhempia vanhemmissa vanhempiin"},
+		{"vapaa", 17, "vapaan vapaata vapaaseen vapaana vapaat vapaiden vapaita vapaissa vapaisiin"},
+		{"maa", 18, "maan maata maahan maana maat maiden maita maissa maihin"},
+		{"puu", 18, "puun puuta puuhun puuna puut puiden puita puissa puihin"},
+		{"suo", 19, "suon suota suohon suona suot soiden soita soissa soihin"},
+		{"työ", 19, "työn työtä työhön työnä työt töiden töitä töissä töihin"},
+		{"tie", 19, "tien tietä tiehen tienä tiet teiden teitä teissä teihin"},
+		{"uni", 24, "unen unta uneen unena unet unien unia unissa uniin"},
+		{"lumi", 25, "lumen lunta lumeen lumena lumet lumien lumia lumissa lumiin"},
+		{"pieni", 26, "pienen pientä pieneen pienenä pienet pienten pieniä pienissä pieniin"},
+		{"käsi", 27, "käden kättä käteen kätenä kädet käsien käsiä käsissä käsiin"},
+		{"vuosi", 27, "vuoden vuotta vuoteen vuotena vuodet vuosien vuosia vuosissa vuosiin"},
+		{"kynsi", 28, "kynnen kynttä kynteen kyntenä kynnet kynsien kynsiä kynsissä kynsiin"},
+		{"lapsi", 29, "lapsen lasta lapseen lapsena lapset lasten lapsia lapsissa lapsiin"},
+		{"sisar", 32, "sisaren sisarta sisareen sisarena sisaret sisarien sisaria sisarissa sisariin"},
+		{"tytär", 32, "tyttären tytärtä tyttäreen tyttärenä tyttäret tyttärien tyttäriä tyttärissä tyttäriin"},
+		{"puhelin", 33, "puhelimen puhelinta puhelimeen puhelimena puhelimet puhelimien puhelimia puhelimissa puhelimiin"},
+		{"onneton", 34, "onnettoman onnetonta onnettomaan onnettomana onnettomat onnettomien onnettomia onnettomissa onnettomiin"},
+		{"lämmin", 35, "lämpimän lämmintä lämpimään lämpimänä lämpimät lämpimien lämpimiä lämpimissä lämpimiin"},
+		{"nainen", 38, "naisen naista naiseen naisena naiset naisten naisia naisissa naisiin"},
+		{"vastaus", 39, "vastauksen vastausta vastaukseen vastauksena vastaukset vastausten vastauksia vastauksissa vastauksiin"},
+		{"rakkaus", 40, "rakkauden rakkautta rakkauteen rakkautena rakkaudet rakkauksien rakkauksia rakkauksissa rakkauksiin"},
+		{"terveys", 40, "terveyden terveyttä terveyteen terveytenä terveydet terveyksien terveyksiä terveyksissä terveyksiin"},
+		{"vieras", 41, "vieraan vierasta vieraaseen vieraana vieraat vieraiden vieraita vieraissa vieraisiin"},
+		{"rikas", 41, "rikkaan rikasta rikkaaseen rikkaana rikkaat rikkaiden rikkaita rikkaissa rikkaisiin"},
+		{"kallis", 41, "kalliin kallista kalliiseen kalliina kalliit kalliiden kalliita kalliissa kalliisiin"},
+		{"mies", 42, "miehen miestä mieheen miehenä miehet miesten miehiä miehissä miehiin"},
+		{"ohut", 43, "ohuen ohutta ohueen ohuena ohuet ohuiden ohuita ohuissa ohuihin"},
+		{"kevät", 44, "kevään kevättä kevääseen keväänä keväät keväiden keväitä keväissä keväisiin"},
+		{"kuollut", 47, "kuolleen kuollutta kuolleeseen kuolleena kuolleet kuolleiden kuolleita kuolleissa kuolleisiin"},
+		{"hame", 48, "hameen hametta hameeseen hameena hameet hameiden hameita hameissa hameisiin"},
+		{"osoite", 48, "osoitteen osoitetta osoitteeseen osoitteena osoitteet osoitteiden osoitteita osoitteissa osoitteisiin"},
+		{"auto", 1, "auton autoa autoon autona autot autojen autoja autoissa autoihin"},
+	}
+
+	for _, tt := range tests {
+		if got := decliner.KotusType(tt.word); got != tt.kotus {
+			t.Errorf("KotusType(%q) = %d, want %d", tt.word, got, tt.kotus)
+		}
+
+		d := decliner.DeclineNoun(tt.word)
+		got := strings.Join([]string{
+			caseForm(d, CaseGenitive, NumberSingular),
+			caseForm(d, CasePartitive, NumberSingular),
+			caseForm(d, CaseIllative, NumberSingular),
+			caseForm(d, CaseEssive, NumberSingular),
+			caseForm(d, CaseNominative, NumberPlural),
+			caseForm(d, CaseGenitive, NumberPlural),
+			caseForm(d, CasePartitive, NumberPlural),
+			caseForm(d, CaseInessive, NumberPlural),
+			caseForm(d, CaseIllative, NumberPlural),
+		}, " ")
+		if got != tt.want {
+			t.Errorf("DeclineNoun(%q):\n got %s\nwant %s", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestNounVowelHarmony(t *testing.T) {
+	decliner := NewNounDecliner()
+
+	tests := []struct {
+		word string
+		want string
+	}{
+		{"talo", "talossa"},
+		{"kylä", "kylässä"},
+		{"kivi", "kivessä"},
+		{"tyttö", "tytössä"},
+		{"olut", "oluessa"},
+	}
+
+	for _, tt := range tests {
+		if got := caseForm(decliner.DeclineNoun(tt.word), CaseInessive, NumberSingular); got != tt.want {
+			t.Errorf("inessive of %q = %q, want %q", tt.word, got, tt.want)
+		}
+	}
+}
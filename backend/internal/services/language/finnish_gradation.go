@@ -0,0 +1,104 @@
+package language
+
+import "strings"
+
+// Consonant gradation: the consonants that begin the last syllable of a
+// stem alternate between a strong grade, used when that syllable is open
+// (ot-taa, lu-kee), and a weak grade, used when it is closed (o-tan, lu-en).
+
+// weakGrades maps a strong-grade cluster to its weak grade
+var weakGrades = map[string]string{
+	"kk": "k",  // nukkua → nukun
+	"pp": "p",  // oppia → opin
+	"tt": "t",  // ottaa → otan
+	"nk": "ng", // onkia → ongin
+	"mp": "mm", // ampua → ammun
+	"nt": "nn", // antaa → annan
+	"lt": "ll", // kieltää → kiellän
+	"rt": "rr", // kertoa → kerron
+	"ht": "hd", // lähteä → lähden
+	"lk": "l",  // alkaa → alan
+	"rk": "r",  // purkaa → puran
+	"hk": "h",  // pyyhkiä → pyyhin
+	"lp": "lv", // kylpeä → kylven
+	"rp": "rv", // turpoaa → turvota
+	"k":  "",   // lukea → luen
+	"p":  "v",  // sopia → sovin
+	"t":  "d",  // tietää → tiedän
+}
+
+// strongGrades maps a weak-grade cluster to its strong grade. Verbs of
+// types 3, 4 and 6 have the weak grade in the infinitive and the strong
+// grade in their stem. A single v (p), lv and rv (lp, rp) and a lost k are
+// left to irregularStems, as most v's and most vowel pairs do not
+// alternate: arvata → arvaan but kalveta → kalpenen.
+var strongGrades = map[string]string{
+	"k":  "kk", // hakata → hakkaan
+	"p":  "pp", // hypätä → hyppään
+	"t":  "tt", // ajatella → ajattelen
+	"d":  "t",  // pudota → putoan
+	"ng": "nk",
+	"mm": "mp", // ommella → ompelen
+	"nn": "nt", // kuunnella → kuuntelen
+	"ll": "lt", // vallata → valtaan
+	"rr": "rt", // kerrata → kertaan
+	"hd": "ht",
+}
+
+func isFinnishVowel(r rune) bool {
+	return strings.ContainsRune("aeiouyäö", r)
+}
+
+// gradationSite returns the bounds of the consonant cluster that begins the
+// last syllable of word, ignoring consonants at the end of the word. ok is
+// false when there is no such cluster or it starts the word (tulla, olla).
+func gradationSite(word []rune) (start, end int, ok bool) {
+	i := len(word) - 1
+	for i >= 0 && !isFinnishVowel(word[i]) {
+		i--
+	}
+	for i >= 0 && isFinnishVowel(word[i]) {
+		i--
+	}
+	end = i + 1
+	for i >= 0 && !isFinnishVowel(word[i]) {
+		i--
+	}
+	start = i + 1
+	return start, end, i >= 0 && start < end
+}
+
+// weakGrade returns word with the consonants of its last syllable in the
+// weak grade: otta → ota, luke → lue, tietä → tiedä
+func weakGrade(word string) string {
+	runes := []rune(word)
+	start, end, ok := gradationSite(runes)
+	if !ok {
+		return word
+	}
+	cluster := string(runes[start:end])
+	weak, ok := weakGrades[cluster]
+	if !ok {
+		return word
+	}
+	// lk and rk soften to lj and rj before e and i: kulkea → kuljen
+	if (cluster == "lk" || cluster == "rk") && end < len(runes) && (runes[end] == 'e' || runes[end] == 'i') {
+		weak = cluster[:1] + "j"
+	}
+	return string(runes[:start]) + weak + string(runes[end:])
+}
+
+// strongGrade returns word with the consonants of its last syllable in the
+// strong grade: ajatel → ajattel, pudo → puto
+func strongGrade(word string) string {
+	runes := []rune(word)
+	start, end, ok := gradationSite(runes)
+	if !ok {
+		return word
+	}
+	strong, ok := strongGrades[string(runes[start:end])]
+	if !ok {
+		return word
+	}
+	return string(runes[:start]) + strong + string(runes[end:])
+}
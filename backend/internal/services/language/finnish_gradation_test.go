@@ -0,0 +1,59 @@
+package language
+
+import "testing"
+
+func TestWeakGrade(t *testing.T) {
+	tests := []struct {
+		strong string
+		want   string
+	}{
+		{"otta", "ota"},      // tt/t
+		{"oppi", "opi"},      // pp/p
+		{"nukku", "nuku"},    // kk/k
+		{"luke", "lue"},      // k/∅
+		{"sopi", "sovi"},     // p/v
+		{"tietä", "tiedä"},   // t/d
+		{"anta", "anna"},     // nt/nn
+		{"kieltä", "kiellä"}, // lt/ll
+		{"kerto", "kerro"},   // rt/rr
+		{"onki", "ongi"},     // nk/ng
+		{"ampu", "ammu"},     // mp/mm
+		{"kulke", "kulje"},   // lk/lj before e
+		{"alka", "ala"},      // lk/l
+		{"antoi", "annoi"},   // past stems grade the same way
+		{"sano", "sano"},     // n does not alternate
+		{"osta", "osta"},     // nor does st
+		{"tule", "tule"},     // nor a word-initial consonant
+	}
+
+	for _, tt := range tests {
+		if got := weakGrade(tt.strong); got != tt.want {
+			t.Errorf("weakGrade(%s) = %s, want %s", tt.strong, got, tt.want)
+		}
+	}
+}
+
+func TestStrongGrade(t *testing.T) {
+	tests := []struct {
+		weak string
+		want string
+	}{
+		{"ajatel", "ajattel"},  // t/tt
+		{"hypä", "hyppä"},      // p/pp
+		{"haka", "hakka"},      // k/kk
+		{"pudo", "puto"},       // d/t
+		{"kuunnel", "kuuntel"}, // nn/nt
+		{"ommel", "ompel"},     // mm/mp
+		{"kerra", "kerta"},     // rr/rt
+		{"halu", "halu"},       // l does not alternate
+		{"kävel", "kävel"},     // v is left to the lexicon
+		{"arva", "arva"},       // so are lv and rv
+		{"men", "men"},         // word-initial consonant
+	}
+
+	for _, tt := range tests {
+		if got := strongGrade(tt.weak); got != tt.want {
+			t.Errorf("strongGrade(%s) = %s, want %s", tt.weak, got, tt.want)
+		}
+	}
+}
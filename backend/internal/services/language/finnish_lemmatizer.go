@@ -0,0 +1,440 @@
+package language
+
+import (
+	_ "embed"
+	"strings"
+	"unicode/utf8"
+)
+
+//go:embed lexicon/finnish.txt
+var finnishLexicon string
+
+// Analysis is one morphological reading of a word form: talossanikin is
+// talo, inessive singular, with the 1sg possessive suffix and -kin
+type Analysis struct {
+	Lemma        string       `json:"lemma"`
+	PartOfSpeech string       `json:"part_of_speech"`
+	Case         Case         `json:"case,omitempty"`
+	Number       Number       `json:"number,omitempty"`
+	Mood         Mood         `json:"mood,omitempty"`
+	Tense        Tense        `json:"tense,omitempty"`
+	Voice        Voice        `json:"voice,omitempty"`
+	Person       string       `json:"person,omitempty"`
+	Variant      string       `json:"variant,omitempty"`
+	Definiteness Definiteness `json:"definiteness,omitempty"` // Swedish nouns and adjectives
+	Gender       Gender       `json:"gender,omitempty"`
+	Degree       Degree       `json:"degree,omitempty"`
+	Possessive   string       `json:"possessive,omitempty"` // 1sg, 2sg, 1pl, 2pl, 3
+	Clitics      []string     `json:"clitics,omitempty"`    // ko, kin, han..., innermost first
+	Guessed      bool         `json:"guessed,omitempty"`    // the lemma is not in the lexicon, nor the head of its compound
+}
+
+// Lemmatizer maps Finnish word forms to their lemmas. The forms of the
+// words of the bundled lexicon are generated with the decliner and the
+// conjugator and looked up. Other words are guessed: endings are stripped
+// to propose lemmas, and a lemma is kept if one of its own generated forms
+// is the word.
+type Lemmatizer struct {
+	decliner   *NounDecliner
+	conjugator *VerbConjugator
+	forms      map[string][]Analysis
+}
+
+func NewLemmatizer() *Lemmatizer {
+	l := &Lemmatizer{
+		decliner:   NewNounDecliner(),
+		conjugator: NewVerbConjugator(),
+		forms:      make(map[string][]Analysis),
+	}
+	for _, line := range strings.Split(finnishLexicon, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		l.addLemma(fields[0], fields[1])
+	}
+	for lemma, pronoun := range personalPronouns {
+		for i, form := range pronoun.forms {
+			l.add(form, Analysis{Lemma: lemma, PartOfSpeech: "pronoun", Case: pronounCases[i], Number: pronoun.number})
+		}
+	}
+	for i, form := range negationVerbs {
+		l.add(form, Analysis{Lemma: "ei", PartOfSpeech: "verb", Person: persons[i]})
+	}
+	return l
+}
+
+// addLemma indexes every form of a lexicon entry
+func (l *Lemmatizer) addLemma(lemma, partOfSpeech string) {
+	switch partOfSpeech {
+	case "noun", "adjective", "numeral":
+		for _, d := range l.decliner.DeclineNoun(lemma) {
+			l.add(d.Form, Analysis{Lemma: lemma, PartOfSpeech: partOfSpeech, Case: d.Case, Number: d.Number})
+		}
+	case "verb":
+		for _, c := range l.conjugator.ConjugateVerb(lemma) {
+			// Negative and compound forms are several words
+			if strings.Contains(c.Form, " ") {
+				continue
+			}
+			l.add(c.Form, verbAnalysis(lemma, c))
+		}
+	default:
+		l.add(lemma, Analysis{Lemma: lemma, PartOfSpeech: partOfSpeech})
+	}
+}
+
+func (l *Lemmatizer) add(form string, a Analysis) {
+	l.forms[form] = append(l.forms[form], a)
+}
+
+func verbAnalysis(lemma string, c Conjugation) Analysis {
+	return Analysis{
+		Lemma:        lemma,
+		PartOfSpeech: "verb",
+		Mood:         c.Mood,
+		Tense:        c.Tense,
+		Voice:        c.Voice,
+		Person:       c.Person,
+		Variant:      c.Variant,
+	}
+}
+
+// Analyze returns the readings of word, most likely first. Readings from
+// the lexicon are returned if there are any, then those of a compound
+// whose parts are in it, and otherwise the guessed ones. A word with no
+// reading returns nil.
+func (l *Lemmatizer) Analyze(word string) []Analysis {
+	word = strings.ToLower(strings.TrimSpace(word))
+	if analyses := l.known(word); len(analyses) > 0 {
+		return analyses
+	}
+	parts := splitCompound(word, l.compoundModifier, l.compoundHead)
+	if analyses := compoundAnalyses(word, parts, l.known); len(analyses) > 0 {
+		return analyses
+	}
+	var analyses []Analysis
+	for _, split := range splitClitics(word) {
+		analyses = append(analyses, l.guess(split.host, split.clitics)...)
+	}
+	return analyses
+}
+
+// known returns the lexicon readings of a lower-case word, without
+// guessing
+func (l *Lemmatizer) known(word string) []Analysis {
+	var analyses []Analysis
+	for _, split := range splitClitics(word) {
+		analyses = append(analyses, l.lookup(split.host, split.clitics)...)
+	}
+	return analyses
+}
+
+// Lemmas returns the distinct lemmas of the readings of word, most likely
+// first
+func (l *Lemmatizer) Lemmas(word string) []string {
+	return lemmasOf(l.Analyze(word))
+}
+
+func lemmasOf(analyses []Analysis) []string {
+	var lemmas []string
+	seen := make(map[string]bool)
+	for _, a := range analyses {
+		if !seen[a.Lemma] {
+			seen[a.Lemma] = true
+			lemmas = append(lemmas, a.Lemma)
+		}
+	}
+	return lemmas
+}
+
+// lookup returns the lexicon readings of host, a word without its clitics,
+// with or without a possessive suffix
+func (l *Lemmatizer) lookup(host string, clitics []string) []Analysis {
+	var analyses []Analysis
+	for _, a := range l.forms[host] {
+		analyses = append(analyses, withSuffixes(a, "", clitics))
+	}
+
+	for _, px := range possessiveSuffixes {
+		base, ok := strings.CutSuffix(host, px.suffix)
+		if !ok || base == "" {
+			continue
+		}
+		for _, form := range possessorForms(base) {
+			for _, a := range l.forms[form] {
+				// Only declined words take possessive suffixes
+				if a.Case != "" {
+					analyses = append(analyses, withSuffixes(a, px.person, clitics))
+				}
+			}
+		}
+	}
+
+	// The third person suffix is a long vowel and n after a case ending in
+	// a vowel: talossaan, taloaan, talokseen
+	runes := []rune(host)
+	if n := len(runes); n > 3 && runes[n-1] == 'n' && runes[n-2] == runes[n-3] && isFinnishVowel(runes[n-2]) {
+		for _, form := range possessorForms(string(runes[:n-2])) {
+			for _, a := range l.forms[form] {
+				if a.Case != "" && a.Case != CaseNominative && a.Case != CaseGenitive && a.Case != CaseAccusative {
+					analyses = append(analyses, withSuffixes(a, "3", clitics))
+				}
+			}
+		}
+	}
+	return analyses
+}
+
+// possessorForms returns the case forms a word stripped of its possessive
+// suffix may stand for. The suffix replaces the -n of the genitive and the
+// illative and the -t of the plural, and the case stem keeps the strong
+// grade: katuni is katu, kadun or kadut; taloksi becomes talokse-.
+func possessorForms(base string) []string {
+	forms := []string{base, base + "n", base + "t"}
+	if weak := weakGrade(base); weak != base {
+		forms = append(forms, weak+"n", weak+"t")
+	}
+	if stem, ok := strings.CutSuffix(base, "kse"); ok {
+		forms = append(forms, stem+"ksi")
+	}
+	return forms
+}
+
+func withSuffixes(a Analysis, possessive string, clitics []string) Analysis {
+	a.Possessive = possessive
+	if len(clitics) > 0 {
+		a.Clitics = append([]string(nil), clitics...)
+	}
+	return a
+}
+
+// possessiveSuffixes are the possessive suffixes other than the long vowel
+// and n of the third person
+var possessiveSuffixes = []struct {
+	suffix, person string
+}{
+	{"ni", "1sg"},
+	{"si", "2sg"},
+	{"mme", "1pl"},
+	{"nne", "2pl"},
+	{"nsa", "3"},
+	{"nsä", "3"},
+}
+
+// cliticParticles are the enclitic particles, which follow every other
+// ending. -s is only used after -ko and -pa: onkos, tulepas.
+var cliticParticles = []string{"kaan", "kään", "han", "hän", "kin", "ko", "kö", "pa", "pä", "s"}
+
+// maxClitics bounds the particles stripped from one word: onkohan
+const maxClitics = 2
+
+type cliticSplit struct {
+	host    string
+	clitics []string
+}
+
+// splitClitics returns the ways word may divide into a host and clitic
+// particles, the word itself first
+func splitClitics(word string) []cliticSplit {
+	splits := []cliticSplit{{host: word}}
+	for i := 0; i < len(splits); i++ {
+		split := splits[i]
+		if len(split.clitics) == maxClitics {
+			continue
+		}
+		for _, particle := range cliticParticles {
+			host, ok := strings.CutSuffix(split.host, particle)
+			if !ok || utf8.RuneCountInString(host) < 2 {
+				continue
+			}
+			if particle == "s" && (len(split.clitics) > 0 || !endsInAny(host, "ko", "kö", "pa", "pä")) {
+				continue
+			}
+			clitics := append([]string{particle}, split.clitics...)
+			splits = append(splits, cliticSplit{host: host, clitics: clitics})
+		}
+	}
+	return splits
+}
+
+func endsInAny(word string, suffixes ...string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(word, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// harmonic reports whether word keeps to back or to front vowels
+func harmonic(word string) bool {
+	return !strings.ContainsAny(word, "aou") || !strings.ContainsAny(word, "äöy")
+}
+
+// guess returns the readings of a word that is not in the lexicon, as a
+// noun first and then as a verb. The word itself is not guessed to be a
+// lemma, as every word is the nominative of some made-up noun.
+func (l *Lemmatizer) guess(host string, clitics []string) []Analysis {
+	var analyses []Analysis
+
+	seen := map[string]bool{host: true}
+	matched := make(map[string]bool)
+	for _, lemma := range nounCandidates(host) {
+		// The weak grade of a lemma that matched is the same word misread:
+		// helsingissä is helsinki, not helsingi
+		if seen[lemma] || matched[strongGrade(lemma)] {
+			continue
+		}
+		seen[lemma] = true
+		for _, d := range l.decliner.DeclineNoun(lemma) {
+			if d.Form == host {
+				a := Analysis{Lemma: lemma, PartOfSpeech: "noun", Case: d.Case, Number: d.Number, Guessed: true}
+				analyses = append(analyses, withSuffixes(a, "", clitics))
+				matched[lemma] = true
+			}
+		}
+	}
+
+	seen = map[string]bool{host: true}
+	for _, lemma := range verbCandidates(host) {
+		if seen[lemma] {
+			continue
+		}
+		seen[lemma] = true
+		for _, c := range l.conjugator.ConjugateVerb(lemma) {
+			if c.Form == host {
+				a := verbAnalysis(lemma, c)
+				a.Guessed = true
+				analyses = append(analyses, withSuffixes(a, "", clitics))
+			}
+		}
+	}
+	return analyses
+}
+
+// nounEndings are the case and number endings stripped to guess the lemma
+// of a noun, longest first
+var nounEndings = []string{
+	"iden", "seen", "ssa", "ssä", "sta", "stä", "lla", "llä", "lta", "ltä",
+	"lle", "ksi", "tta", "ttä", "hin", "jen", "ten", "den", "na", "nä",
+	"ta", "tä", "ja", "jä", "en", "in", "n", "t", "a", "ä",
+}
+
+// stemEndings turn the end of a case stem into the end of a lemma: the
+// plural kaloi- of kala, the e-stem ove- of ovi, naise- of nainen
+var stemEndings = []struct {
+	stem, lemma string
+}{
+	{"kse", "s"},
+	{"ksi", "s"},
+	{"se", "nen"},
+	{"si", "nen"},
+	{"me", "n"},
+	{"mi", "n"},
+	{"ee", "e"},
+	{"", ""},
+	{"i", ""},
+	{"i", "a"},
+	{"i", "ä"},
+	{"oi", "a"},
+	{"öi", "ä"},
+	{"ei", "i"},
+	{"e", "i"},
+}
+
+// nounCandidates returns the lemmas a noun form may belong to, most likely
+// first. A lemma in the strong grade comes before the same lemma in the
+// weak grade, as case stems are weakened: kadulla is katu rather than kadu.
+func nounCandidates(word string) []string {
+	var candidates []string
+	for _, ending := range nounEndings {
+		stem, ok := strings.CutSuffix(word, ending)
+		if !ok || utf8.RuneCountInString(stem) < 2 {
+			continue
+		}
+		// The illative lengthens the stem vowel: taloon
+		if ending == "n" && endsInLongVowel(stem) {
+			stem = dropLastRune(stem)
+		}
+		for _, e := range stemEndings {
+			root, ok := strings.CutSuffix(stem, e.stem)
+			if !ok || root == "" {
+				continue
+			}
+			lemma := root + e.lemma
+			// Lemmas end in a vowel unless a stem ending says otherwise,
+			// and do not mix back and front vowels
+			if !isFinnishVowel([]rune(lemma)[len([]rune(lemma))-1]) && e.lemma == "" || !harmonic(lemma) {
+				continue
+			}
+			// A vowel comes before -nen (rakennuksessa is not rakennuknen),
+			// and a long -ee is the stem of a word in -e (huoneessa is not
+			// huonee), but for short words like tee
+			if e.lemma == "nen" && !isFinnishVowel([]rune(root)[len([]rune(root))-1]) ||
+				e.lemma == "" && strings.HasSuffix(lemma, "ee") && utf8.RuneCountInString(lemma) > 3 {
+				continue
+			}
+			candidates = append(candidates, strongGrade(lemma), lemma)
+		}
+	}
+	return candidates
+}
+
+// verbEndings are the person endings stripped to guess the infinitive of a
+// verb; the empty ending is the third person singular
+var verbEndings = []string{"mme", "tte", "vat", "vät", "n", "t", ""}
+
+// verbCandidates returns the infinitives a verb form may belong to, most
+// likely first. Only verbs that keep their stem in the infinitive (puhua,
+// ottaa) are guessed; the other types are left to the lexicon.
+func verbCandidates(word string) []string {
+	var candidates []string
+	for _, ending := range verbEndings {
+		stem, ok := strings.CutSuffix(word, ending)
+		if !ok || utf8.RuneCountInString(stem) < 2 {
+			continue
+		}
+		// The third person lengthens the stem vowel: puhuu
+		if ending == "" {
+			if !endsInLongVowel(stem) {
+				continue
+			}
+			stem = dropLastRune(stem)
+		}
+		// The conditional and the past add -isi and -i: puhuisin, puhuin
+		for _, marker := range []string{"", "isi", "i"} {
+			root, ok := strings.CutSuffix(stem, marker)
+			if !ok || utf8.RuneCountInString(root) < 2 {
+				continue
+			}
+			if !harmonic(root) {
+				continue
+			}
+			a := harmonize("a", nounUsesBackVowels(root))
+			candidates = append(candidates, strongGrade(root)+a, root+a)
+		}
+	}
+	return candidates
+}
+
+// pronounCases are the cases of the personal pronoun forms, in order
+var pronounCases = []Case{
+	CaseNominative, CaseGenitive, CasePartitive, CaseAccusative,
+	CaseInessive, CaseElative, CaseIllative, CaseAdessive,
+	CaseAblative, CaseAllative, CaseEssive, CaseTranslative,
+}
+
+// personalPronouns lists the forms of the personal pronouns, which do not
+// follow the noun types
+var personalPronouns = map[string]struct {
+	number Number
+	forms  []string
+}{
+	"minä": {NumberSingular, []string{"minä", "minun", "minua", "minut", "minussa", "minusta", "minuun", "minulla", "minulta", "minulle", "minuna", "minuksi"}},
+	"sinä": {NumberSingular, []string{"sinä", "sinun", "sinua", "sinut", "sinussa", "sinusta", "sinuun", "sinulla", "sinulta", "sinulle", "sinuna", "sinuksi"}},
+	"hän":  {NumberSingular, []string{"hän", "hänen", "häntä", "hänet", "hänessä", "hänestä", "häneen", "hänellä", "häneltä", "hänelle", "hänenä", "häneksi"}},
+	"me":   {NumberPlural, []string{"me", "meidän", "meitä", "meidät", "meissä", "meistä", "meihin", "meillä", "meiltä", "meille", "meinä", "meiksi"}},
+	"te":   {NumberPlural, []string{"te", "teidän", "teitä", "teidät", "teissä", "teistä", "teihin", "teillä", "teiltä", "teille", "teinä", "teiksi"}},
+	"he":   {NumberPlural, []string{"he", "heidän", "heitä", "heidät", "heissä", "heistä", "heihin", "heillä", "heiltä", "heille", "heinä", "heiksi"}},
+}
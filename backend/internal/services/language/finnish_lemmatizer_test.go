@@ -0,0 +1,100 @@
+package language
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLemmatizerLexiconWords(t *testing.T) {
+	lemmatizer := NewLemmatizer()
+
+	tests := []struct {
+		word string
+		want Analysis
+	}{
+		{"talossa", Analysis{Lemma: "talo", PartOfSpeech: "noun", Case: CaseInessive, Number: NumberSingular}},
+		{"kaduilla", Analysis{Lemma: "katu", PartOfSpeech: "noun", Case: CaseAdessive, Number: NumberPlural}},
+		{"pöydällä", Analysis{Lemma: "pöytä", PartOfSpeech: "noun", Case: CaseAdessive, Number: NumberSingular}},
+		{"ihmisten", Analysis{Lemma: "ihminen", PartOfSpeech: "noun", Case: CaseGenitive, Number: NumberPlural}},
+		{"vedessä", Analysis{Lemma: "vesi", PartOfSpeech: "noun", Case: CaseInessive, Number: NumberSingular}},
+		{"uudessa", Analysis{Lemma: "uusi", PartOfSpeech: "adjective", Case: CaseInessive, Number: NumberSingular}},
+		{"minulle", Analysis{Lemma: "minä", PartOfSpeech: "pronoun", Case: CaseAllative, Number: NumberSingular}},
+		{"on", Analysis{Lemma: "olla", PartOfSpeech: "verb", Mood: MoodIndicative, Tense: TensePresent, Voice: VoiceActive, Person: "3sg"}},
+		{"kirjoitan", Analysis{Lemma: "kirjoittaa", PartOfSpeech: "verb", Mood: MoodIndicative, Tense: TensePresent, Voice: VoiceActive, Person: "1sg"}},
+		{"menimme", Analysis{Lemma: "mennä", PartOfSpeech: "verb", Mood: MoodIndicative, Tense: TensePast, Voice: VoiceActive, Person: "1pl"}},
+		{"lukisin", Analysis{Lemma: "lukea", PartOfSpeech: "verb", Mood: MoodConditional, Tense: TensePresent, Voice: VoiceActive, Person: "1sg"}},
+		{"en", Analysis{Lemma: "ei", PartOfSpeech: "verb", Person: "1sg"}},
+		{"talossani", Analysis{Lemma: "talo", PartOfSpeech: "noun", Case: CaseInessive, Number: NumberSingular, Possessive: "1sg"}},
+		{"talossaan", Analysis{Lemma: "talo", PartOfSpeech: "noun", Case: CaseInessive, Number: NumberSingular, Possessive: "3"}},
+		{"taloonsa", Analysis{Lemma: "talo", PartOfSpeech: "noun", Case: CaseIllative, Number: NumberSingular, Possessive: "3"}},
+		{"taloksesi", Analysis{Lemma: "talo", PartOfSpeech: "noun", Case: CaseTranslative, Number: NumberSingular, Possessive: "2sg"}},
+		{"kätesi", Analysis{Lemma: "käsi", PartOfSpeech: "noun", Case: CaseGenitive, Number: NumberSingular, Possessive: "2sg"}},
+		{"autossakin", Analysis{Lemma: "auto", PartOfSpeech: "noun", Case: CaseInessive, Number: NumberSingular, Clitics: []string{"kin"}}},
+		{"onkohan", Analysis{Lemma: "olla", PartOfSpeech: "verb", Mood: MoodIndicative, Tense: TensePresent, Voice: VoiceActive, Person: "3sg", Clitics: []string{"ko", "han"}}},
+		{"onkos", Analysis{Lemma: "olla", PartOfSpeech: "verb", Mood: MoodIndicative, Tense: TensePresent, Voice: VoiceActive, Person: "3sg", Clitics: []string{"ko", "s"}}},
+		{"Talossanikin", Analysis{Lemma: "talo", PartOfSpeech: "noun", Case: CaseInessive, Number: NumberSingular, Possessive: "1sg", Clitics: []string{"kin"}}},
+	}
+
+	for _, tt := range tests {
+		analyses := lemmatizer.Analyze(tt.word)
+		if len(analyses) == 0 {
+			t.Errorf("Analyze(%q) found nothing", tt.word)
+			continue
+		}
+		if got := analyses[0]; !sameAnalysis(got, tt.want) {
+			t.Errorf("Analyze(%q)[0] = %+v, want %+v", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestLemmatizerGuessesUnknownWords(t *testing.T) {
+	lemmatizer := NewLemmatizer()
+
+	tests := []struct {
+		word   string
+		lemma  string
+		reason string
+	}{
+		{"pihalla", "piha", "noun in -a"},
+		{"laukussa", "laukku", "strong grade restored"},
+		{"rakennuksessa", "rakennus", "-kse stem"},
+		{"huoneessa", "huone", "-ee stem"},
+		{"pyöriä", "pyörä", "plural partitive"},
+	}
+
+	for _, tt := range tests {
+		analyses := lemmatizer.Analyze(tt.word)
+		// The analyzer looks up the first two
+		if lemmas := lemmasOf(analyses); !slices.Contains(lemmas[:min(len(lemmas), 2)], tt.lemma) {
+			t.Errorf("%s: Lemmas(%q) = %v, want %q among the first two", tt.reason, tt.word, lemmas, tt.lemma)
+		}
+		for _, a := range analyses {
+			if !a.Guessed {
+				t.Errorf("%q: reading %+v not marked as guessed", tt.word, a)
+			}
+		}
+	}
+
+	// Neither the weak grade of a lemma nor impossible stems are guessed
+	if lemmas := lemmatizer.Lemmas("Helsingissä"); len(lemmas) > 2 || lemmas[0] != "helsinki" {
+		t.Errorf("Lemmas(Helsingissä) = %v, want helsinki and at most one other", lemmas)
+	}
+	for word, lemma := range map[string]string{"rakennuksessa": "rakennus", "huoneessa": "huone", "Tampereella": "tampere"} {
+		if lemmas := lemmatizer.Lemmas(word); !slices.Equal(lemmas, []string{lemma}) {
+			t.Errorf("Lemmas(%q) = %v, want only %q", word, lemmas, lemma)
+		}
+	}
+
+	if analyses := lemmatizer.Analyze("xyzzy"); analyses != nil {
+		t.Errorf("Analyze(xyzzy) = %+v, want no reading", analyses)
+	}
+}
+
+func sameAnalysis(a, b Analysis) bool {
+	return a.Lemma == b.Lemma && a.PartOfSpeech == b.PartOfSpeech && a.Case == b.Case &&
+		a.Number == b.Number && a.Mood == b.Mood && a.Tense == b.Tense && a.Voice == b.Voice &&
+		a.Person == b.Person && a.Possessive == b.Possessive && a.Definiteness == b.Definiteness &&
+		a.Gender == b.Gender && a.Degree == b.Degree &&
+		strings.Join(a.Clitics, "+") == strings.Join(b.Clitics, "+") && a.Guessed == b.Guessed
+}
@@ -0,0 +1,323 @@
+package language
+
+import "strings"
+
+// persons lists the persons of finite forms in paradigm order
+var persons = []string{"1sg", "2sg", "3sg", "1pl", "2pl", "3pl"}
+
+// negationVerbs are the forms of the negation verb for each person
+var negationVerbs = []string{"en", "et", "ei", "emme", "ette", "eivät"}
+
+// harmonize turns the back vowels of a suffix written with a, o and u into
+// ä, ö and y for front-vowel verbs
+func harmonize(suffix string, back bool) string {
+	if back {
+		return suffix
+	}
+	return strings.NewReplacer("a", "ä", "o", "ö", "u", "y").Replace(suffix)
+}
+
+// connegative returns the form that follows the negation verb in the
+// present: otan → en ota, teen → en tee
+func (vc *VerbConjugator) connegative(v verb) string {
+	if v.conjugatesLikeType1() {
+		return weakGrade(v.stem)
+	}
+	return v.stem
+}
+
+// participleStem returns the past active participle without -ut/-yt,
+// which the potential shares: puhun-, syön-, tull-, halunn-
+func (vc *VerbConjugator) participleStem(v verb) string {
+	if stem, ok := irregularParticipleStems[v.infinitive]; ok {
+		return stem
+	}
+	root := []rune(v.infinitive)
+	root = root[:len(root)-2]
+
+	switch v.verbType {
+	case Type1:
+		return v.stem + "n" // puhu-nut
+	case Type2:
+		return string(root) + "n" // syö-nyt, teh-nyt
+	case Type3:
+		// The n assimilates to the stem consonant: tul-lut, nous-sut
+		return string(root) + string(root[len(root)-1])
+	default:
+		return string(root) + "nn" // halu-nnut, tarvi-nnut
+	}
+}
+
+// pastParticiple returns the past active participle in the singular and
+// plural: puhunut, puhuneet. It forms the past negative and the perfect.
+func (vc *VerbConjugator) pastParticiple(v verb) (singular, plural string) {
+	stem := vc.participleStem(v)
+	return stem + harmonize("ut", v.back), stem + "eet"
+}
+
+// imperativeStem returns the stem of the -k- imperative: puhu-koon,
+// teh-köön, tul-koon, halut-koon
+func (vc *VerbConjugator) imperativeStem(v verb) string {
+	runes := []rune(v.infinitive)
+	switch {
+	case v.verbType == Type1:
+		return v.stem
+	case v.verbType == Type2 || v.verbType == Type3:
+		return string(runes[:len(runes)-2])
+	default:
+		return string(runes[:len(runes)-1])
+	}
+}
+
+// potentialStem returns the stem of the potential mood: puhune-, tulle-
+func (vc *VerbConjugator) potentialStem(v verb) string {
+	if stem, ok := irregularPotentialStems[v.infinitive]; ok {
+		return stem
+	}
+	return vc.participleStem(v) + "e"
+}
+
+// conjugateImperative conjugates the imperative. The second person
+// singular is the bare weak stem; there is no first person singular.
+func (vc *VerbConjugator) conjugateImperative(v verb) []Conjugation {
+	stem := vc.imperativeStem(v)
+	forms := map[string]string{
+		"2sg": vc.connegative(v),
+		"3sg": stem + harmonize("koon", v.back),
+		"1pl": stem + harmonize("kaamme", v.back),
+		"2pl": stem + harmonize("kaa", v.back),
+		"3pl": stem + harmonize("koot", v.back),
+	}
+	return vc.imperativeForms(active(MoodImperative, TensePresent), forms)
+}
+
+func (vc *VerbConjugator) imperativeForms(tense Conjugation, forms map[string]string) []Conjugation {
+	var conjugations []Conjugation
+	for _, person := range persons[1:] {
+		c := tense
+		c.Person = person
+		c.Form = forms[person]
+		conjugations = append(conjugations, c)
+	}
+	return conjugations
+}
+
+// conjugatePotential conjugates the potential: puhunen, puhunee. Harmony
+// follows the stem, which for olla is lienevät.
+func (vc *VerbConjugator) conjugatePotential(v verb) []Conjugation {
+	stem := vc.potentialStem(v)
+	vat := vc.vowel(strings.ContainsAny(stem, "aou"), "vat", "vät")
+	return vc.personForms(active(MoodPotential, TensePresent), stem, stem+"e", stem+vat)
+}
+
+// negated returns the six persons of a negative tense: the negation verb
+// followed by singular or plural
+func (vc *VerbConjugator) negated(tense Conjugation, singular, plural string) []Conjugation {
+	tense.Polarity = PolarityNegative
+	conjugations := make([]Conjugation, len(persons))
+	for i, person := range persons {
+		c := tense
+		c.Person = person
+		form := singular
+		if i >= 3 {
+			form = plural
+		}
+		c.Form = negationVerbs[i] + " " + form
+		conjugations[i] = c
+	}
+	return conjugations
+}
+
+// negativeForms returns the negatives of the simple active tenses
+func (vc *VerbConjugator) negativeForms(v verb) []Conjugation {
+	connegative := vc.connegative(v)
+	participle, participlePlural := vc.pastParticiple(v)
+	conditional := vc.conditionalStem(v)
+	potential := vc.potentialStem(v)
+
+	var conjugations []Conjugation
+	conjugations = append(conjugations, vc.negated(active(MoodIndicative, TensePresent), connegative, connegative)...)
+	conjugations = append(conjugations, vc.negated(active(MoodIndicative, TensePast), participle, participlePlural)...)
+	conjugations = append(conjugations, vc.negated(active(MoodConditional, TensePresent), conditional, conditional)...)
+
+	// The imperative negates with älä and a connegative in -ko
+	ko := vc.imperativeStem(v) + harmonize("ko", v.back)
+	imperative := active(MoodImperative, TensePresent)
+	imperative.Polarity = PolarityNegative
+	conjugations = append(conjugations, vc.imperativeForms(imperative, map[string]string{
+		"2sg": "älä " + connegative,
+		"3sg": "älköön " + ko,
+		"1pl": "älkäämme " + ko,
+		"2pl": "älkää " + ko,
+		"3pl": "älkööt " + ko,
+	})...)
+
+	conjugations = append(conjugations, vc.negated(active(MoodPotential, TensePresent), potential, potential)...)
+	return conjugations
+}
+
+// compoundForms returns the tenses built with olla and the past
+// participle, in the affirmative and the negative: olen puhunut,
+// en ole puhunut
+func (vc *VerbConjugator) compoundForms(v verb) []Conjugation {
+	olla := vc.analyze("olla")
+	auxiliaries := vc.simpleForms(olla)
+	participle, participlePlural := vc.pastParticiple(v)
+	ollut, olleet := vc.pastParticiple(olla)
+
+	compounds := []struct {
+		tense     Conjugation
+		auxiliary Conjugation // the simple tense of olla it is built on
+		negative  [2]string   // olla after the negation verb
+	}{
+		{active(MoodIndicative, TensePerfect), active(MoodIndicative, TensePresent), [2]string{vc.connegative(olla), vc.connegative(olla)}},
+		{active(MoodIndicative, TensePluperfect), active(MoodIndicative, TensePast), [2]string{ollut, olleet}},
+		{active(MoodConditional, TensePerfect), active(MoodConditional, TensePresent), [2]string{vc.conditionalStem(olla), vc.conditionalStem(olla)}},
+		{active(MoodPotential, TensePerfect), active(MoodPotential, TensePresent), [2]string{vc.potentialStem(olla), vc.potentialStem(olla)}},
+	}
+
+	var conjugations []Conjugation
+	for _, compound := range compounds {
+		var affirmative []Conjugation
+		for _, aux := range auxiliaries {
+			if aux.Mood != compound.auxiliary.Mood || aux.Tense != compound.auxiliary.Tense {
+				continue
+			}
+			c := compound.tense
+			c.Person = aux.Person
+			c.Form = aux.Form + " " + participle
+			if strings.HasSuffix(c.Person, "pl") {
+				c.Form = aux.Form + " " + participlePlural
+			}
+			affirmative = append(affirmative, c)
+		}
+		conjugations = append(conjugations, affirmative...)
+		conjugations = append(conjugations, vc.negated(compound.tense,
+			compound.negative[0]+" "+participle, compound.negative[1]+" "+participlePlural)...)
+	}
+	return conjugations
+}
+
+// passiveStem returns the stem of the passive past and participles:
+// puhutt-, syöt-, tult-
+func (vc *VerbConjugator) passiveStem(v verb) string {
+	runes := []rune(vc.passiveConnegative(v))
+	if v.verbType == Type2 || v.verbType == Type3 {
+		return string(runes[:len(runes)-2]) + "t" // syö-tiin, tul-tiin
+	}
+	return string(runes[:len(runes)-1]) + "t" // puhut-tiin, halut-tiin
+}
+
+// passiveConnegative returns the present passive without its final -an:
+// (ei) puhuta, oteta, syödä, tulla
+func (vc *VerbConjugator) passiveConnegative(v verb) string {
+	if v.verbType == Type1 {
+		// The weak stem, with a final a or ä turned into e: ottaa → oteta
+		stem := weakGrade(v.stem)
+		if last := lastRune(stem); last == "a" || last == "ä" {
+			stem = dropLastRune(stem) + "e"
+		}
+		return stem + harmonize("ta", v.back)
+	}
+	return v.infinitive
+}
+
+// passiveForms returns the passive of every tense and mood, which has a
+// single impersonal form: puhutaan, ei puhuta, on puhuttu
+func (vc *VerbConjugator) passiveForms(v verb) []Conjugation {
+	stem := vc.passiveStem(v)
+	connegative := vc.passiveConnegative(v)
+	participle := stem + harmonize("u", v.back)
+	conditional := stem + harmonize("aisi", v.back)
+	potential := stem + harmonize("ane", v.back)
+
+	forms := []struct {
+		mood                  Mood
+		tense                 Tense
+		affirmative, negative string
+	}{
+		{MoodIndicative, TensePresent, connegative + harmonize("an", v.back), "ei " + connegative},
+		{MoodIndicative, TensePast, stem + "iin", "ei " + participle},
+		{MoodIndicative, TensePerfect, "on " + participle, "ei ole " + participle},
+		{MoodIndicative, TensePluperfect, "oli " + participle, "ei ollut " + participle},
+		{MoodConditional, TensePresent, conditional + "in", "ei " + conditional},
+		{MoodConditional, TensePerfect, "olisi " + participle, "ei olisi " + participle},
+		{MoodImperative, TensePresent, stem + harmonize("akoon", v.back), "älköön " + stem + harmonize("ako", v.back)},
+		{MoodPotential, TensePresent, potential + "en", "ei " + potential},
+		{MoodPotential, TensePerfect, "lienee " + participle, "ei liene " + participle},
+	}
+
+	var conjugations []Conjugation
+	for _, f := range forms {
+		c := Conjugation{Mood: f.mood, Tense: f.tense, Voice: VoicePassive, Polarity: PolarityAffirmative, Form: f.affirmative}
+		conjugations = append(conjugations, c)
+		c.Polarity = PolarityNegative
+		c.Form = f.negative
+		conjugations = append(conjugations, c)
+	}
+	return conjugations
+}
+
+// nonFiniteForms returns the infinitives and participles
+func (vc *VerbConjugator) nonFiniteForms(v verb) []Conjugation {
+	// The second infinitive: type 1 verbs add -e- to the stem, turning a
+	// final e into i (lukiessa); the others replace the final vowel of the
+	// infinitive (syödessä, tullessa)
+	second := dropLastRune(v.infinitive)
+	if v.verbType == Type1 {
+		second = v.stem
+		if lastRune(second) == "e" {
+			second = dropLastRune(second) + "i"
+		}
+	}
+	// The third infinitive and the agent participle build on the strong
+	// present stem: tekemässä, tekemä
+	ma := v.stem + harmonize("ma", v.back)
+	passive := vc.passiveStem(v)
+	participle, _ := vc.pastParticiple(v)
+
+	forms := []struct {
+		mood    Mood
+		tense   Tense
+		voice   Voice
+		variant string
+		form    string
+	}{
+		{MoodInfinitive, "", VoiceActive, "first", v.infinitive},
+		{MoodInfinitive, "", VoiceActive, "second inessive", second + harmonize("essa", v.back)},
+		{MoodInfinitive, "", VoiceActive, "second instructive", second + "en"},
+		{MoodInfinitive, "", VoicePassive, "second inessive", passive + harmonize("aessa", v.back)},
+		{MoodInfinitive, "", VoiceActive, "third inessive", ma + harmonize("ssa", v.back)},
+		{MoodInfinitive, "", VoiceActive, "third elative", ma + harmonize("sta", v.back)},
+		{MoodInfinitive, "", VoiceActive, "third illative", ma + harmonize("an", v.back)},
+		{MoodInfinitive, "", VoiceActive, "third adessive", ma + harmonize("lla", v.back)},
+		{MoodInfinitive, "", VoiceActive, "third abessive", ma + harmonize("tta", v.back)},
+		{MoodInfinitive, "", VoiceActive, "fourth", v.stem + "minen"},
+		{MoodParticiple, TensePresent, VoiceActive, "", v.stem + harmonize("va", v.back)},
+		{MoodParticiple, TensePast, VoiceActive, "", participle},
+		{MoodParticiple, TensePresent, VoicePassive, "", passive + harmonize("ava", v.back)},
+		{MoodParticiple, TensePast, VoicePassive, "", passive + harmonize("u", v.back)},
+		{MoodParticiple, "", VoiceActive, "agent", ma},
+	}
+
+	var conjugations []Conjugation
+	for _, f := range forms {
+		conjugations = append(conjugations, Conjugation{
+			Mood:     f.mood,
+			Tense:    f.tense,
+			Voice:    f.voice,
+			Polarity: PolarityAffirmative,
+			Variant:  f.variant,
+			Form:     f.form,
+		})
+	}
+	// The negative participle: puhumaton, syömätön
+	conjugations = append(conjugations, Conjugation{
+		Mood:     MoodParticiple,
+		Voice:    VoiceActive,
+		Polarity: PolarityNegative,
+		Variant:  "negative",
+		Form:     ma + harmonize("ton", v.back),
+	})
+	return conjugations
+}
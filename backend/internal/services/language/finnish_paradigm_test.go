@@ -0,0 +1,116 @@
+package language
+
+import "testing"
+
+func TestFullParadigm(t *testing.T) {
+	conjugations := NewVerbConjugator().ConjugateVerb("ottaa")
+
+	negative := func(c Conjugation) Conjugation {
+		c.Polarity = PolarityNegative
+		return c
+	}
+	passive := func(c Conjugation) Conjugation {
+		c.Voice = VoicePassive
+		return c
+	}
+
+	tests := []struct {
+		category Conjugation
+		want     string
+	}{
+		{negative(active(MoodIndicative, TensePresent)), "en ota et ota ei ota emme ota ette ota eivät ota"},
+		{negative(active(MoodIndicative, TensePast)), "en ottanut et ottanut ei ottanut emme ottaneet ette ottaneet eivät ottaneet"},
+		{active(MoodIndicative, TensePerfect), "olen ottanut olet ottanut on ottanut olemme ottaneet olette ottaneet ovat ottaneet"},
+		{negative(active(MoodIndicative, TensePerfect)), "en ole ottanut et ole ottanut ei ole ottanut emme ole ottaneet ette ole ottaneet eivät ole ottaneet"},
+		{active(MoodIndicative, TensePluperfect), "olin ottanut olit ottanut oli ottanut olimme ottaneet olitte ottaneet olivat ottaneet"},
+		{negative(active(MoodIndicative, TensePluperfect)), "en ollut ottanut et ollut ottanut ei ollut ottanut emme olleet ottaneet ette olleet ottaneet eivät olleet ottaneet"},
+		{negative(active(MoodConditional, TensePresent)), "en ottaisi et ottaisi ei ottaisi emme ottaisi ette ottaisi eivät ottaisi"},
+		{active(MoodConditional, TensePerfect), "olisin ottanut olisit ottanut olisi ottanut olisimme ottaneet olisitte ottaneet olisivat ottaneet"},
+		{active(MoodImperative, TensePresent), "ota ottakoon ottakaamme ottakaa ottakoot"},
+		{negative(active(MoodImperative, TensePresent)), "älä ota älköön ottako älkäämme ottako älkää ottako älkööt ottako"},
+		{active(MoodPotential, TensePresent), "ottanen ottanet ottanee ottanemme ottanette ottanevat"},
+		{active(MoodPotential, TensePerfect), "lienen ottanut lienet ottanut lienee ottanut lienemme ottaneet lienette ottaneet lienevät ottaneet"},
+		{passive(active(MoodIndicative, TensePresent)), "otetaan"},
+		{negative(passive(active(MoodIndicative, TensePresent))), "ei oteta"},
+		{passive(active(MoodIndicative, TensePast)), "otettiin"},
+		{negative(passive(active(MoodIndicative, TensePast))), "ei otettu"},
+		{passive(active(MoodIndicative, TensePerfect)), "on otettu"},
+		{passive(active(MoodConditional, TensePresent)), "otettaisiin"},
+		{passive(active(MoodImperative, TensePresent)), "otettakoon"},
+		{passive(active(MoodPotential, TensePresent)), "otettaneen"},
+		{Conjugation{Mood: MoodParticiple, Tense: TensePresent, Voice: VoiceActive, Polarity: PolarityAffirmative}, "ottava"},
+		{Conjugation{Mood: MoodParticiple, Tense: TensePast, Voice: VoicePassive, Polarity: PolarityAffirmative}, "otettu"},
+		{Conjugation{Mood: MoodParticiple, Voice: VoiceActive, Polarity: PolarityAffirmative, Variant: "agent"}, "ottama"},
+		{Conjugation{Mood: MoodInfinitive, Voice: VoiceActive, Polarity: PolarityAffirmative, Variant: "second inessive"}, "ottaessa"},
+		{Conjugation{Mood: MoodInfinitive, Voice: VoiceActive, Polarity: PolarityAffirmative, Variant: "third illative"}, "ottamaan"},
+		{Conjugation{Mood: MoodInfinitive, Voice: VoiceActive, Polarity: PolarityAffirmative, Variant: "fourth"}, "ottaminen"},
+	}
+
+	for _, tt := range tests {
+		c := tt.category
+		if got := formsOf(conjugations, c); got != tt.want {
+			t.Errorf("%s %s %s %s %s:\n got %s\nwant %s", c.Mood, c.Tense, c.Voice, c.Polarity, c.Variant, got, tt.want)
+		}
+	}
+}
+
+// TestParadigmByType checks one form of each category for a verb of
+// every type, where the stems differ most
+func TestParadigmByType(t *testing.T) {
+	conjugator := NewVerbConjugator()
+
+	tests := []struct {
+		infinitive string
+		want       []string // negative present 1sg, past participle, passive present, passive past, 3sg imperative, 3sg potential, third inessive
+	}{
+		{"puhua", []string{"en puhu", "puhunut", "puhutaan", "puhuttiin", "puhukoon", "puhunee", "puhumassa"}},
+		{"tehdä", []string{"en tee", "tehnyt", "tehdään", "tehtiin", "tehköön", "tehnee", "tekemässä"}},
+		{"tulla", []string{"en tule", "tullut", "tullaan", "tultiin", "tulkoon", "tullee", "tulemassa"}},
+		{"nousta", []string{"en nouse", "noussut", "noustaan", "noustiin", "nouskoon", "noussee", "nousemassa"}},
+		{"haluta", []string{"en halua", "halunnut", "halutaan", "haluttiin", "halutkoon", "halunnee", "haluamassa"}},
+		{"tarvita", []string{"en tarvitse", "tarvinnut", "tarvitaan", "tarvittiin", "tarvitkoon", "tarvinnee", "tarvitsemassa"}},
+		{"vanheta", []string{"en vanhene", "vanhennut", "vanhetaan", "vanhettiin", "vanhetkoon", "vanhennee", "vanhenemassa"}},
+		{"olla", []string{"en ole", "ollut", "ollaan", "oltiin", "olkoon", "lienee", "olemassa"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.infinitive, func(t *testing.T) {
+			forms := map[string]string{}
+			for _, c := range conjugator.ConjugateVerb(tt.infinitive) {
+				key := string(c.Mood) + " " + string(c.Tense) + " " + string(c.Voice) + " " + string(c.Polarity) + " " + c.Person + " " + c.Variant
+				if _, ok := forms[key]; !ok {
+					forms[key] = c.Form
+				}
+			}
+			keys := []string{
+				"indicative present active negative 1sg ",
+				"participle past active affirmative  ",
+				"indicative present passive affirmative  ",
+				"indicative past passive affirmative  ",
+				"imperative present active affirmative 3sg ",
+				"potential present active affirmative 3sg ",
+				"infinitive  active affirmative  third inessive",
+			}
+			for i, key := range keys {
+				if forms[key] != tt.want[i] {
+					t.Errorf("%s (%s) = %q, want %q", tt.infinitive, key, forms[key], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestConjugationMetadata(t *testing.T) {
+	for _, c := range NewVerbConjugator().ConjugateVerb("lukea") {
+		if c.Mood == "" || c.Voice == "" || c.Polarity == "" || c.Form == "" {
+			t.Errorf("incomplete conjugation %+v", c)
+		}
+		finite := c.Mood != MoodInfinitive && c.Mood != MoodParticiple
+		if finite && c.Voice == VoiceActive && c.Person == "" {
+			t.Errorf("finite active form without a person: %+v", c)
+		}
+		if !finite && c.Person != "" {
+			t.Errorf("non-finite form with a person: %+v", c)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package language
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/BachirKhiati/lexia/internal/config"
+	"github.com/BachirKhiati/lexia/internal/models"
+	"github.com/BachirKhiati/lexia/internal/services/wiktionary"
+)
+
+// ErrUnsupportedLanguage is returned for languages that are not registered
+var ErrUnsupportedLanguage = errors.New("unsupported language")
+
+// Language is the support for one target language
+type Language interface {
+	// Code is the ISO 639-1 code the language is registered under: "fi"
+	Code() string
+	// Name is the name used in requests and stored data: "finnish"
+	Name() string
+	// Tokenize returns the distinct words of a text
+	Tokenize(text string) []Token
+	// Sentences splits a text into its sentences
+	Sentences(text string) []string
+	// Analyze returns the readings of a word form, most likely first, or
+	// nil when it has none
+	Analyze(word string) []Analysis
+	// GuessPartOfSpeech returns the part of speech a lemma looks like, or
+	// "" when its form does not tell
+	GuessPartOfSpeech(lemma string) string
+	// SplitCompound returns the parts of a compound word, or nil if it is
+	// not one
+	SplitCompound(word string) []CompoundPart
+	// Inflect adds the inflection tables of the analyzed lemma to response
+	Inflect(response *models.AnalyzerResponse)
+	// Wiktionary says where definitions of the language are looked up
+	Wiktionary() wiktionary.Edition
+}
+
+// Builtin returns every language implemented by this package
+func Builtin() []Language {
+	return []Language{NewFinnish(), NewSwedish(), NewEnglish()}
+}
+
+// Registry holds the supported languages by ISO code
+type Registry struct {
+	languages   map[string]Language
+	defaultCode string
+}
+
+// NewRegistry registers the builtin languages listed in
+// cfg.SupportedLanguages, by code or name. Unknown languages and a default
+// that is not supported are configuration errors.
+func NewRegistry(cfg config.LanguageConfig) (*Registry, error) {
+	builtin := &Registry{languages: make(map[string]Language)}
+	for _, l := range Builtin() {
+		builtin.Register(l)
+	}
+
+	r := &Registry{languages: make(map[string]Language)}
+	for _, name := range cfg.SupportedLanguages {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		l, ok := builtin.Lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("%w in configuration: %q", ErrUnsupportedLanguage, name)
+		}
+		r.Register(l)
+	}
+
+	l, ok := r.Lookup(cfg.DefaultLanguage)
+	if !ok {
+		return nil, fmt.Errorf("default language %q is not a supported language", cfg.DefaultLanguage)
+	}
+	r.defaultCode = l.Code()
+	return r, nil
+}
+
+// NewDefaultRegistry registers every builtin language, with Finnish as the
+// default
+func NewDefaultRegistry() *Registry {
+	r := &Registry{languages: make(map[string]Language), defaultCode: "fi"}
+	for _, l := range Builtin() {
+		r.Register(l)
+	}
+	return r
+}
+
+// Register adds a language, replacing the one with the same code
+func (r *Registry) Register(l Language) {
+	r.languages[l.Code()] = l
+}
+
+// Lookup finds a language by ISO code or name, ignoring case
+func (r *Registry) Lookup(codeOrName string) (Language, bool) {
+	key := strings.ToLower(strings.TrimSpace(codeOrName))
+	if l, ok := r.languages[key]; ok {
+		return l, true
+	}
+	for _, l := range r.languages {
+		if l.Name() == key {
+			return l, true
+		}
+	}
+	return nil, false
+}
+
+// Resolve returns the language a request asks for: the default language
+// if it names none, and ErrUnsupportedLanguage if it is not registered
+func (r *Registry) Resolve(language string) (Language, error) {
+	if strings.TrimSpace(language) == "" {
+		return r.languages[r.defaultCode], nil
+	}
+	l, ok := r.Lookup(language)
+	if !ok {
+		names := make([]string, 0, len(r.languages))
+		for _, l := range r.Languages() {
+			names = append(names, l.Name())
+		}
+		return nil, fmt.Errorf("%w: %q (supported: %s)", ErrUnsupportedLanguage, language, strings.Join(names, ", "))
+	}
+	return l, nil
+}
+
+// Languages returns the registered languages ordered by code
+func (r *Registry) Languages() []Language {
+	languages := make([]Language, 0, len(r.languages))
+	for _, l := range r.languages {
+		languages = append(languages, l)
+	}
+	sort.Slice(languages, func(i, j int) bool { return languages[i].Code() < languages[j].Code() })
+	return languages
+}
@@ -0,0 +1,79 @@
+package language
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/BachirKhiati/lexia/internal/config"
+)
+
+func TestRegistryLookup(t *testing.T) {
+	r := NewDefaultRegistry()
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"fi", "fi"},
+		{"finnish", "fi"},
+		{"Finnish", "fi"},
+		{" EN ", "en"},
+		{"english", "en"},
+		{"sv", "sv"},
+		{"Swedish", "sv"},
+	}
+
+	for _, tt := range tests {
+		l, ok := r.Lookup(tt.key)
+		if !ok || l.Code() != tt.want {
+			t.Errorf("Lookup(%q) = %v, %v, want %s", tt.key, l, ok, tt.want)
+		}
+	}
+	if _, ok := r.Lookup("klingon"); ok {
+		t.Error("Lookup(klingon) found a language")
+	}
+}
+
+func TestRegistryResolve(t *testing.T) {
+	r, err := NewRegistry(config.LanguageConfig{DefaultLanguage: "finnish", SupportedLanguages: []string{"finnish"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l, err := r.Resolve(""); err != nil || l.Name() != "finnish" {
+		t.Errorf("Resolve(\"\") = %v, %v, want the default language", l, err)
+	}
+	if l, err := r.Resolve("fi"); err != nil || l.Name() != "finnish" {
+		t.Errorf("Resolve(fi) = %v, %v", l, err)
+	}
+	// English is builtin but not configured
+	if _, err := r.Resolve("english"); !errors.Is(err, ErrUnsupportedLanguage) {
+		t.Errorf("Resolve(english) error = %v, want ErrUnsupportedLanguage", err)
+	}
+}
+
+func TestNewRegistryRejectsBadConfiguration(t *testing.T) {
+	tests := []config.LanguageConfig{
+		{DefaultLanguage: "finnish", SupportedLanguages: []string{"finnish", "klingon"}},
+		{DefaultLanguage: "english", SupportedLanguages: []string{"finnish"}},
+		{DefaultLanguage: "", SupportedLanguages: []string{"finnish"}},
+	}
+
+	for _, cfg := range tests {
+		if _, err := NewRegistry(cfg); err == nil {
+			t.Errorf("NewRegistry(%+v) succeeded", cfg)
+		}
+	}
+}
+
+func TestRegistryLanguagesByCode(t *testing.T) {
+	r, err := NewRegistry(config.LanguageConfig{DefaultLanguage: "fi", SupportedLanguages: []string{"finnish", " english", ""}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	languages := r.Languages()
+	if len(languages) != 2 || languages[0].Code() != "en" || languages[1].Code() != "fi" {
+		t.Errorf("Languages() = %v", languages)
+	}
+}
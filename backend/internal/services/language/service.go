@@ -0,0 +1,303 @@
+package language
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/BachirKhiati/lexia/internal/models"
+	"github.com/BachirKhiati/lexia/internal/services/ai"
+	"github.com/BachirKhiati/lexia/internal/services/wiktionary"
+)
+
+// AIService is the interface for AI-based word definitions
+type AIService interface {
+	GetWordDefinition(ctx context.Context, word string, language string) (*ai.DefinitionResult, error)
+}
+
+// SenseChooser is implemented by AI services that can tell which sense of a
+// word is meant in a sentence
+type SenseChooser interface {
+	ChooseSense(ctx context.Context, word, sentence, language string, senses []string) (int, error)
+}
+
+const (
+	// maxSenseCandidates is how many of the best-ranked senses the AI chooses from
+	maxSenseCandidates = 5
+	// maxOtherSenses is how many alternative senses are returned
+	maxOtherSenses = 3
+	// maxLemmaLookups bounds the Wiktionary lookups of one word: its likely
+	// lemmas, then the word itself
+	maxLemmaLookups = 3
+)
+
+// Speaker returns the URL of a spoken clip of text
+type Speaker interface {
+	AudioURL(ctx context.Context, text string, language string) (string, error)
+}
+
+// audioTimeout bounds the synthesis of all clips of one analysis
+const audioTimeout = 10 * time.Second
+
+// Service handles language-specific operations
+type Service struct {
+	languages         *Registry
+	wiktionaryService *wiktionary.Service
+	aiService         AIService
+	speaker           Speaker
+}
+
+func NewService(wiktionaryService *wiktionary.Service, aiService AIService) *Service {
+	return &Service{
+		languages:         NewDefaultRegistry(),
+		wiktionaryService: wiktionaryService,
+		aiService:         aiService,
+	}
+}
+
+// SetLanguages replaces the supported languages, which are all the builtin
+// languages by default
+func (s *Service) SetLanguages(languages *Registry) {
+	s.languages = languages
+}
+
+// Languages returns the supported languages
+func (s *Service) Languages() *Registry {
+	return s.languages
+}
+
+// SetSpeaker enables audio for analyzed words and their examples
+func (s *Service) SetSpeaker(speaker Speaker) {
+	s.speaker = speaker
+}
+
+// AnalyzeWord performs deep analysis of a word. sentence is the text the
+// word was found in, if known; it decides which sense is returned.
+// Inflected words are looked up by their lemma: talossani → talo. An
+// unsupported language is an ErrUnsupportedLanguage.
+func (s *Service) AnalyzeWord(ctx context.Context, word string, language string, sentence string) (*models.AnalyzerResponse, error) {
+	return s.analyzeWord(ctx, word, language, sentence, true)
+}
+
+// analyzeWord analyzes a word, with the audio of the word and its examples
+// if withAudio is set
+func (s *Service) analyzeWord(ctx context.Context, word string, language string, sentence string, withAudio bool) (*models.AnalyzerResponse, error) {
+	lang, err := s.languages.Resolve(language)
+	if err != nil {
+		return nil, err
+	}
+	language = lang.Name()
+
+	// Initialize response
+	response := &models.AnalyzerResponse{
+		Word:      word,
+		Lemma:     word,
+		Context:   sentence,
+		InSynapse: false,
+	}
+
+	readings := lang.Analyze(word)
+	compound := lang.SplitCompound(word)
+	lookups := lemmaLookups(word, readings)
+	// A lemma from the lexicon is certain enough to ask the AI about
+	if len(readings) > 0 && !readings[0].Guessed {
+		response.Lemma = readings[0].Lemma
+	}
+
+	// Track if we successfully got a definition
+	gotDefinition := false
+
+	// Try to fetch real definition from Wiktionary first, trying the likely
+	// lemmas in turn
+	if s.wiktionaryService != nil {
+		for _, lemma := range lookups {
+			if ctx.Err() != nil {
+				break
+			}
+			lookupCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+			senses, err := s.wiktionaryService.Senses(lookupCtx, lemma, lang.Wiktionary())
+			cancel()
+			if err != nil {
+				// Wiktionary failed - log but continue with the next lemma or the AI fallback
+				log.Printf("⚠️  Wiktionary lookup failed for '%s': %v", lemma, err)
+				continue
+			}
+
+			// Successfully got definitions from Wiktionary; use the one that fits the sentence
+			response.Lemma = lemma
+			ranked := s.rankSenses(ctx, lemma, sentence, language, senses)
+			best := ranked[0]
+			response.Definition = best.Definition
+			response.PartOfSpeech = best.PartOfSpeech
+			if len(best.Examples) > 0 {
+				response.Examples = best.Examples[:min(len(best.Examples), 2)]
+			}
+			for _, other := range ranked[1:min(len(ranked), maxOtherSenses+1)] {
+				response.OtherSenses = append(response.OtherSenses, other.Definition)
+			}
+			gotDefinition = true
+			log.Printf("✅ Fetched definition from Wiktionary for '%s': %s", lemma, best.Definition)
+			break
+		}
+	}
+
+	// If Wiktionary failed, try AI as fallback
+	var aiErr error
+	if !gotDefinition && s.aiService != nil {
+		result, err := s.aiService.GetWordDefinition(ctx, response.Lemma, language)
+		if err == nil && result.Definition != "" {
+			response.Definition = result.Definition
+			response.PartOfSpeech = result.PartOfSpeech
+			if len(result.Examples) > 0 {
+				response.Examples = result.Examples
+			}
+			response.PromptVersion = result.PromptVersion
+			gotDefinition = true
+			log.Printf("✅ Fetched definition from AI for '%s': %s", word, result.Definition)
+		} else {
+			// AI also failed
+			log.Printf("⚠️  AI lookup also failed for '%s': %v", word, err)
+			aiErr = err
+		}
+	}
+
+	// If both Wiktionary and AI failed, return error instead of placeholder
+	// data; the AI error is kept so that callers can tell a spent quota
+	if !gotDefinition {
+		if aiErr != nil {
+			return nil, fmt.Errorf("unable to find definition for '%s' - both Wiktionary and AI sources failed: %w", word, aiErr)
+		}
+		return nil, fmt.Errorf("unable to find definition for '%s' - both Wiktionary and AI sources failed", word)
+	}
+
+	// Nouns and adjectives keep their part of speech; otherwise the form of
+	// the lemma can tell, e.g. a Finnish verb infinitive
+	if !isNominal(response.PartOfSpeech) {
+		if pos := lang.GuessPartOfSpeech(response.Lemma); pos != "" {
+			response.PartOfSpeech = pos
+		}
+	}
+	lang.Inflect(response)
+	response.Morphology = morphologyOf(response.Lemma, readings)
+	response.Compound = compoundOf(compound)
+
+	if withAudio {
+		s.addAudio(ctx, response, language)
+	}
+
+	return response, nil
+}
+
+// addAudio fills in the clips of the word and its examples. Speech is
+// optional: clips that cannot be made are left out.
+func (s *Service) addAudio(ctx context.Context, response *models.AnalyzerResponse, language string) {
+	if s.speaker == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, audioTimeout)
+	defer cancel()
+
+	url, err := s.speaker.AudioURL(ctx, response.Word, language)
+	if err != nil {
+		log.Printf("⚠️  No audio for '%s': %v", response.Word, err)
+		return
+	}
+	response.AudioURL = url
+
+	if len(response.Examples) == 0 {
+		return
+	}
+	urls := make([]string, len(response.Examples))
+	for i, example := range response.Examples {
+		if urls[i], err = s.speaker.AudioURL(ctx, example, language); err != nil {
+			log.Printf("⚠️  No audio for example of '%s': %v", response.Word, err)
+		}
+	}
+	response.ExampleAudioURLs = urls
+}
+
+// rankSenses orders Wiktionary senses by fit with the sentence, best first.
+// The lexical ranking is refined by the AI service when it can choose
+// senses; its pick among the top candidates moves to the front.
+func (s *Service) rankSenses(ctx context.Context, word, sentence, language string, senses []wiktionary.Sense) []wiktionary.Sense {
+	ranked := wiktionary.RankSenses(senses, word, sentence)
+	chooser, ok := s.aiService.(SenseChooser)
+	if !ok || sentence == "" || len(ranked) < 2 {
+		return ranked
+	}
+
+	candidates := ranked[:min(len(ranked), maxSenseCandidates)]
+	definitions := make([]string, len(candidates))
+	for i, sense := range candidates {
+		definitions[i] = sense.PartOfSpeech + ": " + sense.Definition
+	}
+	choice, err := chooser.ChooseSense(ctx, word, sentence, language, definitions)
+	if err != nil {
+		log.Printf("⚠️  AI sense selection failed for '%s': %v (using lexical ranking)", word, err)
+		return ranked
+	}
+
+	chosen := ranked[choice]
+	copy(ranked[1:choice+1], ranked[:choice])
+	ranked[0] = chosen
+	return ranked
+}
+
+// lemmaLookups returns the words to look up for word, most likely first:
+// the lemmas of its readings, then the word itself. A compound is looked
+// up whole, as its readings have it for lemma: työpaikassa as työpaikka.
+func lemmaLookups(word string, readings []Analysis) []string {
+	lemmas := lemmasOf(readings)
+	lookups := lemmas[:min(len(lemmas), maxLemmaLookups-1)]
+	if !slices.Contains(lookups, word) {
+		lookups = append(lookups, word)
+	}
+	return lookups
+}
+
+// morphologyOf returns the readings of the analyzed word as a form of lemma
+func morphologyOf(lemma string, readings []Analysis) []models.MorphAnalysis {
+	var morphology []models.MorphAnalysis
+	for _, r := range readings {
+		if r.Lemma != lemma {
+			continue
+		}
+		morphology = append(morphology, models.MorphAnalysis{
+			Lemma:        r.Lemma,
+			PartOfSpeech: r.PartOfSpeech,
+			Case:         string(r.Case),
+			Number:       string(r.Number),
+			Mood:         string(r.Mood),
+			Tense:        string(r.Tense),
+			Voice:        string(r.Voice),
+			Person:       r.Person,
+			Variant:      r.Variant,
+			Definiteness: string(r.Definiteness),
+			Gender:       string(r.Gender),
+			Degree:       string(r.Degree),
+			Possessive:   r.Possessive,
+			Clitics:      r.Clitics,
+			Guessed:      r.Guessed,
+		})
+	}
+	return morphology
+}
+
+// compoundOf returns the parts of a compound for the response
+func compoundOf(parts []CompoundPart) []models.CompoundPart {
+	var compound []models.CompoundPart
+	for _, p := range parts {
+		compound = append(compound, models.CompoundPart{Form: p.Form, Lemma: p.Lemma})
+	}
+	return compound
+}
+
+// isNominal reports whether a dictionary part of speech ("Noun",
+// "adjective", "proper noun") is declined rather than conjugated
+func isNominal(partOfSpeech string) bool {
+	pos := strings.ToLower(partOfSpeech)
+	return strings.Contains(pos, "noun") || strings.Contains(pos, "adjective")
+}
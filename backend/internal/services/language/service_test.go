@@ -0,0 +1,217 @@
+package language
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+
+	"github.com/BachirKhiati/lexia/internal/services/wiktionary"
+)
+
+// fakeSpeaker makes up a URL for each clip, failing for the texts in fail
+type fakeSpeaker struct {
+	fail map[string]bool
+}
+
+func (s fakeSpeaker) AudioURL(ctx context.Context, text string, language string) (string, error) {
+	if s.fail[text] {
+		return "", errors.New("synthesis failed")
+	}
+	return "/audio/" + language + "/" + text, nil
+}
+
+func TestAnalyzeWordAddsAudio(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"fi": [{"partOfSpeech": "Noun", "definitions": [{"definition": "house", "examples": ["Talo on iso.", "Asun talossa."]}]}]}`))
+	}))
+	defer server.Close()
+
+	service := NewService(wiktionary.NewServiceWithBaseURL(server.URL), nil)
+	service.SetSpeaker(fakeSpeaker{fail: map[string]bool{"Asun talossa.": true}})
+
+	response, err := service.AnalyzeWord(context.Background(), "talo", "finnish", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.AudioURL != "/audio/finnish/talo" {
+		t.Errorf("audio url = %q", response.AudioURL)
+	}
+	// A failed example keeps its place so the URLs line up with the examples
+	want := []string{"/audio/finnish/Talo on iso.", ""}
+	if len(response.ExampleAudioURLs) != len(want) || response.ExampleAudioURLs[0] != want[0] || response.ExampleAudioURLs[1] != want[1] {
+		t.Errorf("example audio = %q, want %q", response.ExampleAudioURLs, want)
+	}
+}
+
+func TestAnalyzeWordWithoutSpeech(t *testing.T) {
+	service := NewService(newWiktionaryServer(t, nil), nil)
+	service.SetSpeaker(fakeSpeaker{fail: map[string]bool{"talo": true}})
+
+	response, err := service.AnalyzeWord(context.Background(), "talo", "finnish", "")
+	if err != nil {
+		t.Fatalf("analysis failed with speech unavailable: %v", err)
+	}
+	if response.AudioURL != "" || response.ExampleAudioURLs != nil {
+		t.Errorf("audio = %q, %q", response.AudioURL, response.ExampleAudioURLs)
+	}
+}
+
+func TestAnalyzeWordDeclinesNouns(t *testing.T) {
+	service := NewService(newWiktionaryServer(t, nil), nil)
+
+	// kala ends like a verb, but the dictionary knows it is a noun
+	response, err := service.AnalyzeWord(context.Background(), "kala", "finnish", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Conjugations != nil {
+		t.Errorf("noun was conjugated: %v", response.Conjugations)
+	}
+	if response.KotusType != 9 {
+		t.Errorf("kotus type = %d, want 9", response.KotusType)
+	}
+	found := false
+	for _, d := range response.Declensions {
+		if d.Case == "inessive" && d.Number == "plural" {
+			found = d.Form == "kaloissa"
+		}
+	}
+	if !found {
+		t.Errorf("no plural inessive kaloissa in %v", response.Declensions)
+	}
+}
+
+func TestAnalyzeWordLooksUpLemma(t *testing.T) {
+	var looked []string
+	service := NewService(newWiktionaryServer(t, func(word string) { looked = append(looked, word) }, "pyöri"), nil)
+
+	response, err := service.AnalyzeWord(context.Background(), "talossani", "finnish", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Word != "talossani" || response.Lemma != "talo" || response.Definition != "meaning of talo" {
+		t.Errorf("word %q, lemma %q, definition %q", response.Word, response.Lemma, response.Definition)
+	}
+	if len(response.Morphology) == 0 || response.Morphology[0].Case != "inessive" || response.Morphology[0].Possessive != "1sg" {
+		t.Errorf("morphology = %+v", response.Morphology)
+	}
+	if response.KotusType != 1 {
+		t.Errorf("declined %q as type %d", response.Lemma, response.KotusType)
+	}
+
+	// Guessed lemmas are tried in turn: pyöriä is not pyöri but pyörä
+	looked = nil
+	response, err = service.AnalyzeWord(context.Background(), "pyöriä", "finnish", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Lemma != "pyörä" || len(looked) != 2 {
+		t.Errorf("lemma %q after looking up %q", response.Lemma, looked)
+	}
+	if len(response.Morphology) == 0 || !response.Morphology[0].Guessed {
+		t.Errorf("morphology = %+v", response.Morphology)
+	}
+}
+
+func TestAnalyzeWordSplitsCompounds(t *testing.T) {
+	var looked []string
+	service := NewService(newWiktionaryServer(t, func(word string) { looked = append(looked, word) }), nil)
+
+	response, err := service.AnalyzeWord(context.Background(), "työpaikassa", "finnish", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The compound is looked up whole, with its head in the nominative
+	if response.Lemma != "työpaikka" || len(looked) != 1 {
+		t.Errorf("lemma %q after looking up %q", response.Lemma, looked)
+	}
+	if len(response.Compound) != 2 || response.Compound[0].Lemma != "työ" || response.Compound[1].Form != "paikassa" || response.Compound[1].Lemma != "paikka" {
+		t.Errorf("compound = %+v", response.Compound)
+	}
+
+	response, err = service.AnalyzeWord(context.Background(), "talo", "finnish", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Compound != nil {
+		t.Errorf("talo split into %+v", response.Compound)
+	}
+}
+
+func TestAnalyzeWordUsesLanguageOfRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"fi": [{"partOfSpeech": "Noun", "definitions": [{"definition": "a Finnish sense"}]}],
+			"en": [{"partOfSpeech": "Noun", "definitions": [{"definition": "an English sense"}]}]}`))
+	}))
+	defer server.Close()
+	service := NewService(wiktionary.NewServiceWithBaseURL(server.URL), nil)
+
+	// English words are read from the English section and not declined
+	response, err := service.AnalyzeWord(context.Background(), "talo", "en", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Definition != "an English sense" || response.Declensions != nil || response.Morphology != nil {
+		t.Errorf("definition %q, declensions %v, morphology %v", response.Definition, response.Declensions, response.Morphology)
+	}
+
+	// Without a language the default, Finnish, is analyzed
+	response, err = service.AnalyzeWord(context.Background(), "talo", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Definition != "a Finnish sense" || response.KotusType != 1 {
+		t.Errorf("definition %q, kotus type %d", response.Definition, response.KotusType)
+	}
+
+	if _, err := service.AnalyzeWord(context.Background(), "talo", "klingon", ""); !errors.Is(err, ErrUnsupportedLanguage) {
+		t.Errorf("error = %v, want ErrUnsupportedLanguage", err)
+	}
+}
+
+func TestAnalyzeWordInSwedish(t *testing.T) {
+	sources := map[string]string{
+		"bil":    "==Svenska==\n===Substantiv===\n# [[motorfordon]] för persontransport\n#: ''Vi tog '''bilen'''.''",
+		"skriva": "==Svenska==\n===Verb===\n# [[forma]] [[bokstav|bokstäver]]",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		source, ok := sources[path.Base(r.URL.Path)]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		body, _ := json.Marshal(map[string]string{"source": source})
+		w.Write(body)
+	}))
+	defer server.Close()
+	service := NewService(wiktionary.NewServiceWithBaseURL(server.URL), nil)
+
+	response, err := service.AnalyzeWord(context.Background(), "bilarna", "sv", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Lemma != "bil" || response.Definition != "motorfordon för persontransport" || response.Gender != "common" {
+		t.Errorf("lemma %q, definition %q, gender %q", response.Lemma, response.Definition, response.Gender)
+	}
+	if len(response.Morphology) == 0 || response.Morphology[0].Definiteness != "definite" || response.Morphology[0].Number != "plural" {
+		t.Errorf("morphology = %+v", response.Morphology)
+	}
+	if len(response.Declensions) != 8 || response.Declensions[3].Form != "bilarna" {
+		t.Errorf("declensions = %+v", response.Declensions)
+	}
+
+	response, err = service.AnalyzeWord(context.Background(), "skrev", "swedish", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Lemma != "skriva" || response.PartOfSpeech != "Verb" || len(response.Conjugations) == 0 {
+		t.Fatalf("lemma %q, part of speech %q, %d conjugations", response.Lemma, response.PartOfSpeech, len(response.Conjugations))
+	}
+	if c := response.Conjugations[2]; c.Tense != "past" || c.Form != "skrev" || c.Language != "swedish" {
+		t.Errorf("past = %+v", c)
+	}
+}
@@ -0,0 +1,149 @@
+package language
+
+import (
+	"strings"
+
+	"github.com/BachirKhiati/lexia/internal/models"
+	"github.com/BachirKhiati/lexia/internal/services/wiktionary"
+)
+
+// Swedish analyzes Swedish words with its own lemmatizer, declines nouns,
+// inflects adjectives and conjugates verbs
+type Swedish struct {
+	conjugator *SwedishConjugator
+	decliner   *SwedishDecliner
+	lemmatizer *SwedishLemmatizer
+	segmenter  *Segmenter
+}
+
+func NewSwedish() *Swedish {
+	return &Swedish{
+		conjugator: NewSwedishConjugator(),
+		decliner:   NewSwedishDecliner(),
+		lemmatizer: NewSwedishLemmatizer(),
+		segmenter:  NewSegmenter(swedishAbbreviations...),
+	}
+}
+
+func (s *Swedish) Code() string { return "sv" }
+
+func (s *Swedish) Name() string { return "swedish" }
+
+func (s *Swedish) Tokenize(text string) []Token {
+	return s.segmenter.Tokenize(text)
+}
+
+func (s *Swedish) Sentences(text string) []string {
+	return s.segmenter.Sentences(text)
+}
+
+func (s *Swedish) Analyze(word string) []Analysis {
+	return s.lemmatizer.Analyze(word)
+}
+
+// GuessPartOfSpeech does not guess: most infinitives end in -a like the
+// nouns of the first class
+func (s *Swedish) GuessPartOfSpeech(lemma string) string { return "" }
+
+func (s *Swedish) SplitCompound(word string) []CompoundPart {
+	return s.lemmatizer.SplitCompound(word)
+}
+
+// Inflect declines nouns, inflects adjectives and conjugates verbs
+func (s *Swedish) Inflect(response *models.AnalyzerResponse) {
+	pos := strings.ToLower(response.PartOfSpeech)
+	switch {
+	case strings.Contains(pos, "adjective"):
+		response.Declensions = s.AdjectiveForms(response.Lemma)
+	case strings.Contains(pos, "noun") && !strings.Contains(pos, "pronoun"):
+		response.Declensions = s.Declensions(response.Lemma)
+		response.Gender = string(s.decliner.Noun(response.Lemma).Gender)
+	case pos == "verb":
+		response.Conjugations = s.Conjugations(response.Lemma)
+	}
+}
+
+// Wiktionary reads the Swedish section of the Swedish Wiktionary, which
+// only serves page sources
+func (s *Swedish) Wiktionary() wiktionary.Edition {
+	return wiktionary.Edition{
+		Code:          "sv",
+		Sections:      []string{"Svenska"},
+		Wikitext:      true,
+		PartsOfSpeech: swedishPartsOfSpeech,
+	}
+}
+
+// swedishPartsOfSpeech names the part-of-speech headings of sv.wiktionary
+var swedishPartsOfSpeech = map[string]string{
+	"Substantiv":   "Noun",
+	"Verb":         "Verb",
+	"Adjektiv":     "Adjective",
+	"Adverb":       "Adverb",
+	"Pronomen":     "Pronoun",
+	"Preposition":  "Preposition",
+	"Konjunktion":  "Conjunction",
+	"Subjunktion":  "Conjunction",
+	"Räkneord":     "Numeral",
+	"Interjektion": "Interjection",
+	"Artikel":      "Article",
+	"Partikel":     "Particle",
+	"Egennamn":     "Proper noun",
+	"Förkortning":  "Abbreviation",
+}
+
+// Conjugations returns the paradigm of a Swedish verb
+func (s *Swedish) Conjugations(word string) []models.WordConjugation {
+	conjugations := s.conjugator.ConjugateVerb(word)
+
+	modelConjugations := make([]models.WordConjugation, len(conjugations))
+	for i, conj := range conjugations {
+		modelConjugations[i] = models.WordConjugation{
+			ID:       i + 1,
+			Mood:     string(conj.Mood),
+			Tense:    string(conj.Tense),
+			Voice:    string(conj.Voice),
+			Polarity: string(conj.Polarity),
+			Person:   conj.Person,
+			Variant:  conj.Variant,
+			Form:     conj.Form,
+			Language: s.Name(),
+		}
+	}
+	return modelConjugations
+}
+
+// Declensions returns the definite and indefinite, singular and plural
+// forms of a Swedish noun in the nominative and the genitive
+func (s *Swedish) Declensions(word string) []models.WordDeclension {
+	declensions := s.decliner.DeclineNoun(word)
+
+	modelDeclensions := make([]models.WordDeclension, len(declensions))
+	for i, d := range declensions {
+		modelDeclensions[i] = models.WordDeclension{
+			Case:         string(d.Case),
+			Number:       string(d.Number),
+			Definiteness: string(d.Definiteness),
+			Form:         d.Form,
+		}
+	}
+	return modelDeclensions
+}
+
+// AdjectiveForms returns the agreeing and compared forms of a Swedish
+// adjective
+func (s *Swedish) AdjectiveForms(word string) []models.WordDeclension {
+	forms := s.decliner.InflectAdjective(word)
+
+	modelDeclensions := make([]models.WordDeclension, len(forms))
+	for i, f := range forms {
+		modelDeclensions[i] = models.WordDeclension{
+			Number:       string(f.Number),
+			Definiteness: string(f.Definiteness),
+			Gender:       string(f.Gender),
+			Degree:       string(f.Degree),
+			Form:         f.Form,
+		}
+	}
+	return modelDeclensions
+}
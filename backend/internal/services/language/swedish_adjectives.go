@@ -0,0 +1,172 @@
+package language
+
+import (
+	"strings"
+)
+
+// Degree of comparison of an adjective
+type Degree string
+
+const (
+	DegreePositive    Degree = "positive"
+	DegreeComparative Degree = "comparative"
+	DegreeSuperlative Degree = "superlative"
+)
+
+// AdjectiveForm is one form of a Swedish adjective. The indefinite
+// positive agrees with its noun in gender and number: en stor bil, ett
+// stort hus, stora bilar. The definite form is the same for every noun: den
+// stora bilen, det stora huset.
+type AdjectiveForm struct {
+	Degree       Degree       `json:"degree"`
+	Gender       Gender       `json:"gender,omitempty"`
+	Number       Number       `json:"number,omitempty"`
+	Definiteness Definiteness `json:"definiteness,omitempty"`
+	Form         string       `json:"form"`
+}
+
+// swedishAdjective lists the forms of an adjective that do not follow the
+// rules; empty forms do
+type swedishAdjective struct {
+	neuter, plural, definite string
+	comparative, superlative string
+}
+
+// InflectAdjective returns the positive forms of an adjective, then its
+// comparative and its indefinite and definite superlative
+func (d *SwedishDecliner) InflectAdjective(word string) []AdjectiveForm {
+	word = strings.ToLower(word)
+	irregular := swedishIrregularAdjectives[word]
+
+	neuter := orDefault(irregular.neuter, swedishNeuter(word))
+	plural := orDefault(irregular.plural, swedishAdjectivePlural(word))
+	definite := orDefault(irregular.definite, plural)
+
+	comparative, superlative := irregular.comparative, irregular.superlative
+	definiteSuperlative := ""
+	switch {
+	case comparative != "":
+	case comparedWithMer(word):
+		comparative, superlative = "mer "+word, "mest "+word
+		definiteSuperlative = "mest " + plural
+	default:
+		stem := word
+		if hasUnstressedE(word) {
+			stem = dropUnstressedE(word) // enkel, enklare
+		}
+		comparative, superlative = stem+"are", stem+"ast"
+	}
+	if definiteSuperlative == "" {
+		// -ast takes -e, the short -st of the irregular ones -a: snabbaste, största
+		if strings.HasSuffix(superlative, "ast") {
+			definiteSuperlative = superlative + "e"
+		} else {
+			definiteSuperlative = superlative + "a"
+		}
+	}
+
+	return []AdjectiveForm{
+		{Degree: DegreePositive, Gender: GenderCommon, Number: NumberSingular, Definiteness: Indefinite, Form: word},
+		{Degree: DegreePositive, Gender: GenderNeuter, Number: NumberSingular, Definiteness: Indefinite, Form: neuter},
+		{Degree: DegreePositive, Number: NumberPlural, Definiteness: Indefinite, Form: plural},
+		{Degree: DegreePositive, Definiteness: Definite, Form: definite},
+		{Degree: DegreeComparative, Form: comparative},
+		{Degree: DegreeSuperlative, Definiteness: Indefinite, Form: superlative},
+		{Degree: DegreeSuperlative, Definiteness: Definite, Form: definiteSuperlative},
+	}
+}
+
+func orDefault(form, regular string) string {
+	if form != "" {
+		return form
+	}
+	return regular
+}
+
+// indeclinable reports whether an adjective takes no endings: those ending
+// in -a or -e and a few loans
+func indeclinable(word string) bool {
+	return endsInAny(word, "a", "e") || swedishIndeclinables[word]
+}
+
+// comparedWithMer reports whether an adjective is compared with mer and
+// mest rather than -are and -ast: the indeclinables, the participles and
+// the adjectives in -isk
+func comparedWithMer(word string) bool {
+	return indeclinable(word) || isSwedishParticiple(word) || endsInAny(word, "isk")
+}
+
+// isSwedishParticiple reports whether a word looks like a past participle
+// of the first group (älskad) or a present participle (spännande)
+func isSwedishParticiple(word string) bool {
+	return strings.HasSuffix(word, "ad") && swedishSyllables(word) > 1 || endsInAny(word, "ande", "ende")
+}
+
+// swedishNeuter returns the neuter singular: -t, which lengthens to -tt
+// after a long vowel and absorbs a final -d or -t
+func swedishNeuter(word string) string {
+	runes := []rune(word)
+	n := len(runes)
+	switch {
+	case indeclinable(word):
+		return word
+	case isSwedishParticiple(word):
+		return string(runes[:n-1]) + "t" // älskat
+	case hasUnstressedE(word) && strings.HasSuffix(word, "en"):
+		return string(runes[:n-1]) + "t" // öppet, moget
+	case endsInSwedishVowel(word):
+		return word + "tt" // nytt, blått
+	case strings.HasSuffix(word, "tt"), strings.HasSuffix(word, "t") && !isSwedishVowel(runes[n-2]):
+		return word // lätt, svart
+	case strings.HasSuffix(word, "t"):
+		return word + "t" // vitt
+	case strings.HasSuffix(word, "dd"):
+		return string(runes[:n-2]) + "tt" // klätt
+	case strings.HasSuffix(word, "d") && isSwedishVowel(runes[n-2]):
+		return string(runes[:n-1]) + "tt" // rött, glatt
+	case strings.HasSuffix(word, "d"):
+		return string(runes[:n-1]) + "t" // hårt
+	case strings.HasSuffix(word, "nn"):
+		return string(runes[:n-1]) + "t" // tunt
+	}
+	return word + "t"
+}
+
+// swedishAdjectivePlural returns the plural, which is also the definite
+// form: -a, or -e for the participles in -ad
+func swedishAdjectivePlural(word string) string {
+	switch {
+	case indeclinable(word):
+		return word
+	case strings.HasSuffix(word, "ad") && swedishSyllables(word) > 1:
+		return word + "e" // älskade
+	case hasUnstressedE(word):
+		return dropUnstressedE(word) + "a" // enkla, vackra
+	}
+	return word + "a"
+}
+
+// swedishIndeclinables are adjectives that keep one form
+var swedishIndeclinables = map[string]bool{
+	"gratis": true, "kul": true, "fel": true, "slut": true,
+}
+
+// swedishIrregularAdjectives lists stem changes: a dropped vowel, a doubled
+// consonant, umlaut in the comparative
+var swedishIrregularAdjectives = map[string]swedishAdjective{
+	"liten":  {neuter: "litet", plural: "små", definite: "lilla", comparative: "mindre", superlative: "minst"},
+	"gammal": {plural: "gamla", comparative: "äldre", superlative: "äldst"},
+	"god":    {comparative: "bättre", superlative: "bäst"},
+	"bra":    {comparative: "bättre", superlative: "bäst"},
+	"dålig":  {comparative: "sämre", superlative: "sämst"},
+	"ung":    {comparative: "yngre", superlative: "yngst"},
+	"stor":   {comparative: "större", superlative: "störst"},
+	"lång":   {comparative: "längre", superlative: "längst"},
+	"låg":    {comparative: "lägre", superlative: "lägst"},
+	"hög":    {comparative: "högre", superlative: "högst"},
+	"tung":   {comparative: "tyngre", superlative: "tyngst"},
+	"nära":   {comparative: "närmare", superlative: "närmast"},
+	"dum":    {neuter: "dumt", plural: "dumma", comparative: "dummare", superlative: "dummast"},
+	"tom":    {neuter: "tomt", plural: "tomma", comparative: "tommare", superlative: "tommast"},
+	"grym":   {neuter: "grymt", plural: "grymma", comparative: "grymmare", superlative: "grymmast"},
+}
@@ -0,0 +1,63 @@
+package language
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSwedishAdjectiveAgreement(t *testing.T) {
+	decliner := NewSwedishDecliner()
+
+	// common, neuter, plural, definite; comparative, superlative, definite
+	// superlative
+	tests := []struct {
+		word   string
+		want   string
+		reason string
+	}{
+		{"stor", "stor stort stora stora större störst största", "umlaut in the comparative"},
+		{"snabb", "snabb snabbt snabba snabba snabbare snabbast snabbaste", "regular"},
+		{"ny", "ny nytt nya nya nyare nyast nyaste", "-tt after a vowel"},
+		{"röd", "röd rött röda röda rödare rödast rödaste", "-d becomes -tt"},
+		{"svart", "svart svart svarta svarta svartare svartast svartaste", "-t absorbed"},
+		{"enkel", "enkel enkelt enkla enkla enklare enklast enklaste", "unstressed e dropped"},
+		{"öppen", "öppen öppet öppna öppna öppnare öppnast öppnaste", "-en becomes -et"},
+		{"liten", "liten litet små lilla mindre minst minsta", "suppletive"},
+		{"gammal", "gammal gammalt gamla gamla äldre äldst äldsta", "suppletive comparative"},
+		{"bra", "bra bra bra bra bättre bäst bästa", "indeclinable positive"},
+		{"praktisk", "praktisk praktiskt praktiska praktiska mer praktisk mest praktisk mest praktiska", "-isk compared with mer"},
+		{"älskad", "älskad älskat älskade älskade mer älskad mest älskad mest älskade", "participle"},
+	}
+
+	for _, tt := range tests {
+		var forms []string
+		for _, f := range decliner.InflectAdjective(tt.word) {
+			forms = append(forms, f.Form)
+		}
+		if got := strings.Join(forms, " "); got != tt.want {
+			t.Errorf("%s: %s:\n got %s\nwant %s", tt.reason, tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestSwedishAdjectiveFormsAreLabelled(t *testing.T) {
+	forms := NewSwedishDecliner().InflectAdjective("stor")
+
+	want := []AdjectiveForm{
+		{Degree: DegreePositive, Gender: GenderCommon, Number: NumberSingular, Definiteness: Indefinite, Form: "stor"},
+		{Degree: DegreePositive, Gender: GenderNeuter, Number: NumberSingular, Definiteness: Indefinite, Form: "stort"},
+		{Degree: DegreePositive, Number: NumberPlural, Definiteness: Indefinite, Form: "stora"},
+		{Degree: DegreePositive, Definiteness: Definite, Form: "stora"},
+		{Degree: DegreeComparative, Form: "större"},
+		{Degree: DegreeSuperlative, Definiteness: Indefinite, Form: "störst"},
+		{Degree: DegreeSuperlative, Definiteness: Definite, Form: "största"},
+	}
+	if len(forms) != len(want) {
+		t.Fatalf("got %d forms, want %d", len(forms), len(want))
+	}
+	for i := range want {
+		if forms[i] != want[i] {
+			t.Errorf("form %d = %+v, want %+v", i, forms[i], want[i])
+		}
+	}
+}
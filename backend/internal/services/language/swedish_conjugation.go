@@ -0,0 +1,314 @@
+package language
+
+import (
+	"strings"
+)
+
+// SwedishVerbGroup is the conjugation group of a Swedish verb
+type SwedishVerbGroup string
+
+const (
+	SwedishGroup1  SwedishVerbGroup = "1"  // -ar, -ade: tala
+	SwedishGroup2a SwedishVerbGroup = "2a" // -er, -de after a voiced consonant: stänga
+	SwedishGroup2b SwedishVerbGroup = "2b" // -er, -te after a voiceless consonant: köpa
+	SwedishGroup3  SwedishVerbGroup = "3"  // short verbs in another vowel: bo
+	SwedishGroup4  SwedishVerbGroup = "4"  // strong and irregular verbs: skriva
+)
+
+// TenseFuture is the Swedish future, ska with the infinitive
+const TenseFuture Tense = "future"
+
+// swedishPrincipalParts are the forms the paradigm of a verb is built from.
+// An empty participle means the verb has none, an intransitive verb has no
+// passive and a modal verb no imperative. A deponent verb has neither, nor
+// a present participle.
+type swedishPrincipalParts struct {
+	present, past, supine, participle string
+	intransitive, modal, deponent     bool
+}
+
+// SwedishConjugator conjugates Swedish verbs
+type SwedishConjugator struct{}
+
+func NewSwedishConjugator() *SwedishConjugator {
+	return &SwedishConjugator{}
+}
+
+// Group returns the conjugation group of an infinitive. The second group
+// cannot be told from the infinitive: it is known from swedishGroup2Verbs,
+// and other verbs in -a are taken to be of the first, which new verbs join.
+// A deponent verb is in the group of the verb without its -s.
+func (sc *SwedishConjugator) Group(infinitive string) SwedishVerbGroup {
+	infinitive = strings.ToLower(infinitive)
+	if swedishDeponentVerbs[infinitive] {
+		return sc.Group(strings.TrimSuffix(infinitive, "s"))
+	}
+	if _, ok := swedishStrongVerbs[infinitive]; ok {
+		return SwedishGroup4
+	}
+	switch {
+	case !strings.HasSuffix(infinitive, "a"):
+		return SwedishGroup3
+	case swedishGroup2Verbs[infinitive]:
+		if endsInAny(strings.TrimSuffix(infinitive, "a"), "k", "p", "s", "t", "x") {
+			return SwedishGroup2b
+		}
+		return SwedishGroup2a
+	}
+	return SwedishGroup1
+}
+
+// principalParts returns the present, past, supine and past participle.
+// A deponent verb takes the forms of the verb without its -s with -s added
+// in place of the -r of the present: finns, fanns, funnits; hoppas,
+// hoppades, hoppats.
+func (sc *SwedishConjugator) principalParts(infinitive string) swedishPrincipalParts {
+	if swedishDeponentVerbs[infinitive] {
+		base := strings.TrimSuffix(infinitive, "s")
+		parts := sc.principalParts(base)
+		return swedishPrincipalParts{
+			present:      sc.presentPassive(base, parts),
+			past:         parts.past + "s",
+			supine:       parts.supine + "s",
+			intransitive: true,
+			deponent:     true,
+		}
+	}
+	stem := strings.TrimSuffix(infinitive, "a")
+
+	switch sc.Group(infinitive) {
+	case SwedishGroup4:
+		return swedishStrongVerbs[infinitive]
+	case SwedishGroup3:
+		return swedishPrincipalParts{
+			present:    infinitive + "r",
+			past:       infinitive + "dde",
+			supine:     infinitive + "tt",
+			participle: infinitive + "dd",
+		}
+	case SwedishGroup2a, SwedishGroup2b:
+		return group2Parts(stem)
+	}
+	return swedishPrincipalParts{
+		present:    infinitive + "r",
+		past:       infinitive + "de",
+		supine:     infinitive + "t",
+		participle: infinitive + "d",
+	}
+}
+
+// group2Parts builds the forms of a second group verb from its stem. A stem
+// in -r takes no present ending (kör); -mm and -nn are simplified before
+// -de and -t (kände, känt), -d after a consonant becomes -t in the supine
+// (använt), and a stem in -t takes -te and -tt (mötte, mött).
+func group2Parts(stem string) swedishPrincipalParts {
+	present := stem + "er"
+	if strings.HasSuffix(stem, "r") {
+		present = stem
+	}
+
+	runes := []rune(stem)
+	n := len(runes)
+	last := runes[n-1]
+	single := stem
+	if n > 2 && runes[n-2] == last && (last == 'm' || last == 'n') {
+		single = string(runes[:n-1])
+	}
+
+	switch {
+	case endsInAny(stem, "k", "p", "s", "t", "x"):
+		return swedishPrincipalParts{present: present, past: stem + "te", supine: stem + "t", participle: stem + "t"}
+	case last == 'd' && n > 1 && !isSwedishVowel(runes[n-2]):
+		base := string(runes[:n-1])
+		return swedishPrincipalParts{present: present, past: stem + "e", supine: base + "t", participle: stem}
+	case last == 'd':
+		base := string(runes[:n-1])
+		return swedishPrincipalParts{present: present, past: stem + "de", supine: base + "tt", participle: stem + "d"}
+	}
+	return swedishPrincipalParts{present: present, past: single + "de", supine: single + "t", participle: single + "d"}
+}
+
+// ConjugateVerb returns the paradigm of a Swedish verb, which does not
+// inflect for person: the infinitive, present, past, supine and imperative,
+// the compound tenses built with ha, ska and skulle, the s-passive, and
+// the participles
+func (sc *SwedishConjugator) ConjugateVerb(infinitive string) []Conjugation {
+	infinitive = strings.ToLower(strings.TrimSpace(infinitive))
+	return sc.conjugate(infinitive, sc.principalParts(infinitive))
+}
+
+// conjugate returns the paradigm of infinitive built from parts
+func (sc *SwedishConjugator) conjugate(infinitive string, parts swedishPrincipalParts) []Conjugation {
+	forms := []Conjugation{
+		swedishForm(MoodInfinitive, "", VoiceActive, "", infinitive),
+		swedishForm(MoodIndicative, TensePresent, VoiceActive, "", parts.present),
+		swedishForm(MoodIndicative, TensePast, VoiceActive, "", parts.past),
+		swedishForm(MoodInfinitive, "", VoiceActive, "supine", parts.supine),
+	}
+	if !parts.modal && !parts.deponent {
+		forms = append(forms, swedishForm(MoodImperative, TensePresent, VoiceActive, "", swedishImperative(infinitive, parts)))
+	}
+	forms = append(forms,
+		swedishForm(MoodIndicative, TensePerfect, VoiceActive, "", "har "+parts.supine),
+		swedishForm(MoodIndicative, TensePluperfect, VoiceActive, "", "hade "+parts.supine),
+		swedishForm(MoodIndicative, TenseFuture, VoiceActive, "", "ska "+infinitive),
+		swedishForm(MoodConditional, TensePresent, VoiceActive, "", "skulle "+infinitive),
+	)
+
+	if !parts.intransitive {
+		forms = append(forms,
+			swedishForm(MoodInfinitive, "", VoicePassive, "", infinitive+"s"),
+			swedishForm(MoodIndicative, TensePresent, VoicePassive, "", sc.presentPassive(infinitive, parts)),
+			swedishForm(MoodIndicative, TensePast, VoicePassive, "", parts.past+"s"),
+			swedishForm(MoodInfinitive, "", VoicePassive, "supine", parts.supine+"s"),
+			swedishForm(MoodIndicative, TensePerfect, VoicePassive, "", "har "+parts.supine+"s"),
+		)
+	}
+
+	if !parts.deponent {
+		forms = append(forms, swedishForm(MoodParticiple, TensePresent, VoiceActive, "", swedishPresentParticiple(infinitive)))
+	}
+	if parts.participle != "" {
+		forms = append(forms, swedishForm(MoodParticiple, TensePast, VoicePassive, "", parts.participle))
+	}
+	return forms
+}
+
+func swedishForm(mood Mood, tense Tense, voice Voice, variant, form string) Conjugation {
+	return Conjugation{
+		Mood:     mood,
+		Tense:    tense,
+		Voice:    voice,
+		Polarity: PolarityAffirmative,
+		Variant:  variant,
+		Form:     form,
+	}
+}
+
+// swedishImperative drops the -a of the infinitive, and a final -mm is
+// simplified: köp, kom. Verbs whose present adds -r to the infinitive, as
+// in the first group and the short verbs, keep the infinitive: tala, bo.
+func swedishImperative(infinitive string, parts swedishPrincipalParts) string {
+	stem, ok := strings.CutSuffix(infinitive, "a")
+	if !ok || parts.present == infinitive+"r" {
+		return infinitive
+	}
+	if strings.HasSuffix(stem, "mm") {
+		stem = strings.TrimSuffix(stem, "m")
+	}
+	return stem
+}
+
+// presentPassive returns the present s-passive: the -r of the present gives
+// way to -s (talas, tas), -er is dropped before it (köps, skrivs) but kept
+// after another s (läses), and a present that ends in its stem takes -s
+// (körs, görs). As in the imperative, -mm is simplified: glöms.
+func (sc *SwedishConjugator) presentPassive(infinitive string, parts swedishPrincipalParts) string {
+	switch {
+	case parts.present == infinitive+"r":
+		return infinitive + "s" // talas, bos
+	case strings.HasSuffix(parts.present, "ser"):
+		return strings.TrimSuffix(parts.present, "r") + "s"
+	case strings.HasSuffix(parts.present, "mmer"):
+		return strings.TrimSuffix(parts.present, "mer") + "s"
+	case strings.HasSuffix(parts.present, "er"):
+		return strings.TrimSuffix(parts.present, "er") + "s"
+	case parts.present == strings.TrimSuffix(infinitive, "a"):
+		return parts.present + "s"
+	}
+	return strings.TrimSuffix(parts.present, "r") + "s" // tas, ses
+}
+
+// swedishPresentParticiple adds -nde to an infinitive in -a and -ende to
+// the short verbs: talande, skrivande, boende
+func swedishPresentParticiple(infinitive string) string {
+	if participle, ok := swedishPresentParticiples[infinitive]; ok {
+		return participle
+	}
+	if strings.HasSuffix(infinitive, "a") {
+		return infinitive + "nde"
+	}
+	return infinitive + "ende"
+}
+
+// swedishPresentParticiples are built on an older stem
+var swedishPresentParticiples = map[string]string{
+	"ta":  "tagande",
+	"dra": "dragande",
+	"ha":  "havande",
+	"ge":  "givande",
+	"bli": "blivande",
+	"be":  "bedjande",
+}
+
+// swedishGroup2Verbs are the common verbs of the second group
+var swedishGroup2Verbs = map[string]bool{
+	// -de
+	"stänga": true, "höra": true, "köra": true, "använda": true, "känna": true,
+	"glömma": true, "ringa": true, "leva": true, "följa": true, "ställa": true,
+	"behöva": true, "bygga": true, "böja": true, "hänga": true,
+	"lära": true, "stämma": true, "svänga": true, "tända": true, "vända": true,
+	"betyda": true, "gömma": true, "drömma": true,
+	// the deponent trivas and minnas
+	"triva": true, "minna": true,
+	// -te
+	"köpa": true, "läsa": true, "leka": true, "resa": true, "möta": true,
+	"hjälpa": true, "tycka": true, "åka": true, "söka": true, "röka": true,
+	"steka": true, "släcka": true, "märka": true, "byta": true, "tänka": true,
+}
+
+// swedishDeponentVerbs are the common verbs that are passive in form but
+// active in meaning
+var swedishDeponentVerbs = map[string]bool{
+	"finnas": true, "hoppas": true, "lyckas": true, "trivas": true,
+	"minnas": true, "andas": true, "låtsas": true,
+}
+
+// swedishStrongVerbs lists the present, past, supine and past participle of
+// the strong and irregular verbs
+var swedishStrongVerbs = map[string]swedishPrincipalParts{
+	"vara":      {present: "är", past: "var", supine: "varit", intransitive: true},
+	"ha":        {present: "har", past: "hade", supine: "haft", intransitive: true},
+	"bli":       {present: "blir", past: "blev", supine: "blivit", participle: "bliven", intransitive: true},
+	"kunna":     {present: "kan", past: "kunde", supine: "kunnat", intransitive: true, modal: true},
+	"vilja":     {present: "vill", past: "ville", supine: "velat", intransitive: true, modal: true},
+	"veta":      {present: "vet", past: "visste", supine: "vetat"},
+	"göra":      {present: "gör", past: "gjorde", supine: "gjort", participle: "gjord"},
+	"säga":      {present: "säger", past: "sa", supine: "sagt", participle: "sagd"},
+	"lägga":     {present: "lägger", past: "la", supine: "lagt", participle: "lagd"},
+	"sälja":     {present: "säljer", past: "sålde", supine: "sålt", participle: "såld"},
+	"välja":     {present: "väljer", past: "valde", supine: "valt", participle: "vald"},
+	"heta":      {present: "heter", past: "hette", supine: "hetat", intransitive: true},
+	"gå":        {present: "går", past: "gick", supine: "gått", participle: "gången", intransitive: true},
+	"stå":       {present: "står", past: "stod", supine: "stått", intransitive: true},
+	"få":        {present: "får", past: "fick", supine: "fått"},
+	"se":        {present: "ser", past: "såg", supine: "sett", participle: "sedd"},
+	"ge":        {present: "ger", past: "gav", supine: "gett", participle: "given"},
+	"ta":        {present: "tar", past: "tog", supine: "tagit", participle: "tagen"},
+	"dra":       {present: "drar", past: "drog", supine: "dragit", participle: "dragen"},
+	"slå":       {present: "slår", past: "slog", supine: "slagit", participle: "slagen"},
+	"le":        {present: "ler", past: "log", supine: "lett", intransitive: true},
+	"be":        {present: "ber", past: "bad", supine: "bett", participle: "bedd"},
+	"dö":        {present: "dör", past: "dog", supine: "dött", intransitive: true},
+	"komma":     {present: "kommer", past: "kom", supine: "kommit", participle: "kommen", intransitive: true},
+	"sova":      {present: "sover", past: "sov", supine: "sovit", intransitive: true},
+	"äta":       {present: "äter", past: "åt", supine: "ätit", participle: "äten"},
+	"ligga":     {present: "ligger", past: "låg", supine: "legat", intransitive: true},
+	"sitta":     {present: "sitter", past: "satt", supine: "suttit", intransitive: true},
+	"skriva":    {present: "skriver", past: "skrev", supine: "skrivit", participle: "skriven"},
+	"bita":      {present: "biter", past: "bet", supine: "bitit", participle: "biten"},
+	"rida":      {present: "rider", past: "red", supine: "ridit", participle: "riden"},
+	"dricka":    {present: "dricker", past: "drack", supine: "druckit", participle: "drucken"},
+	"springa":   {present: "springer", past: "sprang", supine: "sprungit", participle: "sprungen", intransitive: true},
+	"finna":     {present: "finner", past: "fann", supine: "funnit", participle: "funnen"},
+	"vinna":     {present: "vinner", past: "vann", supine: "vunnit", participle: "vunnen"},
+	"binda":     {present: "binder", past: "band", supine: "bundit", participle: "bunden"},
+	"sjunga":    {present: "sjunger", past: "sjöng", supine: "sjungit", participle: "sjungen"},
+	"bjuda":     {present: "bjuder", past: "bjöd", supine: "bjudit", participle: "bjuden"},
+	"flyga":     {present: "flyger", past: "flög", supine: "flugit", intransitive: true},
+	"bära":      {present: "bär", past: "bar", supine: "burit", participle: "buren"},
+	"stjäla":    {present: "stjäl", past: "stal", supine: "stulit", participle: "stulen"},
+	"hålla":     {present: "håller", past: "höll", supine: "hållit", participle: "hållen"},
+	"falla":     {present: "faller", past: "föll", supine: "fallit", intransitive: true},
+	"försvinna": {present: "försvinner", past: "försvann", supine: "försvunnit", intransitive: true},
+}
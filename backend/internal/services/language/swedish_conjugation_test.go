@@ -0,0 +1,161 @@
+package language
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSwedishVerbGroup(t *testing.T) {
+	conjugator := NewSwedishConjugator()
+
+	tests := []struct {
+		infinitive string
+		want       SwedishVerbGroup
+	}{
+		{"tala", SwedishGroup1},    // -ar, -ade
+		{"prata", SwedishGroup1},   // unknown verbs in -a join the first group
+		{"stänga", SwedishGroup2a}, // -er, -de
+		{"köpa", SwedishGroup2b},   // -er, -te after a voiceless consonant
+		{"bo", SwedishGroup3},      // short verbs: -r, -dde
+		{"skriva", SwedishGroup4},  // strong: vowel change
+		{"vara", SwedishGroup4},    // irregular
+	}
+
+	for _, tt := range tests {
+		if got := conjugator.Group(tt.infinitive); got != tt.want {
+			t.Errorf("Group(%s) = %s, want %s", tt.infinitive, got, tt.want)
+		}
+	}
+}
+
+// swedishParadigms lists the present, past, supine and imperative, then the
+// present passive and the past participle ("-" when there is none)
+var swedishParadigms = map[string][2]string{
+	// Group 1
+	"tala":   {"talar talade talat tala", "talas talad"},
+	"arbeta": {"arbetar arbetade arbetat arbeta", "arbetas arbetad"},
+	"öppna":  {"öppnar öppnade öppnat öppna", "öppnas öppnad"},
+
+	// Group 2a: -de, -mm and -nn simplified, -d after a consonant
+	"stänga":  {"stänger stängde stängt stäng", "stängs stängd"},
+	"höra":    {"hör hörde hört hör", "hörs hörd"},
+	"glömma":  {"glömmer glömde glömt glöm", "glöms glömd"},
+	"känna":   {"känner kände känt känn", "känns känd"},
+	"använda": {"använder använde använt använd", "används använd"},
+	"betyda":  {"betyder betydde betytt betyd", "betyds betydd"},
+
+	// Group 2b: -te
+	"köpa":  {"köper köpte köpt köp", "köps köpt"},
+	"läsa":  {"läser läste läst läs", "läses läst"},
+	"möta":  {"möter mötte mött möt", "möts mött"},
+	"tänka": {"tänker tänkte tänkt tänk", "tänks tänkt"},
+
+	// Group 3
+	"bo":  {"bor bodde bott bo", "bos bodd"},
+	"tro": {"tror trodde trott tro", "tros trodd"},
+
+	// Group 4 and irregular verbs
+	"vara":   {"är var varit var", "- -"},
+	"ha":     {"har hade haft ha", "- -"},
+	"kunna":  {"kan kunde kunnat -", "- -"},
+	"vilja":  {"vill ville velat -", "- -"},
+	"göra":   {"gör gjorde gjort gör", "görs gjord"},
+	"säga":   {"säger sa sagt säg", "sägs sagd"},
+	"gå":     {"går gick gått gå", "- gången"},
+	"komma":  {"kommer kom kommit kom", "- kommen"},
+	"skriva": {"skriver skrev skrivit skriv", "skrivs skriven"},
+	"dricka": {"dricker drack druckit drick", "dricks drucken"},
+	"ta":     {"tar tog tagit ta", "tas tagen"},
+	"se":     {"ser såg sett se", "ses sedd"},
+	"bära":   {"bär bar burit bär", "bärs buren"},
+
+	// Deponent verbs
+	"finnas": {"finns fanns funnits -", "- -"},
+	"hoppas": {"hoppas hoppades hoppats -", "- -"},
+	"andas":  {"andas andades andats -", "- -"},
+	"trivas": {"trivs trivdes trivts -", "- -"},
+	"minnas": {"minns mindes mints -", "- -"},
+}
+
+func TestSwedishParadigms(t *testing.T) {
+	conjugator := NewSwedishConjugator()
+
+	for infinitive, want := range swedishParadigms {
+		t.Run(infinitive, func(t *testing.T) {
+			conjugations := conjugator.ConjugateVerb(infinitive)
+			active := []string{
+				swedishFormOf(conjugations, MoodIndicative, TensePresent, VoiceActive, ""),
+				swedishFormOf(conjugations, MoodIndicative, TensePast, VoiceActive, ""),
+				swedishFormOf(conjugations, MoodInfinitive, "", VoiceActive, "supine"),
+				swedishFormOf(conjugations, MoodImperative, TensePresent, VoiceActive, ""),
+			}
+			if got := strings.Join(active, " "); got != want[0] {
+				t.Errorf("%s:\n got %s\nwant %s", infinitive, got, want[0])
+			}
+			passive := []string{
+				swedishFormOf(conjugations, MoodIndicative, TensePresent, VoicePassive, ""),
+				swedishFormOf(conjugations, MoodParticiple, TensePast, VoicePassive, ""),
+			}
+			if got := strings.Join(passive, " "); got != want[1] {
+				t.Errorf("%s passive:\n got %s\nwant %s", infinitive, got, want[1])
+			}
+		})
+	}
+}
+
+func TestSwedishCompoundTenses(t *testing.T) {
+	conjugations := NewSwedishConjugator().ConjugateVerb("skriva")
+
+	tests := []struct {
+		mood  Mood
+		tense Tense
+		voice Voice
+		want  string
+	}{
+		{MoodIndicative, TensePerfect, VoiceActive, "har skrivit"},
+		{MoodIndicative, TensePluperfect, VoiceActive, "hade skrivit"},
+		{MoodIndicative, TenseFuture, VoiceActive, "ska skriva"},
+		{MoodConditional, TensePresent, VoiceActive, "skulle skriva"},
+		{MoodIndicative, TensePast, VoicePassive, "skrevs"},
+		{MoodIndicative, TensePerfect, VoicePassive, "har skrivits"},
+		{MoodParticiple, TensePresent, VoiceActive, "skrivande"},
+	}
+	for _, tt := range tests {
+		if got := swedishFormOf(conjugations, tt.mood, tt.tense, tt.voice, ""); got != tt.want {
+			t.Errorf("%s %s %s = %s, want %s", tt.mood, tt.tense, tt.voice, got, tt.want)
+		}
+	}
+
+	// Swedish verbs do not inflect for person
+	for _, c := range conjugations {
+		if c.Person != "" || c.Polarity != PolarityAffirmative {
+			t.Errorf("form %+v has a person or polarity", c)
+		}
+	}
+}
+
+func TestSwedishPresentParticiple(t *testing.T) {
+	tests := map[string]string{
+		"tala": "talande",
+		"bo":   "boende",
+		"ta":   "tagande",
+		"ge":   "givande",
+	}
+	conjugator := NewSwedishConjugator()
+	for infinitive, want := range tests {
+		if got := swedishFormOf(conjugator.ConjugateVerb(infinitive), MoodParticiple, TensePresent, VoiceActive, ""); got != want {
+			t.Errorf("present participle of %s = %s, want %s", infinitive, got, want)
+		}
+	}
+}
+
+// swedishFormOf returns the form in the given slot, or "-" if the verb has
+// none
+func swedishFormOf(conjugations []Conjugation, mood Mood, tense Tense, voice Voice, variant string) string {
+	for _, c := range conjugations {
+		if c.Mood == mood && c.Tense == tense && c.Voice == voice && c.Variant == variant {
+			return c.Form
+		}
+	}
+	return "-"
+}
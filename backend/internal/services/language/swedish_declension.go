@@ -0,0 +1,339 @@
+package language
+
+import (
+	"strings"
+)
+
+// Gender of a Swedish noun: en-words are common, ett-words neuter
+type Gender string
+
+const (
+	GenderCommon Gender = "common"
+	GenderNeuter Gender = "neuter"
+)
+
+// Definiteness of a Swedish form. The definite article of a noun is a
+// suffix: bil, bilen.
+type Definiteness string
+
+const (
+	Indefinite Definiteness = "indefinite"
+	Definite   Definiteness = "definite"
+)
+
+// SwedishNoun is what the declension of a noun depends on: its gender and
+// its plural class, 1 to 5
+type SwedishNoun struct {
+	Gender Gender
+	Class  int // 1 -or, 2 -ar, 3 -er or -r, 4 -n, 5 no ending
+}
+
+// SwedishDeclension is one form of a Swedish noun. The genitive adds -s to
+// each of the nominative forms.
+type SwedishDeclension struct {
+	Case         Case         `json:"case"`
+	Number       Number       `json:"number"`
+	Definiteness Definiteness `json:"definiteness"`
+	Form         string       `json:"form"`
+}
+
+// SwedishDecliner declines Swedish nouns and inflects adjectives
+type SwedishDecliner struct{}
+
+func NewSwedishDecliner() *SwedishDecliner {
+	return &SwedishDecliner{}
+}
+
+// Noun returns the gender and plural class of a noun. Words missing from
+// swedishNouns are classed by their ending; the gender of most words can
+// only be known from the lexicon, and defaults to common.
+func (d *SwedishDecliner) Noun(word string) SwedishNoun {
+	word = strings.ToLower(word)
+	if noun, ok := swedishNouns[word]; ok {
+		return noun
+	}
+
+	switch {
+	case endsInAny(word, "ande", "ende"):
+		return SwedishNoun{GenderNeuter, 4} // meddelande
+	case endsInAny(word, "are"):
+		return SwedishNoun{GenderCommon, 5} // lärare
+	case endsInAny(word, "eum", "ium"):
+		return SwedishNoun{GenderNeuter, 3} // museum
+	case endsInAny(word, "eri"):
+		return SwedishNoun{GenderNeuter, 3} // bageri
+	case endsInAny(word, "het", "else", "ion", "tet", "ist", "ism", "ör", "ik", "ur", "or"):
+		return SwedishNoun{GenderCommon, 3} // frihet, station, doktor
+	case endsInAny(word, "a"):
+		return SwedishNoun{GenderCommon, 1} // flicka
+	case endsInSwedishVowel(word) && !strings.HasSuffix(word, "e"):
+		return SwedishNoun{GenderCommon, 3} // studio, sko
+	}
+	return SwedishNoun{GenderCommon, 2} // bil, pojke, tidning
+}
+
+// DeclineNoun returns the singular and plural, indefinite and definite
+// forms of a noun in the nominative, then the same in the genitive
+func (d *SwedishDecliner) DeclineNoun(word string) []SwedishDeclension {
+	word = strings.ToLower(word)
+	return d.decline(word, d.Noun(word))
+}
+
+// decline returns the forms of word declined as noun
+func (d *SwedishDecliner) decline(word string, noun SwedishNoun) []SwedishDeclension {
+	var forms [4]string
+	if irregular, ok := swedishIrregularNouns[word]; ok {
+		forms = [4]string{word, irregular[0], irregular[1], irregular[2]}
+	} else {
+		plural := swedishPlural(word, noun)
+		forms = [4]string{word, swedishDefiniteSingular(word, noun), plural, swedishDefinitePlural(plural, noun)}
+	}
+
+	slots := []struct {
+		number       Number
+		definiteness Definiteness
+	}{
+		{NumberSingular, Indefinite},
+		{NumberSingular, Definite},
+		{NumberPlural, Indefinite},
+		{NumberPlural, Definite},
+	}
+
+	declensions := make([]SwedishDeclension, 0, 2*len(slots))
+	for _, c := range []Case{CaseNominative, CaseGenitive} {
+		for i, slot := range slots {
+			form := forms[i]
+			if c == CaseGenitive {
+				form = swedishGenitive(form)
+			}
+			declensions = append(declensions, SwedishDeclension{
+				Case:         c,
+				Number:       slot.number,
+				Definiteness: slot.definiteness,
+				Form:         form,
+			})
+		}
+	}
+	return declensions
+}
+
+// swedishDefiniteSingular adds the definite article: -n or -t after an
+// unstressed vowel, -en or -et after a consonant or a stressed vowel. The e
+// of an unstressed -el, -en or -er ending is dropped before -et and -en:
+// fönstret, öknen.
+func swedishDefiniteSingular(word string, noun SwedishNoun) string {
+	if noun.Gender == GenderNeuter {
+		switch {
+		case endsInAny(word, "eum", "ium"):
+			return strings.TrimSuffix(word, "um") + "et"
+		case endsInAny(word, "eri") || endsInSwedishVowel(word) && swedishSyllables(word) == 1:
+			return word + "et" // bageriet, biet
+		case endsInSwedishVowel(word):
+			return word + "t"
+		case hasUnstressedE(word):
+			return dropUnstressedE(word) + "et"
+		}
+		return word + "et"
+	}
+
+	switch {
+	case endsInSwedishVowel(word):
+		return word + "n"
+	case hasUnstressedE(word) && strings.HasSuffix(word, "en"):
+		return dropUnstressedE(word) + "en"
+	case hasUnstressedE(word), strings.HasSuffix(word, "or") && swedishSyllables(word) > 1:
+		return word + "n" // nyckeln, datorn
+	}
+	return word + "en"
+}
+
+// swedishPlural returns the indefinite plural of a regular noun
+func swedishPlural(word string, noun SwedishNoun) string {
+	switch noun.Class {
+	case 1:
+		return strings.TrimSuffix(word, "a") + "or"
+	case 2:
+		if hasUnstressedE(word) {
+			return dropUnstressedE(word) + "ar"
+		}
+		return strings.TrimSuffix(word, "e") + "ar"
+	case 3:
+		switch {
+		case endsInAny(word, "eum", "ium"):
+			return strings.TrimSuffix(word, "um") + "er"
+		case endsInAny(word, "eri"):
+			return word + "er"
+		case hasUnstressedE(word):
+			return dropUnstressedE(word) + "er"
+		case strings.HasSuffix(word, "e"):
+			return word + "r" // linje, linjer
+		case endsInSwedishVowel(word):
+			return word + "r" // sko, skor
+		}
+		return word + "er"
+	case 4:
+		return word + "n"
+	}
+	return word
+}
+
+// swedishDefinitePlural adds the definite article to a plural: -na after
+// -r, -a after the -n of the fourth class, -en to the unchanged plural of
+// neuters, and -na to that of common nouns, whose -are loses its e
+func swedishDefinitePlural(plural string, noun SwedishNoun) string {
+	switch noun.Class {
+	case 1, 2, 3:
+		return plural + "na"
+	case 4:
+		return plural + "a"
+	}
+	if noun.Gender == GenderNeuter {
+		if hasUnstressedE(plural) {
+			return dropUnstressedE(plural) + "en"
+		}
+		return plural + "en"
+	}
+	if strings.HasSuffix(plural, "are") {
+		return strings.TrimSuffix(plural, "e") + "na"
+	}
+	return plural + "na"
+}
+
+// swedishGenitive adds -s, except to words already ending in a sibilant
+func swedishGenitive(form string) string {
+	if endsInAny(form, "s", "x", "z") {
+		return form
+	}
+	return form + "s"
+}
+
+func isSwedishVowel(r rune) bool {
+	return strings.ContainsRune("aeiouyåäöé", r)
+}
+
+func endsInSwedishVowel(word string) bool {
+	runes := []rune(word)
+	return len(runes) > 0 && isSwedishVowel(runes[len(runes)-1])
+}
+
+// swedishSyllables counts the vowel groups of a word
+func swedishSyllables(word string) int {
+	n := 0
+	previous := false
+	for _, r := range word {
+		vowel := isSwedishVowel(r)
+		if vowel && !previous {
+			n++
+		}
+		previous = vowel
+	}
+	return n
+}
+
+// hasUnstressedE reports whether a word of more than one syllable ends in
+// -el, -en or -er: nyckel, öken, vinter
+func hasUnstressedE(word string) bool {
+	if !endsInAny(word, "el", "en", "er") {
+		return false
+	}
+	stem := []rune(word)
+	stem = stem[:len(stem)-2]
+	for _, r := range stem {
+		if isSwedishVowel(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// dropUnstressedE drops the e of a final -el, -en or -er: nyckel → nyckl-
+func dropUnstressedE(word string) string {
+	runes := []rune(word)
+	n := len(runes)
+	return string(runes[:n-2]) + string(runes[n-1])
+}
+
+// swedishNouns gives the gender and class of common nouns
+var swedishNouns = map[string]SwedishNoun{
+	// Common gender, -or
+	"flicka": {GenderCommon, 1}, "kvinna": {GenderCommon, 1}, "gata": {GenderCommon, 1},
+	"klocka": {GenderCommon, 1}, "lampa": {GenderCommon, 1}, "skola": {GenderCommon, 1},
+	"vecka": {GenderCommon, 1}, "kyrka": {GenderCommon, 1}, "blomma": {GenderCommon, 1},
+	"väska": {GenderCommon, 1}, "människa": {GenderCommon, 1}, "ros": {GenderCommon, 1},
+	"våg": {GenderCommon, 1}, "mamma": {GenderCommon, 1}, "pappa": {GenderCommon, 1},
+
+	// Common gender, -ar
+	"bil": {GenderCommon, 2}, "pojke": {GenderCommon, 2}, "dag": {GenderCommon, 2},
+	"stol": {GenderCommon, 2}, "hund": {GenderCommon, 2}, "arm": {GenderCommon, 2},
+	"fisk": {GenderCommon, 2}, "kväll": {GenderCommon, 2}, "sjö": {GenderCommon, 2},
+	"tidning": {GenderCommon, 2}, "nyckel": {GenderCommon, 2}, "fågel": {GenderCommon, 2},
+	"vinter": {GenderCommon, 2}, "timme": {GenderCommon, 2}, "svensk": {GenderCommon, 2},
+	"katt": {GenderCommon, 2}, "väg": {GenderCommon, 2}, "bro": {GenderCommon, 2},
+	"sommar": {GenderCommon, 2}, "morgon": {GenderCommon, 2}, "dotter": {GenderCommon, 2},
+	"mor": {GenderCommon, 2}, "by": {GenderCommon, 2}, "ö": {GenderCommon, 2},
+
+	// Common gender, -er and -r
+	"park": {GenderCommon, 3}, "telefon": {GenderCommon, 3}, "station": {GenderCommon, 3},
+	"student": {GenderCommon, 3}, "restaurang": {GenderCommon, 3}, "person": {GenderCommon, 3},
+	"dator": {GenderCommon, 3}, "sko": {GenderCommon, 3}, "ko": {GenderCommon, 3},
+	"muskel": {GenderCommon, 3}, "linje": {GenderCommon, 3}, "stad": {GenderCommon, 3},
+	"hand": {GenderCommon, 3}, "fot": {GenderCommon, 3}, "bok": {GenderCommon, 3},
+	"natt": {GenderCommon, 3}, "tand": {GenderCommon, 3}, "son": {GenderCommon, 3},
+	"bonde": {GenderCommon, 3}, "bror": {GenderCommon, 3}, "far": {GenderCommon, 3},
+	"vän": {GenderCommon, 3}, "sak": {GenderCommon, 3}, "familj": {GenderCommon, 3},
+	"tid": {GenderCommon, 3},
+
+	// Common gender, no plural ending
+	"lärare": {GenderCommon, 5}, "läkare": {GenderCommon, 5}, "arbetare": {GenderCommon, 5},
+	"musiker": {GenderCommon, 5}, "man": {GenderCommon, 5}, "mus": {GenderCommon, 5},
+	"gås": {GenderCommon, 5},
+
+	// Neuter, -er
+	"museum": {GenderNeuter, 3}, "land": {GenderNeuter, 3}, "bageri": {GenderNeuter, 3},
+
+	// Neuter, -n
+	"äpple": {GenderNeuter, 4}, "hjärta": {GenderNeuter, 4}, "öga": {GenderNeuter, 4},
+	"öra": {GenderNeuter, 4}, "frimärke": {GenderNeuter, 4}, "ställe": {GenderNeuter, 4},
+	"meddelande": {GenderNeuter, 4}, "bi": {GenderNeuter, 4}, "kaffe": {GenderNeuter, 4},
+	"rike": {GenderNeuter, 4},
+
+	// Neuter, no plural ending
+	"hus": {GenderNeuter, 5}, "barn": {GenderNeuter, 5}, "bord": {GenderNeuter, 5},
+	"år": {GenderNeuter, 5}, "språk": {GenderNeuter, 5}, "brev": {GenderNeuter, 5},
+	"djur": {GenderNeuter, 5}, "glas": {GenderNeuter, 5}, "kök": {GenderNeuter, 5},
+	"fönster": {GenderNeuter, 5}, "ord": {GenderNeuter, 5}, "rum": {GenderNeuter, 5},
+	"hem": {GenderNeuter, 5}, "tåg": {GenderNeuter, 5}, "namn": {GenderNeuter, 5},
+	"problem": {GenderNeuter, 5}, "program": {GenderNeuter, 5}, "papper": {GenderNeuter, 5},
+	"vatten": {GenderNeuter, 5}, "tecken": {GenderNeuter, 5}, "segel": {GenderNeuter, 5},
+	"jobb": {GenderNeuter, 5},
+}
+
+// swedishIrregularNouns lists the definite singular, the plural and the
+// definite plural of nouns that change their stem: umlaut in the plural,
+// a doubled consonant before the article, a dropped vowel
+var swedishIrregularNouns = map[string][3]string{
+	"man":     {"mannen", "män", "männen"},
+	"mus":     {"musen", "möss", "mössen"},
+	"gås":     {"gåsen", "gäss", "gässen"},
+	"bror":    {"brodern", "bröder", "bröderna"},
+	"far":     {"fadern", "fäder", "fäderna"},
+	"mor":     {"modern", "mödrar", "mödrarna"},
+	"dotter":  {"dottern", "döttrar", "döttrarna"},
+	"öga":     {"ögat", "ögon", "ögonen"},
+	"öra":     {"örat", "öron", "öronen"},
+	"hand":    {"handen", "händer", "händerna"},
+	"fot":     {"foten", "fötter", "fötterna"},
+	"stad":    {"staden", "städer", "städerna"},
+	"land":    {"landet", "länder", "länderna"},
+	"bok":     {"boken", "böcker", "böckerna"},
+	"natt":    {"natten", "nätter", "nätterna"},
+	"tand":    {"tanden", "tänder", "tänderna"},
+	"son":     {"sonen", "söner", "sönerna"},
+	"bonde":   {"bonden", "bönder", "bönderna"},
+	"vän":     {"vännen", "vänner", "vännerna"},
+	"rum":     {"rummet", "rum", "rummen"},
+	"hem":     {"hemmet", "hem", "hemmen"},
+	"program": {"programmet", "program", "programmen"},
+	"sommar":  {"sommaren", "somrar", "somrarna"},
+	"morgon":  {"morgonen", "morgnar", "morgnarna"},
+}
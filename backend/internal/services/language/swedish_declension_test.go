@@ -0,0 +1,81 @@
+package language
+
+import (
+	"strings"
+	"testing"
+)
+
+// swedishForms joins the nominative or genitive forms of a noun in the
+// order indefinite and definite singular, indefinite and definite plural
+func swedishForms(declensions []SwedishDeclension, c Case) string {
+	var forms []string
+	for _, d := range declensions {
+		if d.Case == c {
+			forms = append(forms, d.Form)
+		}
+	}
+	return strings.Join(forms, " ")
+}
+
+func TestDeclineSwedishNounByClass(t *testing.T) {
+	decliner := NewSwedishDecliner()
+
+	tests := []struct {
+		word   string
+		noun   SwedishNoun
+		want   string
+		reason string
+	}{
+		{"flicka", SwedishNoun{GenderCommon, 1}, "flicka flickan flickor flickorna", "-or"},
+		{"ros", SwedishNoun{GenderCommon, 1}, "ros rosen rosor rosorna", "-or after a consonant"},
+		{"bil", SwedishNoun{GenderCommon, 2}, "bil bilen bilar bilarna", "-ar"},
+		{"pojke", SwedishNoun{GenderCommon, 2}, "pojke pojken pojkar pojkarna", "-e dropped before -ar"},
+		{"nyckel", SwedishNoun{GenderCommon, 2}, "nyckel nyckeln nycklar nycklarna", "unstressed e dropped"},
+		{"sommar", SwedishNoun{GenderCommon, 2}, "sommar sommaren somrar somrarna", "irregular -ar"},
+		{"by", SwedishNoun{GenderCommon, 2}, "by byn byar byarna", "-ar after a vowel"},
+		{"park", SwedishNoun{GenderCommon, 3}, "park parken parker parkerna", "-er"},
+		{"sko", SwedishNoun{GenderCommon, 3}, "sko skon skor skorna", "-r after a vowel"},
+		{"studio", SwedishNoun{GenderCommon, 3}, "studio studion studior studiorna", "classed by a final vowel"},
+		{"dator", SwedishNoun{GenderCommon, 3}, "dator datorn datorer datorerna", "-n after unstressed -or"},
+		{"tid", SwedishNoun{GenderCommon, 3}, "tid tiden tider tiderna", "-er after a single consonant"},
+		{"frihet", SwedishNoun{GenderCommon, 3}, "frihet friheten friheter friheterna", "classed by -het"},
+		{"bok", SwedishNoun{GenderCommon, 3}, "bok boken böcker böckerna", "umlaut"},
+		{"museum", SwedishNoun{GenderNeuter, 3}, "museum museet museer museerna", "-um dropped"},
+		{"äpple", SwedishNoun{GenderNeuter, 4}, "äpple äpplet äpplen äpplena", "-n"},
+		{"rike", SwedishNoun{GenderNeuter, 4}, "rike riket riken rikena", "neuter in -e"},
+		{"bi", SwedishNoun{GenderNeuter, 4}, "bi biet bin bina", "stressed vowel takes -et"},
+		{"lärare", SwedishNoun{GenderCommon, 5}, "lärare läraren lärare lärarna", "no plural ending"},
+		{"man", SwedishNoun{GenderCommon, 5}, "man mannen män männen", "umlaut"},
+		{"hus", SwedishNoun{GenderNeuter, 5}, "hus huset hus husen", "neuter without ending"},
+		{"fönster", SwedishNoun{GenderNeuter, 5}, "fönster fönstret fönster fönstren", "unstressed e dropped"},
+		{"rum", SwedishNoun{GenderNeuter, 5}, "rum rummet rum rummen", "doubled m"},
+	}
+
+	for _, tt := range tests {
+		if got := decliner.Noun(tt.word); got != tt.noun {
+			t.Errorf("%s: Noun(%s) = %+v, want %+v", tt.reason, tt.word, got, tt.noun)
+		}
+		if got := swedishForms(decliner.DeclineNoun(tt.word), CaseNominative); got != tt.want {
+			t.Errorf("%s: %s:\n got %s\nwant %s", tt.reason, tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestDeclineSwedishNounGenitive(t *testing.T) {
+	decliner := NewSwedishDecliner()
+
+	tests := map[string]string{
+		"bil":    "bils bilens bilars bilarnas",
+		"hus":    "hus husets hus husens",
+		"flicka": "flickas flickans flickors flickornas",
+	}
+	for word, want := range tests {
+		declensions := decliner.DeclineNoun(word)
+		if len(declensions) != 8 {
+			t.Fatalf("%s: got %d forms, want 4 nominative and 4 genitive", word, len(declensions))
+		}
+		if got := swedishForms(declensions, CaseGenitive); got != want {
+			t.Errorf("genitive of %s:\n got %s\nwant %s", word, got, want)
+		}
+	}
+}
@@ -0,0 +1,341 @@
+package language
+
+import (
+	_ "embed"
+	"strings"
+	"unicode/utf8"
+)
+
+//go:embed lexicon/swedish.txt
+var swedishLexicon string
+
+// SwedishLemmatizer maps Swedish word forms to their lemmas like the
+// Finnish Lemmatizer: the forms of the lexicon words are generated and
+// looked up, and other words are guessed by stripping endings and keeping
+// the lemmas that generate the word again
+type SwedishLemmatizer struct {
+	decliner   *SwedishDecliner
+	conjugator *SwedishConjugator
+	forms      map[string][]Analysis
+}
+
+func NewSwedishLemmatizer() *SwedishLemmatizer {
+	l := &SwedishLemmatizer{
+		decliner:   NewSwedishDecliner(),
+		conjugator: NewSwedishConjugator(),
+		forms:      make(map[string][]Analysis),
+	}
+	for _, line := range strings.Split(swedishLexicon, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		l.addLemma(fields[0], fields[1])
+	}
+	for lemma, pronoun := range swedishPersonalPronouns {
+		if pronoun.subject != "" {
+			l.add(pronoun.subject, Analysis{Lemma: lemma, PartOfSpeech: "pronoun", Case: CaseNominative, Number: pronoun.number})
+		}
+		if pronoun.object != "" {
+			l.add(pronoun.object, Analysis{Lemma: lemma, PartOfSpeech: "pronoun", Case: CaseAccusative, Number: pronoun.number})
+		}
+	}
+	for lemma, forms := range swedishPossessives {
+		l.add(lemma, Analysis{Lemma: lemma, PartOfSpeech: "pronoun", Gender: GenderCommon, Number: NumberSingular})
+		l.add(forms[0], Analysis{Lemma: lemma, PartOfSpeech: "pronoun", Gender: GenderNeuter, Number: NumberSingular})
+		l.add(forms[1], Analysis{Lemma: lemma, PartOfSpeech: "pronoun", Number: NumberPlural})
+	}
+	return l
+}
+
+// addLemma indexes every form of a lexicon entry
+func (l *SwedishLemmatizer) addLemma(lemma, partOfSpeech string) {
+	switch partOfSpeech {
+	case "noun":
+		for _, d := range l.decliner.DeclineNoun(lemma) {
+			l.add(d.Form, nounAnalysis(lemma, d))
+		}
+	case "adjective":
+		for _, f := range l.decliner.InflectAdjective(lemma) {
+			// mer and mest compare the long adjectives
+			if !strings.Contains(f.Form, " ") {
+				l.add(f.Form, adjectiveAnalysis(lemma, f))
+			}
+		}
+	case "verb":
+		for _, c := range l.conjugator.ConjugateVerb(lemma) {
+			// Compound tenses are several words
+			if strings.Contains(c.Form, " ") {
+				continue
+			}
+			// A deponent verb comes before the passive it shares its forms
+			// with: finns is finnas rather than finna
+			if swedishDeponentVerbs[lemma] {
+				l.forms[c.Form] = append([]Analysis{verbAnalysis(lemma, c)}, l.forms[c.Form]...)
+				continue
+			}
+			l.add(c.Form, verbAnalysis(lemma, c))
+		}
+	default:
+		l.add(lemma, Analysis{Lemma: lemma, PartOfSpeech: partOfSpeech})
+	}
+}
+
+func (l *SwedishLemmatizer) add(form string, a Analysis) {
+	l.forms[form] = append(l.forms[form], a)
+}
+
+func nounAnalysis(lemma string, d SwedishDeclension) Analysis {
+	return Analysis{
+		Lemma:        lemma,
+		PartOfSpeech: "noun",
+		Case:         d.Case,
+		Number:       d.Number,
+		Definiteness: d.Definiteness,
+	}
+}
+
+func adjectiveAnalysis(lemma string, f AdjectiveForm) Analysis {
+	return Analysis{
+		Lemma:        lemma,
+		PartOfSpeech: "adjective",
+		Number:       f.Number,
+		Gender:       f.Gender,
+		Definiteness: f.Definiteness,
+		Degree:       f.Degree,
+	}
+}
+
+// Analyze returns the readings of word, most likely first: the lexicon
+// readings if there are any, then those of a compound whose parts are in
+// it, otherwise the guessed ones. A word with no reading returns nil.
+func (l *SwedishLemmatizer) Analyze(word string) []Analysis {
+	word = strings.ToLower(strings.TrimSpace(word))
+	if analyses := l.forms[word]; len(analyses) > 0 {
+		return append([]Analysis(nil), analyses...)
+	}
+	parts := splitCompound(word, l.compoundModifier, l.compoundHead)
+	if analyses := compoundAnalyses(word, parts, l.known); len(analyses) > 0 {
+		return analyses
+	}
+	return l.guess(word)
+}
+
+// known returns the lexicon readings of a lower-case word
+func (l *SwedishLemmatizer) known(word string) []Analysis {
+	return l.forms[word]
+}
+
+// Lemmas returns the distinct lemmas of the readings of word, most likely
+// first
+func (l *SwedishLemmatizer) Lemmas(word string) []string {
+	return lemmasOf(l.Analyze(word))
+}
+
+// guess returns the readings of a word that is not in the lexicon, as a
+// noun, then an adjective, then a verb. As with Finnish the word itself is
+// not taken for a lemma.
+func (l *SwedishLemmatizer) guess(word string) []Analysis {
+	var analyses []Analysis
+
+	candidates := swedishCandidates(word, swedishNounEndings)
+	// The gender and class of an unknown noun are guessed from its ending;
+	// only when that fails are the other classes tried
+	for _, anyClass := range []bool{false, true} {
+		for _, lemma := range candidates {
+			nouns := []SwedishNoun{l.decliner.Noun(lemma)}
+			if anyClass {
+				nouns = swedishNounClasses
+			}
+			for _, noun := range nouns {
+				for _, d := range l.decliner.decline(lemma, noun) {
+					if d.Form == word {
+						analyses = appendGuess(analyses, nounAnalysis(lemma, d))
+					}
+				}
+			}
+		}
+		if len(analyses) > 0 {
+			break
+		}
+	}
+
+	for _, lemma := range swedishCandidates(word, swedishAdjectiveEndings) {
+		// Participles are read as forms of their verb
+		if isSwedishParticiple(lemma) {
+			continue
+		}
+		for _, f := range l.decliner.InflectAdjective(lemma) {
+			if f.Form == word {
+				analyses = appendGuess(analyses, adjectiveAnalysis(lemma, f))
+			}
+		}
+	}
+
+	for _, infinitive := range swedishVerbCandidates(word) {
+		paradigms := [][]Conjugation{l.conjugator.ConjugateVerb(infinitive)}
+		// Unknown verbs are conjugated in the first group, but may be weak
+		// verbs of the second
+		if stem, ok := strings.CutSuffix(infinitive, "a"); ok && l.conjugator.Group(infinitive) == SwedishGroup1 {
+			paradigms = append(paradigms, l.conjugator.conjugate(infinitive, group2Parts(stem)))
+		}
+		for _, paradigm := range paradigms {
+			for _, c := range paradigm {
+				if c.Form == word {
+					analyses = appendGuess(analyses, verbAnalysis(infinitive, c))
+				}
+			}
+		}
+	}
+	return analyses
+}
+
+// appendGuess adds a guessed reading unless an equal one is already there
+func appendGuess(analyses []Analysis, a Analysis) []Analysis {
+	a.Guessed = true
+	for _, b := range analyses {
+		if sameReading(a, b) {
+			return analyses
+		}
+	}
+	return append(analyses, a)
+}
+
+func sameReading(a, b Analysis) bool {
+	return a.Lemma == b.Lemma && a.PartOfSpeech == b.PartOfSpeech && a.Case == b.Case &&
+		a.Number == b.Number && a.Definiteness == b.Definiteness && a.Gender == b.Gender &&
+		a.Degree == b.Degree && a.Mood == b.Mood && a.Tense == b.Tense && a.Voice == b.Voice &&
+		a.Variant == b.Variant
+}
+
+// swedishNounClasses are the genders and plural classes tried for a noun
+// whose own guess does not generate the word
+var swedishNounClasses = []SwedishNoun{
+	{GenderCommon, 1}, {GenderCommon, 2}, {GenderCommon, 3}, {GenderCommon, 5},
+	{GenderNeuter, 3}, {GenderNeuter, 4}, {GenderNeuter, 5},
+}
+
+// swedishNounEndings are the article, plural and genitive endings stripped
+// to guess the lemma of a noun, longest first
+var swedishNounEndings = []string{
+	"ornas", "arnas", "ernas", "orna", "arna", "erna", "ens", "ets", "nas",
+	"ena", "ors", "ars", "ers", "or", "ar", "er", "en", "et", "na", "ns",
+	"ts", "rs", "n", "t", "r", "s",
+}
+
+// swedishAdjectiveEndings are the agreement and comparison endings of
+// adjectives, longest first
+var swedishAdjectiveEndings = []string{"aste", "are", "ast", "ste", "a", "e", "t"}
+
+// swedishCandidates returns the lemmas a form may belong to: the form
+// stripped of one of endings, with the -a of the first class or the -e of
+// the second restored, or with the unstressed e that the ending dropped
+// (nycklar, nyckel)
+func swedishCandidates(word string, endings []string) []string {
+	var candidates []string
+	seen := map[string]bool{word: true}
+	add := func(lemma string) {
+		if !seen[lemma] {
+			seen[lemma] = true
+			candidates = append(candidates, lemma)
+		}
+	}
+	for _, ending := range endings {
+		stem, ok := strings.CutSuffix(word, ending)
+		if !ok || utf8.RuneCountInString(stem) < 2 {
+			continue
+		}
+		if restored, ok := restoreUnstressedE(stem); ok {
+			add(restored)
+		}
+		add(stem)
+		// Only a vowel ending can have replaced a final vowel: flickor,
+		// pojkar; flickan keeps it
+		if isSwedishVowel([]rune(ending)[0]) {
+			add(stem + "a")
+			add(stem + "e")
+		}
+	}
+	return candidates
+}
+
+// restoreUnstressedE puts back the e of -el, -en and -er that a stem lost
+// before a vowel: fönstr, fönster. Stems that end in two of l, n and r
+// (barn, karl) have no e to restore.
+func restoreUnstressedE(stem string) (string, bool) {
+	runes := []rune(stem)
+	n := len(runes)
+	if n < 3 || !strings.ContainsRune("lnr", runes[n-1]) || isSwedishVowel(runes[n-2]) || strings.ContainsRune("lnr", runes[n-2]) {
+		return "", false
+	}
+	return string(runes[:n-1]) + "e" + string(runes[n-1]), true
+}
+
+// swedishVerbEndings are the tense, participle and passive endings
+// stripped to guess an infinitive, longest first
+var swedishVerbEndings = []string{
+	"andes", "ades", "ande", "ende", "ade", "ats", "tes", "des",
+	"ar", "er", "at", "ad", "as", "es", "te", "de", "ts", "r", "t", "d", "s",
+}
+
+// swedishVerbCandidates returns the infinitives a verb form may belong to.
+// A consonant stem takes -a; a stem in -a and the short stems of the third
+// group (bor, bo) are infinitives already. A single m or n is doubled for the stems that simplify it
+// (glömde, glömma).
+func swedishVerbCandidates(word string) []string {
+	var candidates []string
+	seen := map[string]bool{word: true}
+	add := func(infinitive string) {
+		if !seen[infinitive] {
+			seen[infinitive] = true
+			candidates = append(candidates, infinitive)
+		}
+	}
+	for _, ending := range swedishVerbEndings {
+		stem, ok := strings.CutSuffix(word, ending)
+		if !ok || utf8.RuneCountInString(stem) < 2 {
+			continue
+		}
+		switch {
+		case strings.HasSuffix(stem, "a"):
+			add(stem) // talar, tala
+		case endsInSwedishVowel(stem) && swedishSyllables(stem) == 1:
+			add(stem) // bor, bo
+		case !endsInSwedishVowel(stem):
+			add(stem + "a")
+		}
+		runes := []rune(stem)
+		if last := runes[len(runes)-1]; (last == 'm' || last == 'n') && isSwedishVowel(runes[len(runes)-2]) {
+			add(stem + string(last) + "a")
+		}
+	}
+	return candidates
+}
+
+// swedishPersonalPronouns are the subject and object forms of the personal
+// pronouns
+var swedishPersonalPronouns = map[string]struct {
+	subject, object string
+	number          Number
+}{
+	"jag": {"jag", "mig", NumberSingular},
+	"du":  {"du", "dig", NumberSingular},
+	"han": {"han", "honom", NumberSingular},
+	"hon": {"hon", "henne", NumberSingular},
+	"den": {"den", "", NumberSingular},
+	"det": {"det", "", NumberSingular},
+	"vi":  {"vi", "oss", NumberPlural},
+	"ni":  {"ni", "er", NumberPlural},
+	"de":  {"de", "dem", NumberPlural},
+	"sig": {"", "sig", ""},
+}
+
+// swedishPossessives are the neuter and plural forms of the possessive
+// pronouns, which agree like adjectives: min bil, mitt hus, mina böcker
+var swedishPossessives = map[string][2]string{
+	"min": {"mitt", "mina"},
+	"din": {"ditt", "dina"},
+	"sin": {"sitt", "sina"},
+	"vår": {"vårt", "våra"},
+	"er":  {"ert", "era"},
+}
@@ -0,0 +1,80 @@
+package language
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSwedishLemmatizerLexiconWords(t *testing.T) {
+	lemmatizer := NewSwedishLemmatizer()
+
+	tests := []struct {
+		word string
+		want Analysis
+	}{
+		{"huset", Analysis{Lemma: "hus", PartOfSpeech: "noun", Case: CaseNominative, Number: NumberSingular, Definiteness: Definite}},
+		{"bilarna", Analysis{Lemma: "bil", PartOfSpeech: "noun", Case: CaseNominative, Number: NumberPlural, Definiteness: Definite}},
+		{"flickor", Analysis{Lemma: "flicka", PartOfSpeech: "noun", Case: CaseNominative, Number: NumberPlural, Definiteness: Indefinite}},
+		{"böckerna", Analysis{Lemma: "bok", PartOfSpeech: "noun", Case: CaseNominative, Number: NumberPlural, Definiteness: Definite}},
+		{"bilens", Analysis{Lemma: "bil", PartOfSpeech: "noun", Case: CaseGenitive, Number: NumberSingular, Definiteness: Definite}},
+		{"fönstret", Analysis{Lemma: "fönster", PartOfSpeech: "noun", Case: CaseNominative, Number: NumberSingular, Definiteness: Definite}},
+		{"stort", Analysis{Lemma: "stor", PartOfSpeech: "adjective", Number: NumberSingular, Gender: GenderNeuter, Definiteness: Indefinite, Degree: DegreePositive}},
+		{"små", Analysis{Lemma: "liten", PartOfSpeech: "adjective", Number: NumberPlural, Definiteness: Indefinite, Degree: DegreePositive}},
+		{"större", Analysis{Lemma: "stor", PartOfSpeech: "adjective", Degree: DegreeComparative}},
+		{"är", Analysis{Lemma: "vara", PartOfSpeech: "verb", Mood: MoodIndicative, Tense: TensePresent, Voice: VoiceActive}},
+		{"gick", Analysis{Lemma: "gå", PartOfSpeech: "verb", Mood: MoodIndicative, Tense: TensePast, Voice: VoiceActive}},
+		{"köpte", Analysis{Lemma: "köpa", PartOfSpeech: "verb", Mood: MoodIndicative, Tense: TensePast, Voice: VoiceActive}},
+		{"skrivs", Analysis{Lemma: "skriva", PartOfSpeech: "verb", Mood: MoodIndicative, Tense: TensePresent, Voice: VoicePassive}},
+		{"finns", Analysis{Lemma: "finnas", PartOfSpeech: "verb", Mood: MoodIndicative, Tense: TensePresent, Voice: VoiceActive}},
+		{"hoppades", Analysis{Lemma: "hoppas", PartOfSpeech: "verb", Mood: MoodIndicative, Tense: TensePast, Voice: VoiceActive}},
+		{"mig", Analysis{Lemma: "jag", PartOfSpeech: "pronoun", Case: CaseAccusative, Number: NumberSingular}},
+		{"mitt", Analysis{Lemma: "min", PartOfSpeech: "pronoun", Gender: GenderNeuter, Number: NumberSingular}},
+		{"Och", Analysis{Lemma: "och", PartOfSpeech: "conjunction"}},
+	}
+
+	for _, tt := range tests {
+		analyses := lemmatizer.Analyze(tt.word)
+		if len(analyses) == 0 {
+			t.Errorf("Analyze(%q) found nothing", tt.word)
+			continue
+		}
+		if got := analyses[0]; !sameAnalysis(got, tt.want) {
+			t.Errorf("Analyze(%q)[0] = %+v, want %+v", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestSwedishLemmatizerGuessesUnknownWords(t *testing.T) {
+	lemmatizer := NewSwedishLemmatizer()
+
+	tests := []struct {
+		word   string
+		lemma  string
+		reason string
+	}{
+		{"pennorna", "penna", "noun of the first class"},
+		{"byggnaden", "byggnad", "definite singular"},
+		{"universitetet", "universitet", "neuter tried when the guessed gender fails"},
+		{"cyklar", "cykel", "unstressed e restored"},
+		{"hämtat", "hämta", "supine"},
+		{"tvättade", "tvätta", "first group past"},
+		{"kramas", "krama", "passive"},
+		{"spännande", "spänna", "present participle"},
+	}
+
+	for _, tt := range tests {
+		analyses := lemmatizer.Analyze(tt.word)
+		if lemmas := lemmasOf(analyses); !slices.Contains(lemmas[:min(len(lemmas), 2)], tt.lemma) {
+			t.Errorf("%s: Lemmas(%q) = %v, want %q among the first two", tt.reason, tt.word, lemmas, tt.lemma)
+		}
+		for _, a := range analyses {
+			if !a.Guessed {
+				t.Errorf("%q: reading %+v not marked as guessed", tt.word, a)
+			}
+		}
+	}
+
+	if analyses := lemmatizer.Analyze("xyzzy"); analyses != nil {
+		t.Errorf("Analyze(xyzzy) = %+v, want no reading", analyses)
+	}
+}
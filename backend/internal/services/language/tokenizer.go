@@ -0,0 +1,197 @@
+package language
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Segmenter splits text into sentences and words. A period ends a sentence
+// unless it ends one of the language's abbreviations ("esim.", "t.ex."), a
+// single initial ("J. K. Paasikivi"), or is followed by a lower-case word
+// as after a Finnish ordinal ("5. toukokuuta"). A quote or bracket closing
+// after the punctuation stays with its sentence.
+type Segmenter struct {
+	abbreviations map[string]bool
+}
+
+// NewSegmenter returns a segmenter that knows the given abbreviations,
+// written without their final period
+func NewSegmenter(abbreviations ...string) *Segmenter {
+	s := &Segmenter{abbreviations: make(map[string]bool, len(abbreviations))}
+	for _, a := range abbreviations {
+		s.abbreviations[strings.ToLower(a)] = true
+	}
+	return s
+}
+
+// plainSegmenter knows no abbreviations
+var plainSegmenter = NewSegmenter()
+
+// Tokenize splits a text into its distinct words, in order of first
+// appearance. Words are compared case-insensitively; numbers and
+// punctuation are skipped. Hyphens, colons and apostrophes inside a word
+// are kept, so "linja-auto" and "EU:n" stay whole.
+func Tokenize(text string) []Token {
+	return plainSegmenter.Tokenize(text)
+}
+
+// Tokenize splits a text into its distinct words like the package
+// Tokenize, with the sentences of this segmenter
+func (s *Segmenter) Tokenize(text string) []Token {
+	var tokens []Token
+	seen := make(map[string]int)
+	for _, sentence := range s.Sentences(text) {
+		for _, word := range s.Words(sentence) {
+			word = strings.ToLower(word)
+			if i, ok := seen[word]; ok {
+				tokens[i].Occurrences++
+				continue
+			}
+			seen[word] = len(tokens)
+			tokens = append(tokens, Token{Word: word, Sentence: sentence, Occurrences: 1})
+		}
+	}
+	return tokens
+}
+
+// Sentences splits a text into its sentences, trimmed of surrounding
+// whitespace. A blank line also ends a sentence, so headings without
+// punctuation stand alone.
+func (s *Segmenter) Sentences(text string) []string {
+	var sentences []string
+	runes := []rune(text)
+	start := 0
+	flush := func(end int) {
+		if sentence := strings.TrimSpace(string(runes[start:end])); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		start = end
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if unicode.IsSpace(r) {
+			j, newlines := i, 0
+			for ; j < len(runes) && unicode.IsSpace(runes[j]); j++ {
+				if runes[j] == '\n' {
+					newlines++
+				}
+			}
+			if newlines >= 2 {
+				flush(i)
+			}
+			i = j - 1
+			continue
+		}
+		if !isTerminal(r) {
+			continue
+		}
+
+		// The sentence ends after the last of "?!", "..." and closing quotes
+		end := i + 1
+		for end < len(runes) && (isTerminal(runes[end]) || isClosing(runes[end])) {
+			end++
+		}
+		punctuation := string(runes[i:end])
+		i = end - 1
+		// 3.5, t.ex, www.yle.fi
+		if end < len(runes) && !unicode.IsSpace(runes[end]) {
+			continue
+		}
+		if s.continues(runes, end) {
+			continue
+		}
+		if strings.TrimRightFunc(punctuation, isClosing) == "." && s.isAbbreviation(wordBefore(runes, end-len([]rune(punctuation)))) {
+			continue
+		}
+		flush(end)
+	}
+	flush(len(runes))
+	return sentences
+}
+
+// continues reports whether the text after the punctuation ending at end
+// carries on the same sentence: its next word starts in lower case, as in
+// "”Tule!” hän sanoi" or after an ordinal
+func (s *Segmenter) continues(runes []rune, end int) bool {
+	for _, r := range runes[end:] {
+		if unicode.IsSpace(r) || isOpening(r) {
+			continue
+		}
+		return unicode.IsLower(r)
+	}
+	return false
+}
+
+// isAbbreviation reports whether the word before a period is an
+// abbreviation or an initial
+func (s *Segmenter) isAbbreviation(word string) bool {
+	if runes := []rune(word); len(runes) == 1 && unicode.IsUpper(runes[0]) {
+		return true
+	}
+	return s.abbreviations[strings.ToLower(word)]
+}
+
+// wordBefore returns the letters and inner periods before position end:
+// "bl.a" before the final period of "bl.a."
+func wordBefore(runes []rune, end int) string {
+	start := end
+	for start > 0 && (unicode.IsLetter(runes[start-1]) || runes[start-1] == '.') {
+		start--
+	}
+	return strings.Trim(string(runes[start:end]), ".")
+}
+
+func isTerminal(r rune) bool {
+	return r == '.' || r == '!' || r == '?' || r == '…'
+}
+
+func isOpening(r rune) bool {
+	return strings.ContainsRune("\"'“”„«»‘’([", r)
+}
+
+func isClosing(r rune) bool {
+	return strings.ContainsRune("\"'”»’)]", r)
+}
+
+// Words returns the words of a sentence as written
+func (s *Segmenter) Words(sentence string) []string {
+	var words []string
+	for _, field := range strings.FieldsFunc(sentence, func(r rune) bool {
+		return !isWordRune(r) && !isJoiner(r)
+	}) {
+		word := strings.TrimFunc(field, isJoiner)
+		if strings.IndexFunc(word, unicode.IsLetter) >= 0 {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// isJoiner reports whether r can join two parts of one word
+func isJoiner(r rune) bool {
+	return r == '-' || r == ':' || r == '\'' || r == '’'
+}
+
+// finnishAbbreviations never end a sentence. Those that often do, such as
+// "jne." and "yms.", are left out: before a capital they end it.
+var finnishAbbreviations = []string{
+	"esim", "mm", "ns", "ts", "vrt", "ks", "huom", "klo", "kpl", "nro", "puh",
+	"tri", "prof", "dos", "ap", "ip", "os", "n", "s", "v", "l", "em",
+}
+
+// swedishAbbreviations never end a sentence; "osv." and "m.m." may
+var swedishAbbreviations = []string{
+	"t.ex", "bl.a", "s.k", "d.v.s", "dvs", "p.g.a", "pga", "ca", "kl", "nr",
+	"jfr", "resp", "fr.o.m", "t.o.m", "f.d", "dr", "prof",
+}
+
+// englishAbbreviations never end a sentence; "etc." may
+var englishAbbreviations = []string{
+	"mr", "mrs", "ms", "dr", "prof", "st", "e.g", "i.e", "vs", "jr", "sr",
+	"fig", "approx", "cf",
+}
@@ -0,0 +1,47 @@
+package language
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSentences(t *testing.T) {
+	finnish := NewSegmenter(finnishAbbreviations...)
+	swedish := NewSegmenter(swedishAbbreviations...)
+
+	tests := []struct {
+		name      string
+		segmenter *Segmenter
+		text      string
+		want      []string
+	}{
+		{"terminals", finnish, "Kissa istuu. Koira haukkuu! Mitä nyt?", []string{"Kissa istuu.", "Koira haukkuu!", "Mitä nyt?"}},
+		{"abbreviation", finnish, "Ostin hedelmiä, esim. omenoita. Ne olivat hyviä.", []string{"Ostin hedelmiä, esim. omenoita.", "Ne olivat hyviä."}},
+		{"abbreviation before a capital", finnish, "Tapaaminen on klo 9. Tule ajoissa.", []string{"Tapaaminen on klo 9.", "Tule ajoissa."}},
+		{"ordinal", finnish, "Juhla on 5. toukokuuta. Tervetuloa!", []string{"Juhla on 5. toukokuuta.", "Tervetuloa!"}},
+		{"decimal", finnish, "Hinta nousi 3.5 prosenttia. Se on paljon.", []string{"Hinta nousi 3.5 prosenttia.", "Se on paljon."}},
+		{"initials", finnish, "J. K. Paasikivi oli presidentti. Hän asui Helsingissä.", []string{"J. K. Paasikivi oli presidentti.", "Hän asui Helsingissä."}},
+		{"quote continues", finnish, "”Tule tänne!” hän sanoi. Minä tulin.", []string{"”Tule tänne!” hän sanoi.", "Minä tulin."}},
+		{"quote closes", finnish, "Hän kysyi: ”Missä olet?” Kukaan ei vastannut.", []string{"Hän kysyi: ”Missä olet?”", "Kukaan ei vastannut."}},
+		{"ellipsis", finnish, "Odota... Nyt se tuli.", []string{"Odota...", "Nyt se tuli."}},
+		{"blank line", finnish, "Uutiset\n\nSataa lunta.\nTuulee.", []string{"Uutiset", "Sataa lunta.", "Tuulee."}},
+		{"swedish abbreviation", swedish, "Vi köpte frukt, t.ex. äpplen. Sedan gick vi hem.", []string{"Vi köpte frukt, t.ex. äpplen.", "Sedan gick vi hem."}},
+		{"swedish abbreviation before a capital", swedish, "Han kom bl.a. Anna. Hon var trött.", []string{"Han kom bl.a. Anna.", "Hon var trött."}},
+		{"no punctuation", finnish, "  Kissa istuu  ", []string{"Kissa istuu"}},
+		{"empty", finnish, " \n ", nil},
+	}
+
+	for _, tt := range tests {
+		if got := tt.segmenter.Sentences(tt.text); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: Sentences(%q) = %q, want %q", tt.name, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestWords(t *testing.T) {
+	got := NewSegmenter().Words("”Linja-autossa” on EU:n 3 lippua - ja se’s (kiva).")
+	want := []string{"Linja-autossa", "on", "EU:n", "lippua", "ja", "se’s", "kiva"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Words = %q, want %q", got, want)
+	}
+}
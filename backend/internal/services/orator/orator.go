@@ -0,0 +1,156 @@
+package orator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/BachirKhiati/lexia/internal/models"
+	"github.com/BachirKhiati/lexia/internal/services/srs"
+)
+
+var (
+	// ErrEmptyAttempt is returned when the target or the transcript is blank
+	ErrEmptyAttempt = errors.New("target and transcript are required")
+	// ErrTargetTooLong is returned for targets longer than maxTargetRunes
+	ErrTargetTooLong = errors.New("target phrase is too long")
+	// ErrTranscriptTooLong is returned for transcripts longer than
+	// maxTranscriptRunes
+	ErrTranscriptTooLong = errors.New("transcript is too long")
+)
+
+const (
+	defaultLanguage = "finnish"
+	// maxTargetRunes bounds the phrase of one attempt
+	maxTargetRunes = 500
+	// maxTranscriptRunes bounds what the recognizer heard, which is aligned
+	// against the target: a learner repeating or rambling still fits
+	maxTranscriptRunes = 4 * maxTargetRunes
+	// weakScore is the word score below which a saved word goes back to
+	// SRS review. One wrong length in a short word (kuka for kukka) is
+	// already below it, as it says another word.
+	weakScore = 90
+)
+
+// Schedule is the SRS state of one of the learner's saved words
+type Schedule struct {
+	WordID          int
+	EaseFactor      float64
+	RepetitionCount int
+	Interval        int
+}
+
+// Store persists attempts and reads and updates saved words
+type Store interface {
+	// SavedWords returns the user's saved words among words, keyed by the
+	// lowercase word or lemma that matched
+	SavedWords(ctx context.Context, userID int, language string, words []string) (map[string]Schedule, error)
+	// SaveAttempt stores the attempt and the reviews of its weak words,
+	// keyed by word ID, in one transaction. It sets the attempt's ID and
+	// creation time.
+	SaveAttempt(ctx context.Context, attempt *models.OratorAttempt, reviews map[int]srs.ReviewResult) error
+	// ListAttempts returns the user's attempts, newest first
+	ListAttempts(ctx context.Context, userID int, filter ListFilter) ([]models.OratorAttempt, error)
+}
+
+// ListFilter narrows an attempt listing. Zero fields match everything.
+type ListFilter struct {
+	Word  string // attempts whose target contains the word
+	Limit int
+}
+
+// Service scores pronunciation attempts and feeds weak words back into
+// spaced repetition
+type Service struct {
+	store Store
+	srs   *srs.Service
+}
+
+func NewService(store Store, srsService *srs.Service) *Service {
+	return &Service{store: store, srs: srsService}
+}
+
+// Submit scores an attempt and stores it. Saved words pronounced below
+// weakScore are reviewed with a failing SRS grade, so they come back for
+// practice the next day.
+func (s *Service) Submit(ctx context.Context, userID int, req models.OratorAttemptRequest) (*models.OratorAttempt, error) {
+	target := strings.TrimSpace(req.Target)
+	transcript := strings.TrimSpace(req.Transcript)
+	if len(words(target)) == 0 || transcript == "" {
+		return nil, ErrEmptyAttempt
+	}
+	if utf8.RuneCountInString(target) > maxTargetRunes {
+		return nil, ErrTargetTooLong
+	}
+	if utf8.RuneCountInString(transcript) > maxTranscriptRunes {
+		return nil, ErrTranscriptTooLong
+	}
+	language := req.Language
+	if language == "" {
+		language = defaultLanguage
+	}
+
+	score, wordScores := Score(target, transcript, language)
+	attempt := &models.OratorAttempt{
+		UserID:     userID,
+		Language:   language,
+		Target:     target,
+		Transcript: transcript,
+		Score:      score,
+		Words:      wordScores,
+	}
+
+	reviews, err := s.reschedule(ctx, userID, language, attempt.Words)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.SaveAttempt(ctx, attempt, reviews); err != nil {
+		return nil, fmt.Errorf("failed to save attempt: %w", err)
+	}
+	return attempt, nil
+}
+
+// reschedule links the scored words to the learner's saved words and
+// returns the reviews that send the weak ones back, by word ID
+func (s *Service) reschedule(ctx context.Context, userID int, language string, scores []models.WordScore) (map[int]srs.ReviewResult, error) {
+	lookup := make([]string, len(scores))
+	for i, ws := range scores {
+		lookup[i] = ws.Word
+	}
+	saved, err := s.store.SavedWords(ctx, userID, language, lookup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up saved words: %w", err)
+	}
+
+	reviews := make(map[int]srs.ReviewResult)
+	for i := range scores {
+		schedule, ok := saved[scores[i].Word]
+		if !ok {
+			continue
+		}
+		id := schedule.WordID
+		scores[i].WordID = &id
+		// A word repeated in the phrase is reviewed once
+		if _, ok := reviews[id]; ok || scores[i].Score >= weakScore {
+			continue
+		}
+
+		reviews[id] = s.srs.CalculateNextReview(qualityFor(scores[i].Score), schedule.EaseFactor, schedule.RepetitionCount, schedule.Interval)
+		scores[i].Rescheduled = true
+	}
+	return reviews, nil
+}
+
+// qualityFor maps a weak word score to a failing SM-2 grade: 0-24 is a
+// blackout, 25-49 wrong and 50 up to weakScore hard
+func qualityFor(score int) srs.Quality {
+	return min(srs.Quality(score/25), srs.QualityHard)
+}
+
+// Attempts lists the user's attempts, newest first
+func (s *Service) Attempts(ctx context.Context, userID int, filter ListFilter) ([]models.OratorAttempt, error) {
+	filter.Word = strings.ToLower(strings.TrimSpace(filter.Word))
+	return s.store.ListAttempts(ctx, userID, filter)
+}
@@ -0,0 +1,183 @@
+package orator
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BachirKhiati/lexia/internal/models"
+	"github.com/BachirKhiati/lexia/internal/services/srs"
+)
+
+// memoryStore is an in-memory Store for tests
+type memoryStore struct {
+	saved    map[string]Schedule
+	reviews  map[int]srs.ReviewResult
+	attempts []models.OratorAttempt
+	failSave error
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		saved: map[string]Schedule{
+			"kukka": {WordID: 1, EaseFactor: 2.5, RepetitionCount: 3, Interval: 15},
+			"talo":  {WordID: 2, EaseFactor: 2.5, RepetitionCount: 2, Interval: 6},
+		},
+		reviews: make(map[int]srs.ReviewResult),
+	}
+}
+
+func (m *memoryStore) SavedWords(ctx context.Context, userID int, language string, words []string) (map[string]Schedule, error) {
+	found := make(map[string]Schedule)
+	for _, w := range words {
+		if sc, ok := m.saved[w]; ok && userID == 1 {
+			found[w] = sc
+		}
+	}
+	return found, nil
+}
+
+func (m *memoryStore) SaveAttempt(ctx context.Context, attempt *models.OratorAttempt, reviews map[int]srs.ReviewResult) error {
+	if m.failSave != nil {
+		return m.failSave
+	}
+	for id, result := range reviews {
+		m.reviews[id] = result
+	}
+	attempt.ID = len(m.attempts) + 1
+	attempt.CreatedAt = time.Now()
+	m.attempts = append(m.attempts, *attempt)
+	return nil
+}
+
+func (m *memoryStore) ListAttempts(ctx context.Context, userID int, filter ListFilter) ([]models.OratorAttempt, error) {
+	var attempts []models.OratorAttempt
+	for i := len(m.attempts) - 1; i >= 0; i-- {
+		a := m.attempts[i]
+		if a.UserID != userID {
+			continue
+		}
+		for _, w := range a.Words {
+			if filter.Word == "" || w.Word == filter.Word {
+				attempts = append(attempts, a)
+				break
+			}
+		}
+	}
+	return attempts, nil
+}
+
+func TestSubmitReschedulesWeakSavedWords(t *testing.T) {
+	store := newMemoryStore()
+	service := NewService(store, srs.NewService())
+
+	attempt, err := service.Submit(context.Background(), 1, models.OratorAttemptRequest{
+		Target:     "Kukka on talossa.",
+		Transcript: "kuka on talossa",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attempt.ID == 0 || attempt.Language != "finnish" || len(attempt.Words) != 3 {
+		t.Fatalf("attempt = %+v", attempt)
+	}
+
+	// kukka is saved and missed its geminate: back to review tomorrow
+	kukka := attempt.Words[0]
+	if kukka.WordID == nil || *kukka.WordID != 1 || !kukka.Rescheduled || kukka.Score >= weakScore {
+		t.Errorf("kukka = %+v", kukka)
+	}
+	review, ok := store.reviews[1]
+	if !ok || review.Interval != 1 || review.RepetitionCount != 0 {
+		t.Errorf("kukka review = %+v, %v", review, ok)
+	}
+
+	// on is not saved; talossa is said well and is not a saved form
+	for _, ws := range attempt.Words[1:] {
+		if ws.WordID != nil || ws.Rescheduled {
+			t.Errorf("%s = %+v", ws.Word, ws)
+		}
+	}
+	if len(store.reviews) != 1 {
+		t.Errorf("reviews = %+v", store.reviews)
+	}
+}
+
+func TestSubmitKeepsReviewsWhenSaveFails(t *testing.T) {
+	store := newMemoryStore()
+	store.failSave = errors.New("connection lost")
+	service := NewService(store, srs.NewService())
+
+	_, err := service.Submit(context.Background(), 1, models.OratorAttemptRequest{Target: "kukka", Transcript: "kuka"})
+	if err == nil {
+		t.Fatal("expected the save error")
+	}
+	if len(store.reviews) != 0 {
+		t.Errorf("reviews were saved without the attempt: %+v", store.reviews)
+	}
+}
+
+func TestSubmitLeavesWellSpokenWordsAlone(t *testing.T) {
+	store := newMemoryStore()
+	service := NewService(store, srs.NewService())
+
+	attempt, err := service.Submit(context.Background(), 1, models.OratorAttemptRequest{Target: "talo", Transcript: "talo", Language: "finnish"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ws := attempt.Words[0]; ws.WordID == nil || ws.Rescheduled || ws.Score != 100 {
+		t.Errorf("talo = %+v", ws)
+	}
+	if len(store.reviews) != 0 {
+		t.Errorf("reviews = %+v", store.reviews)
+	}
+}
+
+func TestSubmitRejectsEmptyAttempts(t *testing.T) {
+	service := NewService(newMemoryStore(), srs.NewService())
+	for _, req := range []models.OratorAttemptRequest{
+		{Target: "talo"},
+		{Target: " ?! ", Transcript: "talo"},
+	} {
+		if _, err := service.Submit(context.Background(), 1, req); !errors.Is(err, ErrEmptyAttempt) {
+			t.Errorf("%+v: err = %v, want ErrEmptyAttempt", req, err)
+		}
+	}
+	long := models.OratorAttemptRequest{Target: strings.Repeat("talo ", 101), Transcript: "talo"}
+	if _, err := service.Submit(context.Background(), 1, long); !errors.Is(err, ErrTargetTooLong) {
+		t.Errorf("err = %v, want ErrTargetTooLong", err)
+	}
+	rambling := models.OratorAttemptRequest{Target: "talo", Transcript: strings.Repeat("talo ", 401)}
+	if _, err := service.Submit(context.Background(), 1, rambling); !errors.Is(err, ErrTranscriptTooLong) {
+		t.Errorf("err = %v, want ErrTranscriptTooLong", err)
+	}
+}
+
+func TestAttemptsByWord(t *testing.T) {
+	store := newMemoryStore()
+	service := NewService(store, srs.NewService())
+	ctx := context.Background()
+	for _, target := range []string{"talo", "kukka", "iso talo"} {
+		if _, err := service.Submit(ctx, 1, models.OratorAttemptRequest{Target: target, Transcript: target}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	attempts, err := service.Attempts(ctx, 1, ListFilter{Word: " Talo "})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(attempts) != 2 || attempts[0].Target != "iso talo" || attempts[1].Target != "talo" {
+		t.Errorf("attempts = %+v", attempts)
+	}
+}
+
+func TestQualityFor(t *testing.T) {
+	for score, want := range map[int]srs.Quality{0: srs.QualityBlackout, 30: srs.QualityWrong, 89: srs.QualityHard} {
+		if got := qualityFor(score); got != want {
+			t.Errorf("qualityFor(%d) = %d, want %d", score, got, want)
+		}
+	}
+}
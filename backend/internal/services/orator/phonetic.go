@@ -0,0 +1,228 @@
+package orator
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/BachirKhiati/lexia/internal/models"
+)
+
+// Kinds of pronunciation issues
+const (
+	IssueVowelLength     = "vowel_length"     // tuli for tuuli
+	IssueConsonantLength = "consonant_length" // kuka for kukka
+	IssueVowelQuality    = "vowel_quality"    // a for ä, o for ö, u for y
+	IssueSubstitution    = "substitution"     // another sound
+	IssueMissing         = "missing"          // a sound that was not heard
+	IssueExtra           = "extra"            // a sound that is not in the phrase
+)
+
+// Alignment costs. A wrong length or a front/back vowel swap is a near miss:
+// the learner produced the right sound, just not quite.
+const (
+	costLength  = 0.5
+	costQuality = 0.5
+	costOther   = 1.0
+)
+
+// vowelPairs are the front and back vowels Finnish keeps apart and learners
+// tend to merge
+var vowelPairs = map[rune]rune{
+	'ä': 'a', 'a': 'ä',
+	'ö': 'o', 'o': 'ö',
+	'y': 'u', 'u': 'y',
+}
+
+// segment is one sound of a phrase: a letter, long when written doubled
+type segment struct {
+	letter rune
+	long   bool
+	word   int // index of the target word it belongs to
+}
+
+func (s segment) String() string {
+	if s.long {
+		return string([]rune{s.letter, s.letter})
+	}
+	return string(s.letter)
+}
+
+func isVowel(r rune) bool {
+	return strings.ContainsRune("aeiouyäöå", r)
+}
+
+// words splits text into lowercase words of letters only
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+}
+
+// segments turns words into sounds. In Finnish a doubled letter is one long
+// sound (tuuli, kukka); in other languages every letter is its own segment.
+func segments(words []string, finnish bool) []segment {
+	var segs []segment
+	for w, word := range words {
+		runes := []rune(word)
+		for i := 0; i < len(runes); i++ {
+			seg := segment{letter: runes[i], word: w}
+			if finnish && i+1 < len(runes) && runes[i+1] == runes[i] {
+				seg.long = true
+				// Overlong runs (from a stuttering recognizer) count as long
+				for i+1 < len(runes) && runes[i+1] == runes[i] {
+					i++
+				}
+			}
+			segs = append(segs, seg)
+		}
+	}
+	return segs
+}
+
+// substitution returns the cost of hearing got for want, and the issue it is
+func substitution(want, got segment) (float64, string) {
+	switch {
+	case want.letter == got.letter && want.long == got.long:
+		return 0, ""
+	case want.letter == got.letter:
+		if isVowel(want.letter) {
+			return costLength, IssueVowelLength
+		}
+		return costLength, IssueConsonantLength
+	case vowelPairs[want.letter] == got.letter:
+		cost := costQuality
+		if want.long != got.long {
+			cost += costLength / 2
+		}
+		return cost, IssueVowelQuality
+	}
+	return costOther, IssueSubstitution
+}
+
+// step is one move of the alignment
+type step struct {
+	want, got int // segment indexes; -1 when absent
+	cost      float64
+	kind      string
+}
+
+// Moves of the alignment traceback
+const (
+	moveMatch   byte = iota // a target sound was heard, maybe as another
+	moveMissing             // a target sound was not heard
+	moveExtra               // a sound was heard that is not in the target
+)
+
+// align finds the cheapest way to turn the target sounds into the heard
+// ones (a weighted edit distance) and returns its steps in order. Only two
+// rows of costs are kept; the traceback takes one byte per cell.
+func align(target, heard []segment) []step {
+	n, m := len(target), len(heard)
+	moves := make([]byte, (n+1)*(m+1))
+	prev := make([]float64, m+1)
+	cur := make([]float64, m+1)
+	for j := 1; j <= m; j++ {
+		prev[j] = float64(j) * costOther
+		moves[j] = moveExtra
+	}
+	for i := 1; i <= n; i++ {
+		cur[0] = float64(i) * costOther
+		moves[i*(m+1)] = moveMissing
+		for j := 1; j <= m; j++ {
+			sub, _ := substitution(target[i-1], heard[j-1])
+			match, missing, extra := prev[j-1]+sub, prev[j]+costOther, cur[j-1]+costOther
+			// Ties prefer a match, then a missing sound
+			switch {
+			case match <= math.Min(missing, extra):
+				cur[j], moves[i*(m+1)+j] = match, moveMatch
+			case missing <= extra:
+				cur[j], moves[i*(m+1)+j] = missing, moveMissing
+			default:
+				cur[j], moves[i*(m+1)+j] = extra, moveExtra
+			}
+		}
+		prev, cur = cur, prev
+	}
+
+	var steps []step
+	i, j := n, m
+	for i > 0 || j > 0 {
+		switch moves[i*(m+1)+j] {
+		case moveMatch:
+			sub, kind := substitution(target[i-1], heard[j-1])
+			steps = append(steps, step{want: i - 1, got: j - 1, cost: sub, kind: kind})
+			i, j = i-1, j-1
+		case moveMissing:
+			steps = append(steps, step{want: i - 1, got: -1, cost: costOther, kind: IssueMissing})
+			i--
+		default:
+			steps = append(steps, step{want: -1, got: j - 1, cost: costOther, kind: IssueExtra})
+			j--
+		}
+	}
+
+	for l, r := 0, len(steps)-1; l < r; l, r = l+1, r-1 {
+		steps[l], steps[r] = steps[r], steps[l]
+	}
+	return steps
+}
+
+// Score compares what the learner was asked to say with what the speech
+// recognizer heard. The phrases are aligned sound by sound; Finnish
+// alignment tells long vowels and geminate consonants from short ones.
+// Scores run from 0 to 100, for the whole phrase and for each target word.
+func Score(target, transcript, language string) (int, []models.WordScore) {
+	finnish := language == "" || language == "finnish"
+	targetWords := words(target)
+	want := segments(targetWords, finnish)
+	got := segments(words(transcript), finnish)
+
+	scores := make([]models.WordScore, len(targetWords))
+	wordCost := make([]float64, len(targetWords))
+	wordLen := make([]int, len(targetWords))
+	for i, word := range targetWords {
+		scores[i].Word = word
+		scores[i].Issues = []models.PronunciationIssue{}
+	}
+	for _, seg := range want {
+		wordLen[seg.word]++
+	}
+
+	total := 0.0
+	lastWord := 0
+	for _, st := range align(want, got) {
+		word := lastWord
+		if st.want >= 0 {
+			word = want[st.want].word
+			lastWord = word
+		}
+		if st.cost == 0 || len(scores) == 0 {
+			continue
+		}
+		total += st.cost
+		wordCost[word] += st.cost
+
+		issue := models.PronunciationIssue{Kind: st.kind}
+		if st.want >= 0 {
+			issue.Expected = want[st.want].String()
+		}
+		if st.got >= 0 {
+			issue.Heard = got[st.got].String()
+		}
+		scores[word].Issues = append(scores[word].Issues, issue)
+	}
+
+	for i := range scores {
+		scores[i].Score = percent(wordCost[i], wordLen[i])
+	}
+	return percent(total, len(want)), scores
+}
+
+// percent turns an alignment cost over length sounds into a 0-100 score
+func percent(cost float64, length int) int {
+	if length == 0 {
+		return 0
+	}
+	return int(math.Round(100 * math.Max(0, 1-cost/float64(length))))
+}
@@ -0,0 +1,98 @@
+package orator
+
+import "testing"
+
+func TestScoreFinnishSounds(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		transcript string
+		kind       string
+		expected   string
+		heard      string
+	}{
+		{"short vowel for long", "tuuli", "tuli", IssueVowelLength, "uu", "u"},
+		{"long vowel for short", "tuli", "tuuli", IssueVowelLength, "u", "uu"},
+		{"single consonant for geminate", "kukka", "kuka", IssueConsonantLength, "kk", "k"},
+		{"geminate for single consonant", "mato", "matto", IssueConsonantLength, "t", "tt"},
+		{"a for ä", "pää", "paa", IssueVowelQuality, "ää", "aa"},
+		{"o for ö", "pöytä", "poytä", IssueVowelQuality, "ö", "o"},
+		{"u for y", "kyllä", "kullä", IssueVowelQuality, "y", "u"},
+		{"other sound", "talo", "kalo", IssueSubstitution, "t", "k"},
+		{"missing sound", "talot", "talo", IssueMissing, "t", ""},
+		{"extra sound", "talo", "talot", IssueExtra, "", "t"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, words := Score(tt.target, tt.transcript, "finnish")
+			if len(words) != 1 || len(words[0].Issues) != 1 {
+				t.Fatalf("words = %+v", words)
+			}
+			issue := words[0].Issues[0]
+			if issue.Kind != tt.kind || issue.Expected != tt.expected || issue.Heard != tt.heard {
+				t.Errorf("issue = %+v, want %s %q heard as %q", issue, tt.kind, tt.expected, tt.heard)
+			}
+			if score <= 0 || score >= 100 {
+				t.Errorf("score = %d", score)
+			}
+		})
+	}
+}
+
+func TestNearMissesCostLessThanWrongSounds(t *testing.T) {
+	length, _ := Score("tuuli", "tuli", "finnish")
+	quality, _ := Score("pää", "paa", "finnish")
+	wrong, _ := Score("tuuli", "tuoli", "finnish")
+	if length <= wrong || quality <= wrong {
+		t.Errorf("length %d, quality %d, wrong sound %d: near misses should score higher", length, quality, wrong)
+	}
+}
+
+func TestScorePerWord(t *testing.T) {
+	score, words := Score("Hyvää huomenta, Matti!", "hyvaa huomenta mati", "finnish")
+	if len(words) != 3 {
+		t.Fatalf("words = %+v", words)
+	}
+	if words[0].Word != "hyvää" || words[0].Score >= 100 || words[0].Issues[0].Kind != IssueVowelQuality {
+		t.Errorf("hyvää = %+v", words[0])
+	}
+	if words[1].Score != 100 || len(words[1].Issues) != 0 {
+		t.Errorf("huomenta = %+v", words[1])
+	}
+	if words[2].Score >= 100 || words[2].Issues[0].Kind != IssueConsonantLength {
+		t.Errorf("matti = %+v", words[2])
+	}
+	if score <= 0 || score >= 100 {
+		t.Errorf("score = %d", score)
+	}
+}
+
+func TestScoreIgnoresCaseAndPunctuation(t *testing.T) {
+	score, words := Score("Kiitos paljon!", "kiitos, PALJON", "finnish")
+	if score != 100 || words[0].Score != 100 || words[1].Score != 100 {
+		t.Errorf("score = %d, words %+v", score, words)
+	}
+}
+
+func TestScoreAcrossWordBoundaries(t *testing.T) {
+	// Recognizers sometimes join words; the sounds still line up
+	score, _ := Score("hyvää päivää", "hyvääpäivää", "finnish")
+	if score != 100 {
+		t.Errorf("score = %d, want 100", score)
+	}
+}
+
+func TestScoreNothingRecognized(t *testing.T) {
+	score, words := Score("kiitos", "hmm", "finnish")
+	if score != 0 || words[0].Score != 0 {
+		t.Errorf("score = %d, words %+v", score, words)
+	}
+}
+
+func TestDoubledLettersOutsideFinnish(t *testing.T) {
+	// Only Finnish treats doubled letters as one long sound
+	_, words := Score("book", "bok", "english")
+	if issue := words[0].Issues[0]; issue.Kind != IssueMissing {
+		t.Errorf("issue = %+v, want a missing letter", issue)
+	}
+}
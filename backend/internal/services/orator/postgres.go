@@ -0,0 +1,185 @@
+package orator
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/lib/pq"
+
+	"github.com/BachirKhiati/lexia/internal/models"
+	"github.com/BachirKhiati/lexia/internal/services/srs"
+)
+
+// PostgresStore keeps attempts in the orator_attempts and
+// orator_attempt_words tables and schedules reviews in words
+type PostgresStore struct {
+	db *sql.DB
+}
+
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+func (s *PostgresStore) SavedWords(ctx context.Context, userID int, language string, words []string) (map[string]Schedule, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, LOWER(word), LOWER(lemma), ease_factor, repetition_count, interval
+		FROM words
+		WHERE user_id = $1 AND language = $2
+			AND (LOWER(word) = ANY($3) OR LOWER(lemma) = ANY($3))
+		ORDER BY id
+	`, userID, language, pq.Array(words))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// The exact form wins over a lemma match; the oldest word wins ties
+	saved := make(map[string]Schedule)
+	byLemma := make(map[string]Schedule)
+	for rows.Next() {
+		var sc Schedule
+		var word, lemma string
+		if err := rows.Scan(&sc.WordID, &word, &lemma, &sc.EaseFactor, &sc.RepetitionCount, &sc.Interval); err != nil {
+			return nil, err
+		}
+		if _, ok := saved[word]; !ok {
+			saved[word] = sc
+		}
+		if _, ok := byLemma[lemma]; !ok {
+			byLemma[lemma] = sc
+		}
+	}
+	for lemma, sc := range byLemma {
+		if _, ok := saved[lemma]; !ok {
+			saved[lemma] = sc
+		}
+	}
+	return saved, rows.Err()
+}
+
+func (s *PostgresStore) SaveAttempt(ctx context.Context, attempt *models.OratorAttempt, reviews map[int]srs.ReviewResult) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO orator_attempts (user_id, language, target, transcript, score)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`, attempt.UserID, attempt.Language, attempt.Target, attempt.Transcript, attempt.Score).Scan(&attempt.ID, &attempt.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	for i, ws := range attempt.Words {
+		issues, err := json.Marshal(ws.Issues)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO orator_attempt_words (attempt_id, user_id, position, word, word_id, score, issues, rescheduled)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		`, attempt.ID, attempt.UserID, i, ws.Word, ws.WordID, ws.Score, issues, ws.Rescheduled)
+		if err != nil {
+			return err
+		}
+	}
+
+	for wordID, result := range reviews {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE words
+			SET ease_factor = $3,
+			    repetition_count = $4,
+			    interval = $5,
+			    next_review_at = $6,
+			    last_reviewed_at = NOW()
+			WHERE id = $1 AND user_id = $2
+		`, wordID, attempt.UserID, result.EaseFactor, result.RepetitionCount, result.Interval, result.NextReviewAt)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// maxListLimit bounds the attempts one listing returns
+const maxListLimit = 200
+
+func (s *PostgresStore) ListAttempts(ctx context.Context, userID int, filter ListFilter) ([]models.OratorAttempt, error) {
+	limit := filter.Limit
+	switch {
+	case limit <= 0:
+		limit = 50
+	case limit > maxListLimit:
+		limit = maxListLimit
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT a.id, a.user_id, a.language, a.target, a.transcript, a.score, a.created_at
+		FROM orator_attempts a
+		WHERE a.user_id = $1
+			AND ($2 = '' OR EXISTS (
+				SELECT 1 FROM orator_attempt_words w
+				WHERE w.attempt_id = a.id AND w.user_id = $1 AND w.word = $2
+			))
+		ORDER BY a.created_at DESC, a.id DESC
+		LIMIT $3
+	`, userID, filter.Word, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attempts := []models.OratorAttempt{}
+	index := make(map[int]int)
+	var ids []int
+	for rows.Next() {
+		var a models.OratorAttempt
+		if err := rows.Scan(&a.ID, &a.UserID, &a.Language, &a.Target, &a.Transcript, &a.Score, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		index[a.ID] = len(attempts)
+		ids = append(ids, a.ID)
+		attempts = append(attempts, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(attempts) == 0 {
+		return attempts, nil
+	}
+
+	wordRows, err := s.db.QueryContext(ctx, `
+		SELECT attempt_id, word, word_id, score, issues, rescheduled
+		FROM orator_attempt_words
+		WHERE attempt_id = ANY($1)
+		ORDER BY attempt_id, position
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer wordRows.Close()
+
+	for wordRows.Next() {
+		var attemptID int
+		var ws models.WordScore
+		var wordID sql.NullInt64
+		var issues []byte
+		if err := wordRows.Scan(&attemptID, &ws.Word, &wordID, &ws.Score, &issues, &ws.Rescheduled); err != nil {
+			return nil, err
+		}
+		if wordID.Valid {
+			id := int(wordID.Int64)
+			ws.WordID = &id
+		}
+		if err := json.Unmarshal(issues, &ws.Issues); err != nil {
+			return nil, err
+		}
+		a := &attempts[index[attemptID]]
+		a.Words = append(a.Words, ws)
+	}
+	return attempts, wordRows.Err()
+}
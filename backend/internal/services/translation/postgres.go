@@ -0,0 +1,164 @@
+package translation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/BachirKhiati/lexia/internal/models"
+)
+
+// PostgresStore keeps translation memories in the translation_memory table
+type PostgresStore struct {
+	db *sql.DB
+}
+
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+const entryColumns = `
+	id, user_id, source_language, target_language, source_text, source_hash,
+	translation, machine_translation, edited, pinned, use_count, created_at, updated_at
+`
+
+func scanEntry(row interface{ Scan(...any) error }) (*models.TranslationEntry, error) {
+	var e models.TranslationEntry
+	err := row.Scan(
+		&e.ID, &e.UserID, &e.SourceLanguage, &e.TargetLanguage, &e.SourceText, &e.SourceHash,
+		&e.Translation, &e.MachineTranslation, &e.Edited, &e.Pinned, &e.UseCount, &e.CreatedAt, &e.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	return &e, err
+}
+
+func (s *PostgresStore) Find(ctx context.Context, userID int, from, to, hash string) (*models.TranslationEntry, error) {
+	return scanEntry(s.db.QueryRowContext(ctx, `
+		SELECT `+entryColumns+`
+		FROM translation_memory
+		WHERE user_id = $1 AND source_language = $2 AND target_language = $3 AND source_hash = $4
+	`, userID, from, to, hash))
+}
+
+func (s *PostgresStore) Create(ctx context.Context, entry *models.TranslationEntry) error {
+	stored, err := scanEntry(s.db.QueryRowContext(ctx, `
+		INSERT INTO translation_memory (user_id, source_language, target_language, source_text, source_hash, translation, machine_translation)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id, source_language, target_language, source_hash)
+		DO UPDATE SET use_count = translation_memory.use_count + 1
+		RETURNING `+entryColumns,
+		entry.UserID, entry.SourceLanguage, entry.TargetLanguage, entry.SourceText, entry.SourceHash,
+		entry.Translation, entry.MachineTranslation))
+	if err != nil {
+		return err
+	}
+	*entry = *stored
+	return nil
+}
+
+func (s *PostgresStore) MarkUsed(ctx context.Context, id int) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE translation_memory SET use_count = use_count + 1 WHERE id = $1`, id)
+	return err
+}
+
+func (s *PostgresStore) Get(ctx context.Context, userID, id int) (*models.TranslationEntry, error) {
+	return scanEntry(s.db.QueryRowContext(ctx, `
+		SELECT `+entryColumns+`
+		FROM translation_memory
+		WHERE id = $1 AND user_id = $2
+	`, id, userID))
+}
+
+func (s *PostgresStore) Update(ctx context.Context, entry *models.TranslationEntry) error {
+	err := s.db.QueryRowContext(ctx, `
+		UPDATE translation_memory
+		SET translation = $3, edited = $4, pinned = $5, updated_at = NOW()
+		WHERE id = $1 AND user_id = $2
+		RETURNING updated_at
+	`, entry.ID, entry.UserID, entry.Translation, entry.Edited, entry.Pinned).Scan(&entry.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	return err
+}
+
+func (s *PostgresStore) Delete(ctx context.Context, userID, id int) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM translation_memory WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// likePattern matches query anywhere, with LIKE wildcards in it taken literally
+func likePattern(query string) string {
+	if query == "" {
+		return ""
+	}
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(query)
+	return "%" + escaped + "%"
+}
+
+// maxSearchLimit bounds the entries one search returns
+const maxSearchLimit = 200
+
+func (s *PostgresStore) Search(ctx context.Context, userID int, filter SearchFilter) ([]models.TranslationEntry, error) {
+	limit := filter.Limit
+	switch {
+	case limit <= 0:
+		limit = 50
+	case limit > maxSearchLimit:
+		limit = maxSearchLimit
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+entryColumns+`
+		FROM translation_memory
+		WHERE user_id = $1
+			AND ($2 = '' OR source_text ILIKE $2 OR translation ILIKE $2)
+			AND ($3 = '' OR source_language = $3)
+			AND ($4 = '' OR target_language = $4)
+			AND (NOT $5 OR pinned)
+		ORDER BY pinned DESC, updated_at DESC, id DESC
+		LIMIT $6
+	`, userID, likePattern(filter.Query), filter.SourceLanguage, filter.TargetLanguage, filter.PinnedOnly, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanEntries(rows)
+}
+
+func (s *PostgresStore) PinnedWithin(ctx context.Context, userID int, from, to, text string, limit int) ([]models.TranslationEntry, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+entryColumns+`
+		FROM translation_memory
+		WHERE user_id = $1 AND pinned AND source_language = $2 AND target_language = $3
+			AND STRPOS(LOWER($4), LOWER(source_text)) > 0
+		ORDER BY LENGTH(source_text) DESC, id
+		LIMIT $5
+	`, userID, from, to, text, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanEntries(rows)
+}
+
+func scanEntries(rows *sql.Rows) ([]models.TranslationEntry, error) {
+	defer rows.Close()
+
+	entries := []models.TranslationEntry{}
+	for rows.Next() {
+		entry, err := scanEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, *entry)
+	}
+	return entries, rows.Err()
+}
@@ -0,0 +1,190 @@
+package translation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/BachirKhiati/lexia/internal/models"
+)
+
+const (
+	defaultFromLanguage = "finnish"
+	defaultToLanguage   = "english"
+	// MaxTextRunes bounds a sentence or passage, and an edited translation
+	MaxTextRunes = 5000
+	// maxPinnedMatches bounds the pinned translations returned with a new one
+	maxPinnedMatches = 10
+)
+
+var (
+	// ErrNotFound is returned for entries that do not exist or belong to
+	// another user
+	ErrNotFound = errors.New("translation not found")
+	// ErrEmptyText is returned for blank input or blank edited translations
+	ErrEmptyText = errors.New("text is empty")
+	// ErrTextTooLong is returned for text longer than MaxTextRunes
+	ErrTextTooLong = fmt.Errorf("text is longer than %d characters", MaxTextRunes)
+)
+
+// AIService is the part of the AI service translations need
+type AIService interface {
+	Translate(ctx context.Context, text string, fromLang string, toLang string) (string, error)
+}
+
+// Store persists translation memories
+type Store interface {
+	// Find returns ErrNotFound unless the user has an entry for the input
+	Find(ctx context.Context, userID int, from, to, hash string) (*models.TranslationEntry, error)
+	// Create stores a new entry. If the same input was stored meanwhile,
+	// entry is filled in from the stored one instead.
+	Create(ctx context.Context, entry *models.TranslationEntry) error
+	MarkUsed(ctx context.Context, id int) error
+	// Get, Update and Delete return ErrNotFound unless the entry belongs to userID
+	Get(ctx context.Context, userID, id int) (*models.TranslationEntry, error)
+	Update(ctx context.Context, entry *models.TranslationEntry) error
+	Delete(ctx context.Context, userID, id int) error
+	// Search returns matching entries, pinned ones first, then most recent
+	Search(ctx context.Context, userID int, filter SearchFilter) ([]models.TranslationEntry, error)
+	// PinnedWithin returns pinned entries whose source text occurs in text,
+	// ignoring case, longest first
+	PinnedWithin(ctx context.Context, userID int, from, to, text string, limit int) ([]models.TranslationEntry, error)
+}
+
+// SearchFilter narrows a search. Zero fields match everything.
+type SearchFilter struct {
+	Query          string // substring of the source text or the translation
+	SourceLanguage string
+	TargetLanguage string
+	PinnedOnly     bool
+	Limit          int
+}
+
+// Service translates text through a per-user translation memory
+type Service struct {
+	store Store
+	ai    AIService
+}
+
+func NewService(store Store, aiService AIService) *Service {
+	return &Service{store: store, ai: aiService}
+}
+
+// Translate returns the learner's stored translation of an identical input
+// if there is one, and otherwise asks the AI and remembers the answer
+func (s *Service) Translate(ctx context.Context, userID int, req models.TranslateRequest) (*models.TranslateResponse, error) {
+	text, err := checkText(req.Text)
+	if err != nil {
+		return nil, err
+	}
+	from, to := req.FromLanguage, req.ToLanguage
+	if from == "" {
+		from = defaultFromLanguage
+	}
+	if to == "" {
+		to = defaultToLanguage
+	}
+	hash := sourceHash(text)
+
+	entry, err := s.store.Find(ctx, userID, from, to, hash)
+	if err == nil {
+		if err := s.store.MarkUsed(ctx, entry.ID); err != nil {
+			return nil, err
+		}
+		entry.UseCount++
+		return &models.TranslateResponse{Entry: entry, Reused: true}, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	translated, err := s.ai.Translate(ctx, text, from, to)
+	if err != nil {
+		return nil, err
+	}
+	translated = strings.TrimSpace(translated)
+	entry = &models.TranslationEntry{
+		UserID:             userID,
+		SourceLanguage:     from,
+		TargetLanguage:     to,
+		SourceText:         text,
+		SourceHash:         hash,
+		Translation:        translated,
+		MachineTranslation: translated,
+		UseCount:           1,
+	}
+	if err := s.store.Create(ctx, entry); err != nil {
+		return nil, fmt.Errorf("failed to save translation: %w", err)
+	}
+
+	pinned, err := s.store.PinnedWithin(ctx, userID, from, to, text, maxPinnedMatches)
+	if err != nil {
+		return nil, err
+	}
+	return &models.TranslateResponse{Entry: entry, PinnedMatches: pinned}, nil
+}
+
+// Update edits a stored translation or changes whether it is pinned.
+// Edits replace the translation that is reused; the AI's version is kept.
+func (s *Service) Update(ctx context.Context, userID, id int, req models.UpdateTranslationRequest) (*models.TranslationEntry, error) {
+	entry, err := s.store.Get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Translation != nil {
+		translation, err := checkText(*req.Translation)
+		if err != nil {
+			return nil, err
+		}
+		entry.Translation = translation
+		entry.Edited = translation != entry.MachineTranslation
+	}
+	if req.Pinned != nil {
+		entry.Pinned = *req.Pinned
+	}
+
+	if err := s.store.Update(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Get returns one of the user's entries
+func (s *Service) Get(ctx context.Context, userID, id int) (*models.TranslationEntry, error) {
+	return s.store.Get(ctx, userID, id)
+}
+
+// Delete forgets one of the user's entries
+func (s *Service) Delete(ctx context.Context, userID, id int) error {
+	return s.store.Delete(ctx, userID, id)
+}
+
+// Search lists the user's entries, pinned ones first
+func (s *Service) Search(ctx context.Context, userID int, filter SearchFilter) ([]models.TranslationEntry, error) {
+	filter.Query = strings.TrimSpace(filter.Query)
+	return s.store.Search(ctx, userID, filter)
+}
+
+// checkText trims text and enforces the length limits
+func checkText(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", ErrEmptyText
+	}
+	if utf8.RuneCountInString(text) > MaxTextRunes {
+		return "", ErrTextTooLong
+	}
+	return text, nil
+}
+
+// sourceHash identifies identical inputs: the same words in the same case,
+// however they are spaced
+func sourceHash(text string) string {
+	sum := sha256.Sum256([]byte(strings.Join(strings.Fields(text), " ")))
+	return hex.EncodeToString(sum[:])
+}
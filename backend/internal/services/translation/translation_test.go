@@ -0,0 +1,257 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BachirKhiati/lexia/internal/models"
+	"github.com/BachirKhiati/lexia/internal/services/ai"
+)
+
+// memoryStore is an in-memory Store for tests
+type memoryStore struct {
+	entries []*models.TranslationEntry
+}
+
+func (m *memoryStore) Find(ctx context.Context, userID int, from, to, hash string) (*models.TranslationEntry, error) {
+	for _, e := range m.entries {
+		if e.UserID == userID && e.SourceLanguage == from && e.TargetLanguage == to && e.SourceHash == hash {
+			copy := *e
+			return &copy, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (m *memoryStore) Create(ctx context.Context, entry *models.TranslationEntry) error {
+	entry.ID = len(m.entries) + 1
+	entry.CreatedAt = time.Now()
+	entry.UpdatedAt = entry.CreatedAt
+	stored := *entry
+	m.entries = append(m.entries, &stored)
+	return nil
+}
+
+func (m *memoryStore) MarkUsed(ctx context.Context, id int) error {
+	m.entries[id-1].UseCount++
+	return nil
+}
+
+func (m *memoryStore) Get(ctx context.Context, userID, id int) (*models.TranslationEntry, error) {
+	if id < 1 || id > len(m.entries) || m.entries[id-1] == nil || m.entries[id-1].UserID != userID {
+		return nil, ErrNotFound
+	}
+	copy := *m.entries[id-1]
+	return &copy, nil
+}
+
+func (m *memoryStore) Update(ctx context.Context, entry *models.TranslationEntry) error {
+	stored := *entry
+	m.entries[entry.ID-1] = &stored
+	return nil
+}
+
+func (m *memoryStore) Delete(ctx context.Context, userID, id int) error {
+	if _, err := m.Get(ctx, userID, id); err != nil {
+		return err
+	}
+	m.entries[id-1] = nil
+	return nil
+}
+
+func (m *memoryStore) Search(ctx context.Context, userID int, filter SearchFilter) ([]models.TranslationEntry, error) {
+	var found []models.TranslationEntry
+	query := strings.ToLower(filter.Query)
+	for _, e := range m.entries {
+		if e == nil || e.UserID != userID || (filter.PinnedOnly && !e.Pinned) {
+			continue
+		}
+		if strings.Contains(strings.ToLower(e.SourceText), query) || strings.Contains(strings.ToLower(e.Translation), query) {
+			found = append(found, *e)
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].Pinned && !found[j].Pinned })
+	return found, nil
+}
+
+func (m *memoryStore) PinnedWithin(ctx context.Context, userID int, from, to, text string, limit int) ([]models.TranslationEntry, error) {
+	var found []models.TranslationEntry
+	for _, e := range m.entries {
+		if e != nil && e.UserID == userID && e.Pinned && strings.Contains(strings.ToLower(text), strings.ToLower(e.SourceText)) {
+			found = append(found, *e)
+		}
+	}
+	return found, nil
+}
+
+func newTestService(t *testing.T, fake *ai.FakeProvider) (*Service, *memoryStore) {
+	t.Helper()
+	aiService, err := ai.NewFakeService(fake)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := &memoryStore{}
+	return NewService(store, aiService), store
+}
+
+func TestTranslateReusesIdenticalInput(t *testing.T) {
+	fake := ai.NewFakeProvider("fake").Script(ai.TaskTranslation, ai.Reply(" I drink coffee. "))
+	service, _ := newTestService(t, fake)
+	ctx := context.Background()
+
+	first, err := service.Translate(ctx, 1, models.TranslateRequest{Text: "Juon kahvia."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Reused || first.Entry.Translation != "I drink coffee." || first.Entry.SourceLanguage != "finnish" || first.Entry.TargetLanguage != "english" {
+		t.Errorf("first = %+v, entry %+v", first, first.Entry)
+	}
+
+	again, err := service.Translate(ctx, 1, models.TranslateRequest{Text: "  Juon   kahvia. ", FromLanguage: "finnish", ToLanguage: "english"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !again.Reused || again.Entry.ID != first.Entry.ID || again.Entry.UseCount != 2 {
+		t.Errorf("again = %+v, entry %+v", again, again.Entry)
+	}
+	if n := fake.CallCount(ai.TaskTranslation); n != 1 {
+		t.Errorf("AI called %d times, want 1", n)
+	}
+
+	// Another user, or another target language, is a new input
+	if other, _ := service.Translate(ctx, 2, models.TranslateRequest{Text: "Juon kahvia."}); other == nil || other.Reused {
+		t.Errorf("other user = %+v", other)
+	}
+	if swedish, _ := service.Translate(ctx, 1, models.TranslateRequest{Text: "Juon kahvia.", ToLanguage: "swedish"}); swedish == nil || swedish.Reused {
+		t.Errorf("other language = %+v", swedish)
+	}
+}
+
+func TestEditedTranslationIsReused(t *testing.T) {
+	fake := ai.NewFakeProvider("fake").Script(ai.TaskTranslation, ai.Reply("The cat sleeps."))
+	service, _ := newTestService(t, fake)
+	ctx := context.Background()
+
+	result, err := service.Translate(ctx, 1, models.TranslateRequest{Text: "Kissa nukkuu."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	edit := "The cat is sleeping."
+	pinned := true
+	entry, err := service.Update(ctx, 1, result.Entry.ID, models.UpdateTranslationRequest{Translation: &edit, Pinned: &pinned})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !entry.Edited || !entry.Pinned || entry.MachineTranslation != "The cat sleeps." {
+		t.Errorf("entry = %+v", entry)
+	}
+
+	again, err := service.Translate(ctx, 1, models.TranslateRequest{Text: "Kissa nukkuu."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Entry.Translation != edit || !again.Entry.Pinned {
+		t.Errorf("reused entry = %+v", again.Entry)
+	}
+
+	// Restoring the AI's version is no longer an edit
+	machine := "The cat sleeps."
+	entry, err = service.Update(ctx, 1, result.Entry.ID, models.UpdateTranslationRequest{Translation: &machine})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Edited || !entry.Pinned {
+		t.Errorf("entry = %+v", entry)
+	}
+}
+
+func TestTranslateReturnsPinnedMatches(t *testing.T) {
+	fake := ai.NewFakeProvider("fake").Script(ai.TaskTranslation, ai.Reply("Good morning!"), ai.Reply("Good morning! How are you?"))
+	service, _ := newTestService(t, fake)
+	ctx := context.Background()
+
+	greeting, err := service.Translate(ctx, 1, models.TranslateRequest{Text: "Hyvää huomenta!"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	edit, pinned := "Morning!", true
+	if _, err := service.Update(ctx, 1, greeting.Entry.ID, models.UpdateTranslationRequest{Translation: &edit, Pinned: &pinned}); err != nil {
+		t.Fatal(err)
+	}
+
+	passage, err := service.Translate(ctx, 1, models.TranslateRequest{Text: "Hyvää huomenta! Mitä kuuluu?"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if passage.Reused || len(passage.PinnedMatches) != 1 || passage.PinnedMatches[0].Translation != "Morning!" {
+		t.Errorf("passage = %+v", passage)
+	}
+}
+
+func TestUpdateChecksOwnerAndText(t *testing.T) {
+	fake := ai.NewFakeProvider("fake").Script(ai.TaskTranslation, ai.Reply("house"))
+	service, _ := newTestService(t, fake)
+	ctx := context.Background()
+
+	result, err := service.Translate(ctx, 1, models.TranslateRequest{Text: "talo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pinned := true
+	if _, err := service.Update(ctx, 2, result.Entry.ID, models.UpdateTranslationRequest{Pinned: &pinned}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("other user's update err = %v, want ErrNotFound", err)
+	}
+	blank := "  "
+	if _, err := service.Update(ctx, 1, result.Entry.ID, models.UpdateTranslationRequest{Translation: &blank}); !errors.Is(err, ErrEmptyText) {
+		t.Errorf("blank edit err = %v, want ErrEmptyText", err)
+	}
+	if err := service.Delete(ctx, 2, result.Entry.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("other user's delete err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestTranslateRejectsBadText(t *testing.T) {
+	fake := ai.NewFakeProvider("fake")
+	service, _ := newTestService(t, fake)
+	ctx := context.Background()
+
+	if _, err := service.Translate(ctx, 1, models.TranslateRequest{Text: " \n "}); !errors.Is(err, ErrEmptyText) {
+		t.Errorf("err = %v, want ErrEmptyText", err)
+	}
+	if _, err := service.Translate(ctx, 1, models.TranslateRequest{Text: strings.Repeat("ä", MaxTextRunes+1)}); !errors.Is(err, ErrTextTooLong) {
+		t.Errorf("err = %v, want ErrTextTooLong", err)
+	}
+	if n := fake.CallCount(ai.TaskTranslation); n != 0 {
+		t.Errorf("AI called %d times", n)
+	}
+}
+
+func TestSearchPutsPinnedFirst(t *testing.T) {
+	fake := ai.NewFakeProvider("fake").Script(ai.TaskTranslation, ai.Reply("I read a book."), ai.Reply("The book is red."))
+	service, _ := newTestService(t, fake)
+	ctx := context.Background()
+
+	if _, err := service.Translate(ctx, 1, models.TranslateRequest{Text: "Luen kirjaa."}); err != nil {
+		t.Fatal(err)
+	}
+	second, err := service.Translate(ctx, 1, models.TranslateRequest{Text: "Kirja on punainen."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pinned := true
+	if _, err := service.Update(ctx, 1, second.Entry.ID, models.UpdateTranslationRequest{Pinned: &pinned}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := service.Search(ctx, 1, SearchFilter{Query: " BOOK "})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].SourceText != "Kirja on punainen." {
+		t.Errorf("entries = %+v", entries)
+	}
+}
@@ -0,0 +1,79 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/BachirKhiati/lexia/internal/config"
+)
+
+// espeakVoices maps the app's language names to espeak-ng voices
+var espeakVoices = map[string]string{
+	"finnish": "fi",
+	"swedish": "sv",
+	"english": "en",
+	"spanish": "es",
+	"french":  "fr",
+	"german":  "de",
+}
+
+func init() {
+	RegisterEngine("espeak", func(cfg config.TTSConfig) (Engine, error) {
+		return NewEspeakEngine(cfg.EspeakPath, cfg.SpeechRate)
+	})
+}
+
+// EspeakEngine runs the local espeak-ng binary, so speech works offline.
+// It produces WAV audio.
+type EspeakEngine struct {
+	binary string
+	rate   int // words per minute; 0 keeps the espeak-ng default
+}
+
+// NewEspeakEngine finds binary on the PATH unless it is a path itself
+func NewEspeakEngine(binary string, rate int) (*EspeakEngine, error) {
+	if binary == "" {
+		binary = "espeak-ng"
+	}
+	path, err := exec.LookPath(binary)
+	if err != nil {
+		return nil, fmt.Errorf("espeak-ng not found: %w", err)
+	}
+	return &EspeakEngine{binary: path, rate: rate}, nil
+}
+
+func (e *EspeakEngine) Name() string {
+	return fmt.Sprintf("espeak-ng/%d", e.rate)
+}
+
+func (e *EspeakEngine) Synthesize(ctx context.Context, text string, language string) ([]byte, error) {
+	voice, ok := espeakVoices[language]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedLanguage, language)
+	}
+
+	// The text goes through stdin so it is never parsed as options
+	args := []string{"-v", voice, "--stdout", "--stdin"}
+	if e.rate > 0 {
+		args = append(args, "-s", fmt.Sprint(e.rate))
+	}
+	cmd := exec.CommandContext(ctx, e.binary, args...)
+	cmd.Stdin = strings.NewReader(text)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("espeak-ng failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	if stdout.Len() == 0 {
+		return nil, fmt.Errorf("espeak-ng produced no audio")
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,65 @@
+package tts
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/BachirKhiati/lexia/internal/config"
+)
+
+// fakeEspeak installs a script that stands in for espeak-ng: it prints its
+// arguments and its input, so tests can see how it was called
+func fakeEspeak(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("needs a POSIX shell")
+	}
+	path := filepath.Join(t.TempDir(), "espeak-ng")
+	script := "#!/bin/sh\necho \"RIFF $*\"\ncat\n"
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestEspeakEngine(t *testing.T) {
+	engine, err := NewEspeakEngine(fakeEspeak(t), 120)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	audio, err := engine.Synthesize(context.Background(), "-v en kissa", "finnish")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The voice comes from the language and the text arrives on stdin,
+	// where a leading dash cannot be taken for an option
+	if got := string(audio); got != "RIFF -v fi --stdout --stdin -s 120\n-v en kissa" {
+		t.Errorf("audio = %q", got)
+	}
+	if engine.Name() != "espeak-ng/120" {
+		t.Errorf("name = %q", engine.Name())
+	}
+}
+
+func TestEspeakEngineRejectsUnknownLanguage(t *testing.T) {
+	engine, err := NewEspeakEngine(fakeEspeak(t), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := engine.Synthesize(context.Background(), "talo", "klingon"); !errors.Is(err, ErrUnsupportedLanguage) {
+		t.Errorf("err = %v, want ErrUnsupportedLanguage", err)
+	}
+}
+
+func TestEspeakEngineNeedsBinary(t *testing.T) {
+	_, err := NewEngine(config.TTSConfig{Engine: "espeak", EspeakPath: filepath.Join(t.TempDir(), "missing")})
+	if err == nil || !strings.Contains(err.Error(), "espeak-ng not found") {
+		t.Errorf("err = %v, want the missing binary reported", err)
+	}
+}
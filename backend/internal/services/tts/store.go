@@ -0,0 +1,92 @@
+package tts
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// Store keeps synthesized clips by key
+type Store interface {
+	Has(key string) bool
+	Put(key string, audio []byte) error
+	// Open returns ErrNotFound for keys that are not stored
+	Open(key string) (io.ReadSeekCloser, error)
+}
+
+// validKey matches the keys made by Service.Key; anything else is rejected
+// before it can reach the filesystem
+var validKey = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// DiskStore keeps clips as files under a directory, fanned out by the first
+// two characters of the key
+type DiskStore struct {
+	dir string
+}
+
+// NewDiskStore creates dir if needed
+func NewDiskStore(dir string) (*DiskStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create audio cache: %w", err)
+	}
+	return &DiskStore{dir: dir}, nil
+}
+
+func (d *DiskStore) path(key string) (string, bool) {
+	if !validKey.MatchString(key) {
+		return "", false
+	}
+	return filepath.Join(d.dir, key[:2], key), true
+}
+
+func (d *DiskStore) Has(key string) bool {
+	path, ok := d.path(key)
+	if !ok {
+		return false
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// Put writes the clip through a temporary file so readers never see a
+// partial one
+func (d *DiskStore) Put(key string, audio []byte) error {
+	path, ok := d.path(key)
+	if !ok {
+		return fmt.Errorf("invalid audio key %q", key)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(audio); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (d *DiskStore) Open(key string) (io.ReadSeekCloser, error) {
+	path, ok := d.path(key)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
@@ -0,0 +1,142 @@
+package tts
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/BachirKhiati/lexia/internal/config"
+)
+
+var (
+	// ErrUnsupportedLanguage is returned by engines without a voice for a language
+	ErrUnsupportedLanguage = errors.New("no voice for language")
+	// ErrTextTooLong is returned for text longer than MaxTextRunes
+	ErrTextTooLong = errors.New("text too long to synthesize")
+	// ErrNotFound is returned for audio that has not been synthesized
+	ErrNotFound = errors.New("audio not found")
+)
+
+const (
+	// MaxTextRunes bounds the text of a single clip: a word or an example sentence
+	MaxTextRunes = 300
+	// URLPrefix is where the API serves stored clips, followed by their key
+	URLPrefix = "/api/v1/audio/"
+)
+
+// Engine turns text into speech. Name identifies the engine and its voice
+// settings: clips are cached per name, so it must change whenever the
+// produced audio would.
+type Engine interface {
+	Name() string
+	Synthesize(ctx context.Context, text string, language string) ([]byte, error)
+}
+
+// EngineFactory builds an engine from configuration
+type EngineFactory func(cfg config.TTSConfig) (Engine, error)
+
+var (
+	enginesMu sync.RWMutex
+	engines   = make(map[string]EngineFactory)
+)
+
+// RegisterEngine makes an engine available to NewEngine. Engine files call
+// it from init() so adding a backend is a single new file.
+func RegisterEngine(name string, factory EngineFactory) {
+	enginesMu.Lock()
+	defer enginesMu.Unlock()
+
+	if _, exists := engines[name]; exists {
+		panic(fmt.Sprintf("tts: engine %q registered twice", name))
+	}
+	engines[name] = factory
+}
+
+// NewEngine builds the engine named by cfg.Engine. It returns a nil engine
+// (and nil error) when text-to-speech is turned off.
+func NewEngine(cfg config.TTSConfig) (Engine, error) {
+	if cfg.Engine == "" || cfg.Engine == "none" {
+		return nil, nil
+	}
+
+	enginesMu.RLock()
+	factory, ok := engines[cfg.Engine]
+	enginesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown TTS engine %q (available: %s)", cfg.Engine, strings.Join(Engines(), ", "))
+	}
+	return factory(cfg)
+}
+
+// Engines lists the registered engine names
+func Engines() []string {
+	enginesMu.RLock()
+	defer enginesMu.RUnlock()
+
+	names := make([]string, 0, len(engines))
+	for name := range engines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Service synthesizes clips once and serves them from the store
+type Service struct {
+	engine Engine
+	store  Store
+}
+
+func NewService(engine Engine, store Store) *Service {
+	return &Service{engine: engine, store: store}
+}
+
+// Key identifies the clip of text in language spoken by the engine
+func (s *Service) Key(text string, language string) string {
+	sum := sha256.Sum256([]byte(s.engine.Name() + "\x00" + language + "\x00" + normalize(text)))
+	return hex.EncodeToString(sum[:])
+}
+
+// AudioURL returns the URL of the clip of text, synthesizing and storing it
+// unless it is already cached
+func (s *Service) AudioURL(ctx context.Context, text string, language string) (string, error) {
+	text = normalize(text)
+	if text == "" {
+		return "", errors.New("no text to synthesize")
+	}
+	if utf8.RuneCountInString(text) > MaxTextRunes {
+		return "", ErrTextTooLong
+	}
+
+	key := s.Key(text, language)
+	if s.store.Has(key) {
+		return URLPrefix + key, nil
+	}
+
+	audio, err := s.engine.Synthesize(ctx, text, language)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", s.engine.Name(), err)
+	}
+	if err := s.store.Put(key, audio); err != nil {
+		return "", fmt.Errorf("failed to store audio: %w", err)
+	}
+	return URLPrefix + key, nil
+}
+
+// Open returns a stored clip. It returns ErrNotFound for unknown or
+// malformed keys.
+func (s *Service) Open(key string) (io.ReadSeekCloser, error) {
+	return s.store.Open(key)
+}
+
+// normalize collapses whitespace so equivalent text shares a clip
+func normalize(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
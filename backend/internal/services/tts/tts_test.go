@@ -0,0 +1,155 @@
+package tts
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/BachirKhiati/lexia/internal/config"
+)
+
+// countingEngine returns the text it was given as audio and counts calls
+type countingEngine struct {
+	mu    sync.Mutex
+	calls int
+	fail  error
+}
+
+func (e *countingEngine) Name() string { return "counting" }
+
+func (e *countingEngine) Synthesize(ctx context.Context, text string, language string) ([]byte, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.calls++
+	if e.fail != nil {
+		return nil, e.fail
+	}
+	return []byte(language + ":" + text), nil
+}
+
+func newTestService(t *testing.T, engine Engine) *Service {
+	t.Helper()
+	store, err := NewDiskStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewService(engine, store)
+}
+
+func TestAudioURLSynthesizesOnce(t *testing.T) {
+	engine := &countingEngine{}
+	service := newTestService(t, engine)
+	ctx := context.Background()
+
+	url, err := service.AudioURL(ctx, "Hyvää huomenta", "finnish")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := strings.TrimPrefix(url, URLPrefix)
+	if !validKey.MatchString(key) {
+		t.Fatalf("url = %q", url)
+	}
+
+	// Equivalent text is served from the cache
+	again, err := service.AudioURL(ctx, "  Hyvää\nhuomenta ", "finnish")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != url || engine.calls != 1 {
+		t.Errorf("second url = %q after %d syntheses, want %q from the cache", again, engine.calls, url)
+	}
+
+	clip, err := service.Open(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clip.Close()
+	data, _ := io.ReadAll(clip)
+	if string(data) != "finnish:Hyvää huomenta" {
+		t.Errorf("clip = %q", data)
+	}
+}
+
+func TestKeyDependsOnLanguageAndEngine(t *testing.T) {
+	service := newTestService(t, &countingEngine{})
+	if service.Key("talo", "finnish") == service.Key("talo", "swedish") {
+		t.Error("languages share a key")
+	}
+	other := NewService(fixedNameEngine("other"), service.store)
+	if service.Key("talo", "finnish") == other.Key("talo", "finnish") {
+		t.Error("engines share a key")
+	}
+}
+
+type fixedNameEngine string
+
+func (e fixedNameEngine) Name() string { return string(e) }
+
+func (e fixedNameEngine) Synthesize(ctx context.Context, text string, language string) ([]byte, error) {
+	return []byte(text), nil
+}
+
+func TestAudioURLRejectsBadText(t *testing.T) {
+	engine := &countingEngine{}
+	service := newTestService(t, engine)
+	ctx := context.Background()
+
+	if _, err := service.AudioURL(ctx, "   ", "finnish"); err == nil {
+		t.Error("blank text accepted")
+	}
+	if _, err := service.AudioURL(ctx, strings.Repeat("a", MaxTextRunes+1), "finnish"); !errors.Is(err, ErrTextTooLong) {
+		t.Errorf("err = %v, want ErrTextTooLong", err)
+	}
+	if engine.calls != 0 {
+		t.Errorf("engine called %d times", engine.calls)
+	}
+}
+
+func TestFailedSynthesisIsNotCached(t *testing.T) {
+	engine := &countingEngine{fail: ErrUnsupportedLanguage}
+	service := newTestService(t, engine)
+
+	if _, err := service.AudioURL(context.Background(), "hej", "klingon"); !errors.Is(err, ErrUnsupportedLanguage) {
+		t.Fatalf("err = %v, want ErrUnsupportedLanguage", err)
+	}
+	if service.store.Has(service.Key("hej", "klingon")) {
+		t.Error("failed synthesis was stored")
+	}
+}
+
+func TestDiskStoreRejectsInvalidKeys(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewDiskStore(filepath.Join(dir, "audio"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "secret"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"../secret", "", "ABC", strings.Repeat("g", 64)} {
+		if _, err := store.Open(key); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Open(%q) err = %v, want ErrNotFound", key, err)
+		}
+		if err := store.Put(key, []byte("x")); err == nil {
+			t.Errorf("Put(%q) accepted", key)
+		}
+	}
+	if _, err := store.Open(strings.Repeat("a", 64)); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing clip err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestNewEngine(t *testing.T) {
+	if engine, err := NewEngine(config.TTSConfig{Engine: "none"}); engine != nil || err != nil {
+		t.Errorf("none = %v, %v; want speech turned off", engine, err)
+	}
+	if _, err := NewEngine(config.TTSConfig{Engine: "polly"}); err == nil || !strings.Contains(err.Error(), "espeak") {
+		t.Errorf("unknown engine err = %v, want the available engines listed", err)
+	}
+}
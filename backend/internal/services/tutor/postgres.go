@@ -0,0 +1,188 @@
+package tutor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/BachirKhiati/lexia/internal/models"
+)
+
+// PostgresStore keeps sessions in the tutor_sessions and tutor_messages tables
+type PostgresStore struct {
+	db *sql.DB
+}
+
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+func (s *PostgresStore) CreateSession(ctx context.Context, session *models.TutorSession) error {
+	return s.db.QueryRowContext(ctx, `
+		INSERT INTO tutor_sessions (user_id, language, level, quest_id, article_id, title)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at
+	`, session.UserID, session.Language, session.Level, session.QuestID, session.ArticleID, session.Title).Scan(
+		&session.ID, &session.CreatedAt, &session.UpdatedAt,
+	)
+}
+
+func (s *PostgresStore) DeleteSession(ctx context.Context, sessionID int) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM tutor_sessions WHERE id = $1`, sessionID)
+	return err
+}
+
+const sessionColumns = `
+	s.id, s.user_id, s.language, s.level, s.quest_id, s.article_id, s.title, s.summary,
+	s.summarized_through, s.created_at, s.updated_at,
+	(SELECT COUNT(*) FROM tutor_messages m WHERE m.session_id = s.id)
+`
+
+func scanSession(row interface{ Scan(...any) error }) (*models.TutorSession, error) {
+	var session models.TutorSession
+	var questID, articleID sql.NullInt64
+	err := row.Scan(
+		&session.ID, &session.UserID, &session.Language, &session.Level, &questID, &articleID, &session.Title, &session.Summary,
+		&session.SummarizedThrough, &session.CreatedAt, &session.UpdatedAt, &session.MessageCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if questID.Valid {
+		id := int(questID.Int64)
+		session.QuestID = &id
+	}
+	if articleID.Valid {
+		id := int(articleID.Int64)
+		session.ArticleID = &id
+	}
+	return &session, nil
+}
+
+func (s *PostgresStore) GetSession(ctx context.Context, userID, sessionID int) (*models.TutorSession, error) {
+	session, err := scanSession(s.db.QueryRowContext(ctx, `
+		SELECT `+sessionColumns+`
+		FROM tutor_sessions s
+		WHERE s.id = $1 AND s.user_id = $2
+	`, sessionID, userID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSessionNotFound
+	}
+	return session, err
+}
+
+// maxListLimit bounds the sessions one listing returns
+const maxListLimit = 200
+
+func (s *PostgresStore) ListSessions(ctx context.Context, userID int, filter ListFilter) ([]models.TutorSession, error) {
+	limit := filter.Limit
+	switch {
+	case limit <= 0:
+		limit = 50
+	case limit > maxListLimit:
+		limit = maxListLimit
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+sessionColumns+`
+		FROM tutor_sessions s
+		WHERE s.user_id = $1
+			AND ($2 = 0 OR s.quest_id = $2)
+			AND ($3 = 0 OR s.article_id = $3)
+		ORDER BY s.updated_at DESC
+		LIMIT $4
+	`, userID, filter.QuestID, filter.ArticleID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []models.TutorSession{}
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, *session)
+	}
+	return sessions, rows.Err()
+}
+
+func (s *PostgresStore) Messages(ctx context.Context, sessionID, afterID int) ([]models.TutorMessage, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, session_id, role, content, created_at
+		FROM tutor_messages
+		WHERE session_id = $1 AND id > $2
+		ORDER BY id
+	`, sessionID, afterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []models.TutorMessage
+	for rows.Next() {
+		var m models.TutorMessage
+		if err := rows.Scan(&m.ID, &m.SessionID, &m.Role, &m.Content, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		messages = append(messages, m)
+	}
+	return messages, rows.Err()
+}
+
+func (s *PostgresStore) AddMessages(ctx context.Context, sessionID int, messages []*models.TutorMessage) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, m := range messages {
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO tutor_messages (session_id, role, content)
+			VALUES ($1, $2, $3)
+			RETURNING id, created_at
+		`, sessionID, m.Role, m.Content).Scan(&m.ID, &m.CreatedAt)
+		if err != nil {
+			return err
+		}
+		m.SessionID = sessionID
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE tutor_sessions SET updated_at = NOW() WHERE id = $1`, sessionID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *PostgresStore) SaveSummary(ctx context.Context, sessionID int, summary string, throughID int) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE tutor_sessions
+		SET summary = $2, summarized_through = $3
+		WHERE id = $1
+	`, sessionID, summary, throughID)
+	return err
+}
+
+func (s *PostgresStore) QuestText(ctx context.Context, userID, questID int) (string, string, error) {
+	var title, description string
+	err := s.db.QueryRowContext(ctx, `
+		SELECT title, description FROM quests WHERE id = $1 AND user_id = $2
+	`, questID, userID).Scan(&title, &description)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", "", ErrContextNotFound
+	}
+	return title, description, err
+}
+
+func (s *PostgresStore) ArticleText(ctx context.Context, userID, articleID int) (string, string, error) {
+	var title, content string
+	err := s.db.QueryRowContext(ctx, `
+		SELECT title, content FROM articles WHERE id = $1 AND user_id = $2
+	`, articleID, userID).Scan(&title, &content)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", "", ErrContextNotFound
+	}
+	return title, content, err
+}
@@ -0,0 +1,274 @@
+package tutor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/BachirKhiati/lexia/internal/models"
+	"github.com/BachirKhiati/lexia/internal/services/ai"
+)
+
+var (
+	// ErrSessionNotFound is returned for sessions that do not exist or belong
+	// to another user
+	ErrSessionNotFound = errors.New("tutor session not found")
+	// ErrContextNotFound is returned when the quest or article to discuss
+	// does not exist or belongs to another user
+	ErrContextNotFound = errors.New("quest or article not found")
+	// ErrEmptyMessage is returned for blank learner messages
+	ErrEmptyMessage = errors.New("message is empty")
+)
+
+const (
+	defaultLanguage = "finnish"
+	defaultLevel    = "beginner"
+	// summarizeAfter is how many unsummarized messages are sent verbatim
+	// before the older ones are folded into the session summary
+	summarizeAfter = 20
+	// keepRecent is how many of the latest messages stay verbatim after
+	// summarizing
+	keepRecent = 8
+	// maxTitleRunes bounds titles derived from the first message
+	maxTitleRunes = 60
+)
+
+// AIService is the part of the AI service the tutor needs
+type AIService interface {
+	Tutor(ctx context.Context, tc ai.TutorContext, messages []ai.Message) (string, error)
+	SummarizeConversation(ctx context.Context, language string, summary string, messages []ai.Message) (string, error)
+}
+
+// Store persists tutor sessions and messages
+type Store interface {
+	CreateSession(ctx context.Context, session *models.TutorSession) error
+	DeleteSession(ctx context.Context, sessionID int) error
+	// GetSession returns ErrSessionNotFound unless the session belongs to userID
+	GetSession(ctx context.Context, userID, sessionID int) (*models.TutorSession, error)
+	ListSessions(ctx context.Context, userID int, filter ListFilter) ([]models.TutorSession, error)
+	// Messages returns the session's messages with an ID above afterID, oldest first
+	Messages(ctx context.Context, sessionID, afterID int) ([]models.TutorMessage, error)
+	AddMessages(ctx context.Context, sessionID int, messages []*models.TutorMessage) error
+	SaveSummary(ctx context.Context, sessionID int, summary string, throughID int) error
+	// QuestText and ArticleText return ErrContextNotFound unless the item belongs to userID
+	QuestText(ctx context.Context, userID, questID int) (title, description string, err error)
+	ArticleText(ctx context.Context, userID, articleID int) (title, content string, err error)
+}
+
+// ListFilter narrows a session listing. Zero fields match everything.
+type ListFilter struct {
+	QuestID   int
+	ArticleID int
+	Limit     int
+}
+
+// Service runs Socratic tutor conversations
+type Service struct {
+	store Store
+	ai    AIService
+}
+
+func NewService(store Store, aiService AIService) *Service {
+	return &Service{store: store, ai: aiService}
+}
+
+// StartSession creates a session and, when req.Message is set, answers it.
+// If the tutor cannot answer the session is removed again, so that failed
+// starts do not leave empty sessions behind.
+func (s *Service) StartSession(ctx context.Context, userID int, req models.CreateTutorSessionRequest) (*models.TutorSession, error) {
+	session := &models.TutorSession{
+		UserID:    userID,
+		Language:  req.Language,
+		Level:     req.Level,
+		QuestID:   req.QuestID,
+		ArticleID: req.ArticleID,
+	}
+	if session.Language == "" {
+		session.Language = defaultLanguage
+	}
+	if session.Level == "" {
+		session.Level = defaultLevel
+	}
+
+	// Check ownership up front and name the session after what it discusses
+	tc, err := s.tutorContext(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case tc.questTitle != "":
+		session.Title = tc.questTitle
+	case tc.articleTitle != "":
+		session.Title = tc.articleTitle
+	case strings.TrimSpace(req.Message) != "":
+		session.Title = titleFrom(req.Message)
+	default:
+		session.Title = "Tutor session"
+	}
+
+	if err := s.store.CreateSession(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to create tutor session: %w", err)
+	}
+
+	if strings.TrimSpace(req.Message) != "" {
+		turn, err := s.reply(ctx, session, tc.TutorContext, req.Message)
+		if err != nil {
+			if err := s.store.DeleteSession(context.WithoutCancel(ctx), session.ID); err != nil {
+				log.Printf("⚠️  Failed to remove unanswered tutor session %d: %v", session.ID, err)
+			}
+			return nil, err
+		}
+		session.Messages = []models.TutorMessage{turn.Message, turn.Reply}
+		session.MessageCount = 2
+	}
+	return session, nil
+}
+
+// Continue adds a learner message to a session and returns the tutor's reply
+func (s *Service) Continue(ctx context.Context, userID, sessionID int, message string) (*models.TutorTurn, error) {
+	if strings.TrimSpace(message) == "" {
+		return nil, ErrEmptyMessage
+	}
+
+	session, err := s.store.GetSession(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	tc, err := s.tutorContext(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	return s.reply(ctx, session, tc.TutorContext, message)
+}
+
+// GetSession returns a session with all of its messages
+func (s *Service) GetSession(ctx context.Context, userID, sessionID int) (*models.TutorSession, error) {
+	session, err := s.store.GetSession(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	session.Messages, err = s.store.Messages(ctx, session.ID, 0)
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// ListSessions returns a user's sessions, most recently active first
+func (s *Service) ListSessions(ctx context.Context, userID int, filter ListFilter) ([]models.TutorSession, error) {
+	return s.store.ListSessions(ctx, userID, filter)
+}
+
+// reply sends the recent history plus message to the tutor. Both messages
+// are stored only once the tutor answered, so the history always alternates.
+func (s *Service) reply(ctx context.Context, session *models.TutorSession, tc ai.TutorContext, message string) (*models.TutorTurn, error) {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return nil, ErrEmptyMessage
+	}
+
+	history, err := s.store.Messages(ctx, session.ID, session.SummarizedThrough)
+	if err != nil {
+		return nil, err
+	}
+	history = s.compact(ctx, session, history)
+	tc.Summary = session.Summary
+
+	messages := make([]ai.Message, 0, len(history)+1)
+	for _, m := range history {
+		messages = append(messages, ai.Message{Role: m.Role, Content: m.Content})
+	}
+	messages = append(messages, ai.Message{Role: ai.RoleUser, Content: message})
+
+	text, err := s.ai.Tutor(ctx, tc, messages)
+	if err != nil {
+		return nil, err
+	}
+
+	turn := &models.TutorTurn{
+		Message: models.TutorMessage{SessionID: session.ID, Role: ai.RoleUser, Content: message},
+		Reply:   models.TutorMessage{SessionID: session.ID, Role: ai.RoleAssistant, Content: text},
+	}
+	if err := s.store.AddMessages(ctx, session.ID, []*models.TutorMessage{&turn.Message, &turn.Reply}); err != nil {
+		return nil, fmt.Errorf("failed to save tutor messages: %w", err)
+	}
+	return turn, nil
+}
+
+// compact folds all but the latest messages into the session summary once
+// the history gets long. If summarizing fails the full history is used.
+func (s *Service) compact(ctx context.Context, session *models.TutorSession, history []models.TutorMessage) []models.TutorMessage {
+	if len(history) < summarizeAfter {
+		return history
+	}
+
+	// Keep the verbatim part starting with a learner message
+	cut := len(history) - keepRecent
+	for cut < len(history) && history[cut].Role != ai.RoleUser {
+		cut++
+	}
+	older := make([]ai.Message, 0, cut)
+	for _, m := range history[:cut] {
+		older = append(older, ai.Message{Role: m.Role, Content: m.Content})
+	}
+
+	summary, err := s.ai.SummarizeConversation(ctx, session.Language, session.Summary, older)
+	if err != nil {
+		log.Printf("⚠️  Failed to summarize tutor session %d: %v", session.ID, err)
+		return history
+	}
+	through := history[cut-1].ID
+	if err := s.store.SaveSummary(ctx, session.ID, summary, through); err != nil {
+		log.Printf("⚠️  Failed to save summary of tutor session %d: %v", session.ID, err)
+		return history
+	}
+	session.Summary = summary
+	session.SummarizedThrough = through
+	return history[cut:]
+}
+
+// sessionContext is the tutor context plus the titles of what is discussed
+type sessionContext struct {
+	ai.TutorContext
+	questTitle   string
+	articleTitle string
+}
+
+func (s *Service) tutorContext(ctx context.Context, session *models.TutorSession) (*sessionContext, error) {
+	tc := &sessionContext{TutorContext: ai.TutorContext{
+		Language: session.Language,
+		Level:    session.Level,
+		Summary:  session.Summary,
+	}}
+
+	if session.QuestID != nil {
+		title, description, err := s.store.QuestText(ctx, session.UserID, *session.QuestID)
+		if err != nil {
+			return nil, err
+		}
+		tc.questTitle = title
+		tc.Quest = description
+	}
+	if session.ArticleID != nil {
+		title, content, err := s.store.ArticleText(ctx, session.UserID, *session.ArticleID)
+		if err != nil {
+			return nil, err
+		}
+		tc.articleTitle = title
+		tc.Article = content
+	}
+	return tc, nil
+}
+
+// titleFrom shortens a first message into a session title
+func titleFrom(message string) string {
+	title := strings.Join(strings.Fields(message), " ")
+	if utf8.RuneCountInString(title) <= maxTitleRunes {
+		return title
+	}
+	runes := []rune(title)
+	return strings.TrimSpace(string(runes[:maxTitleRunes])) + "…"
+}
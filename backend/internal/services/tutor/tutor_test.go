@@ -0,0 +1,232 @@
+package tutor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BachirKhiati/lexia/internal/models"
+	"github.com/BachirKhiati/lexia/internal/services/ai"
+)
+
+// memoryStore is an in-memory Store for tests
+type memoryStore struct {
+	sessions map[int]*models.TutorSession
+	messages []models.TutorMessage
+	quests   map[int]string
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		sessions: make(map[int]*models.TutorSession),
+		quests:   map[int]string{7: "Describe your morning"},
+	}
+}
+
+func (m *memoryStore) CreateSession(ctx context.Context, session *models.TutorSession) error {
+	session.ID = len(m.sessions) + 1
+	session.CreatedAt = time.Now()
+	session.UpdatedAt = session.CreatedAt
+	stored := *session
+	m.sessions[session.ID] = &stored
+	return nil
+}
+
+func (m *memoryStore) DeleteSession(ctx context.Context, sessionID int) error {
+	delete(m.sessions, sessionID)
+	return nil
+}
+
+func (m *memoryStore) GetSession(ctx context.Context, userID, sessionID int) (*models.TutorSession, error) {
+	session, ok := m.sessions[sessionID]
+	if !ok || session.UserID != userID {
+		return nil, ErrSessionNotFound
+	}
+	copy := *session
+	return &copy, nil
+}
+
+func (m *memoryStore) ListSessions(ctx context.Context, userID int, filter ListFilter) ([]models.TutorSession, error) {
+	var sessions []models.TutorSession
+	for _, s := range m.sessions {
+		if s.UserID == userID {
+			sessions = append(sessions, *s)
+		}
+	}
+	return sessions, nil
+}
+
+func (m *memoryStore) Messages(ctx context.Context, sessionID, afterID int) ([]models.TutorMessage, error) {
+	var messages []models.TutorMessage
+	for _, msg := range m.messages {
+		if msg.SessionID == sessionID && msg.ID > afterID {
+			messages = append(messages, msg)
+		}
+	}
+	return messages, nil
+}
+
+func (m *memoryStore) AddMessages(ctx context.Context, sessionID int, messages []*models.TutorMessage) error {
+	for _, msg := range messages {
+		msg.ID = len(m.messages) + 1
+		msg.SessionID = sessionID
+		m.messages = append(m.messages, *msg)
+	}
+	return nil
+}
+
+func (m *memoryStore) SaveSummary(ctx context.Context, sessionID int, summary string, throughID int) error {
+	m.sessions[sessionID].Summary = summary
+	m.sessions[sessionID].SummarizedThrough = throughID
+	return nil
+}
+
+func (m *memoryStore) QuestText(ctx context.Context, userID, questID int) (string, string, error) {
+	description, ok := m.quests[questID]
+	if !ok || userID != 1 {
+		return "", "", ErrContextNotFound
+	}
+	return "Aamu", description, nil
+}
+
+func (m *memoryStore) ArticleText(ctx context.Context, userID, articleID int) (string, string, error) {
+	return "", "", ErrContextNotFound
+}
+
+func newTestService(t *testing.T, fake *ai.FakeProvider) (*Service, *memoryStore) {
+	t.Helper()
+	aiService, err := ai.NewFakeService(fake)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := newMemoryStore()
+	return NewService(store, aiService), store
+}
+
+func TestStartSessionWithQuest(t *testing.T) {
+	fake := ai.NewFakeProvider("fake").Script(ai.TaskTutor, ai.Reply("Mitä teet ensin aamulla?"))
+	service, _ := newTestService(t, fake)
+	questID := 7
+
+	session, err := service.StartSession(context.Background(), 1, models.CreateTutorSessionRequest{
+		Language: "finnish",
+		QuestID:  &questID,
+		Message:  "Miten aloitan?",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if session.Title != "Aamu" || session.Level != "beginner" || len(session.Messages) != 2 {
+		t.Fatalf("session = %+v", session)
+	}
+	if session.Messages[1].Content != "Mitä teet ensin aamulla?" {
+		t.Errorf("reply = %q", session.Messages[1].Content)
+	}
+
+	prompt := fake.Calls()[0].Prompt
+	if !strings.Contains(prompt, "<quest>\nDescribe your morning\n</quest>") || !strings.HasSuffix(prompt, "Miten aloitan?") {
+		t.Errorf("quest or message missing from prompt: %q", prompt)
+	}
+}
+
+func TestStartSessionRejectsForeignQuest(t *testing.T) {
+	service, _ := newTestService(t, ai.NewFakeProvider("fake"))
+	questID := 7
+
+	_, err := service.StartSession(context.Background(), 2, models.CreateTutorSessionRequest{Language: "finnish", QuestID: &questID})
+	if !errors.Is(err, ErrContextNotFound) {
+		t.Errorf("err = %v, want ErrContextNotFound", err)
+	}
+}
+
+func TestStartSessionRemovedWhenTutorFails(t *testing.T) {
+	service, store := newTestService(t, ai.NewFakeProvider("fake"))
+
+	_, err := service.StartSession(context.Background(), 1, models.CreateTutorSessionRequest{Language: "finnish", Message: "Hei"})
+	if err == nil {
+		t.Fatal("expected the unscripted tutor to fail")
+	}
+	if len(store.sessions) != 0 {
+		t.Errorf("failed start left sessions %+v", store.sessions)
+	}
+}
+
+func TestContinueSendsHistory(t *testing.T) {
+	fake := ai.NewFakeProvider("fake").Script(ai.TaskTutor, ai.Reply("Hyvä. Entä sitten?"), ai.Reply("Kerro lisää!"))
+	service, _ := newTestService(t, fake)
+	ctx := context.Background()
+
+	session, err := service.StartSession(ctx, 1, models.CreateTutorSessionRequest{Language: "finnish", Message: "Herään kuudelta."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := service.Continue(ctx, 2, session.ID, "Hei"); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("other user's session: err = %v", err)
+	}
+
+	turn, err := service.Continue(ctx, 1, session.ID, "Juon kahvia.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if turn.Reply.Content != "Kerro lisää!" {
+		t.Errorf("reply = %q", turn.Reply.Content)
+	}
+
+	prompt := fake.Calls()[1].Prompt
+	want := "Student: Herään kuudelta.\nTutor: Hyvä. Entä sitten?\nStudent: Juon kahvia.\n"
+	if !strings.Contains(prompt, want) {
+		t.Errorf("history missing from prompt: %q", prompt)
+	}
+}
+
+func TestLongConversationIsSummarized(t *testing.T) {
+	fake := ai.NewFakeProvider("fake").Script(ai.TaskTutor, ai.Reply("Jatka."))
+	service, store := newTestService(t, fake)
+	ctx := context.Background()
+
+	session, err := service.StartSession(ctx, 1, models.CreateTutorSessionRequest{Language: "finnish"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < summarizeAfter/2; i++ {
+		if _, err := service.Continue(ctx, 1, session.ID, fmt.Sprintf("Lause %d.", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The next turn folds the older messages into a summary first
+	fake.Script(ai.TaskTutor, ai.Reply("The student wrote numbered sentences."), ai.Reply("Hienoa!"))
+	if _, err := service.Continue(ctx, 1, session.ID, "Viimeinen lause."); err != nil {
+		t.Fatal(err)
+	}
+
+	stored := store.sessions[session.ID]
+	if stored.Summary != "The student wrote numbered sentences." {
+		t.Fatalf("summary = %q", stored.Summary)
+	}
+	if want := summarizeAfter - keepRecent; stored.SummarizedThrough != want {
+		t.Errorf("summarized through %d, want %d", stored.SummarizedThrough, want)
+	}
+
+	calls := fake.Calls()
+	last := calls[len(calls)-1].Prompt
+	if !strings.Contains(last, "The student wrote numbered sentences.") {
+		t.Errorf("summary missing from tutor prompt: %q", last)
+	}
+	if strings.Contains(last, "Lause 0.") || !strings.Contains(last, "Lause 9.") {
+		t.Errorf("prompt should hold only recent messages: %q", last)
+	}
+}
+
+func TestTitleFrom(t *testing.T) {
+	if got := titleFrom("  Miten   sanon\n kiitos? "); got != "Miten sanon kiitos?" {
+		t.Errorf("titleFrom = %q", got)
+	}
+	long := strings.Repeat("ä", maxTitleRunes+5)
+	if got := titleFrom(long); got != strings.Repeat("ä", maxTitleRunes)+"…" {
+		t.Errorf("titleFrom(long) = %q", got)
+	}
+}
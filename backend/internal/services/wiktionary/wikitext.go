@@ -0,0 +1,109 @@
+package wiktionary
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// getWikitextDefinition fetches the source of a word's page and parses the
+// definitions out of the edition's language section
+func (s *Service) getWikitextDefinition(ctx context.Context, word string, edition Edition) (*WiktionaryResponse, error) {
+	var page struct {
+		Source string `json:"source"`
+	}
+	if err := s.fetch(ctx, s.pageURL(edition.Code, word), &page); err != nil {
+		return nil, err
+	}
+
+	result := &WiktionaryResponse{
+		Word:        word,
+		Definitions: parseWikitext(page.Source, edition),
+	}
+	if len(result.Definitions) == 0 {
+		return nil, fmt.Errorf("no definitions found")
+	}
+	return result, nil
+}
+
+var wikitextHeading = regexp.MustCompile(`^(={2,6})\s*(.*?)\s*={2,6}$`)
+
+// parseWikitext reads the definitions of a page source. The language is a
+// level-2 heading (==Svenska==) and its parts of speech deeper headings
+// (===Substantiv===). Under those, "#" lines are definitions and "#:" lines
+// examples; quotations and other lists are skipped.
+func parseWikitext(source string, edition Edition) []Definition {
+	var (
+		definitions []Definition
+		current     *Definition
+		inLanguage  bool
+	)
+	for _, line := range strings.Split(source, "\n") {
+		line = strings.TrimSpace(line)
+
+		if m := wikitextHeading.FindStringSubmatch(line); m != nil {
+			current = nil
+			if len(m[1]) == 2 {
+				inLanguage = len(edition.Sections) == 0 || slices.Contains(edition.Sections, m[2])
+				continue
+			}
+			if partOfSpeech, ok := edition.partOfSpeech(m[2]); ok && inLanguage {
+				definitions = append(definitions, Definition{
+					PartOfSpeech: partOfSpeech,
+					Definitions:  []string{},
+					Examples:     []string{},
+				})
+				current = &definitions[len(definitions)-1]
+			}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "#:"):
+			if example := plainWikitext(strings.TrimLeft(line, "#:")); example != "" {
+				current.Examples = append(current.Examples, example)
+			}
+		case strings.HasPrefix(line, "#*"), strings.HasPrefix(line, "##"):
+		case strings.HasPrefix(line, "#"):
+			if definition := plainWikitext(line[1:]); definition != "" {
+				current.Definitions = append(current.Definitions, definition)
+			}
+		}
+	}
+
+	// Headings whose definitions were all templates say nothing
+	kept := definitions[:0]
+	for _, d := range definitions {
+		if len(d.Definitions) > 0 {
+			kept = append(kept, d)
+		}
+	}
+	return kept
+}
+
+var (
+	wikitextRef       = regexp.MustCompile(`(?s)<ref[^>/]*>.*?</ref>|<ref[^>]*/>`)
+	wikitextTemplate  = regexp.MustCompile(`\{\{[^{}]*\}\}`)
+	wikitextLink      = regexp.MustCompile(`\[\[(?:[^\[\]|]*\|)?([^\[\]|]*)\]\]`)
+	wikitextEmphasis  = regexp.MustCompile(`'{2,}`)
+	wikitextLeftovers = regexp.MustCompile(`^[\s,;:.]+`)
+)
+
+// plainWikitext turns a line of wikitext into plain text: links keep their
+// label, templates (labels, inflection tables) are dropped along with
+// references and emphasis
+func plainWikitext(s string) string {
+	s = wikitextRef.ReplaceAllString(s, "")
+	// Templates nest, so strip the innermost ones until none are left
+	for wikitextTemplate.MatchString(s) {
+		s = wikitextTemplate.ReplaceAllString(s, "")
+	}
+	s = wikitextLink.ReplaceAllString(s, "$1")
+	s = wikitextEmphasis.ReplaceAllString(s, "")
+	return wikitextLeftovers.ReplaceAllString(stripHTML(s), "")
+}
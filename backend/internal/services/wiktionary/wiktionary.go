@@ -4,14 +4,44 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"html"
 	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
+	"unicode"
 )
 
 // Service handles Wiktionary API requests
 type Service struct {
 	client  *http.Client
-	baseURL string
+	baseURL string // when set, used for every edition
+}
+
+// Edition says where the entries of a language are looked up: the code of
+// the Wiktionary edition that is queried, and the keys of the language's
+// sections in its REST API responses. No sections keeps every section.
+// Editions without the REST definitions are read from the wikitext of
+// their pages, where the section is the language's level-2 heading.
+type Edition struct {
+	Code     string   // "fi" queries fi.wiktionary.org
+	Sections []string // "fi", "Finnish"
+	Wikitext bool     // parse page sources instead of the REST definitions
+	// PartsOfSpeech names the part-of-speech headings of the edition in
+	// English: "Substantiv" → "Noun". When set, other headings are skipped.
+	PartsOfSpeech map[string]string
+}
+
+// partOfSpeech returns the English name of a part-of-speech heading, and
+// false if the heading is not a part of speech
+func (e Edition) partOfSpeech(heading string) (string, bool) {
+	if len(e.PartsOfSpeech) == 0 {
+		return heading, true
+	}
+	name, ok := e.PartsOfSpeech[heading]
+	return name, ok
 }
 
 // Definition represents a word definition from Wiktionary
@@ -33,41 +63,77 @@ func NewService() *Service {
 		client: &http.Client{
 			Timeout: 10 * time.Second,
 		},
-		baseURL: "https://en.wiktionary.org/api/rest_v1",
 	}
 }
 
-// GetDefinition fetches word definition from Wiktionary
-func (s *Service) GetDefinition(ctx context.Context, word, language string) (*WiktionaryResponse, error) {
-	// Use language-specific Wiktionary
-	baseURL := s.baseURL
-	if language == "finnish" {
-		baseURL = "https://fi.wiktionary.org/api/rest_v1"
+// NewServiceWithBaseURL creates a service that sends every edition to one
+// REST endpoint, e.g. a mirror or a test server
+func NewServiceWithBaseURL(baseURL string) *Service {
+	s := NewService()
+	s.baseURL = baseURL
+	return s
+}
+
+// editionURL returns the REST endpoint of an edition
+func (s *Service) editionURL(code string) string {
+	if s.baseURL != "" {
+		return s.baseURL
 	}
+	if code == "" {
+		code = "en"
+	}
+	return fmt.Sprintf("https://%s.wiktionary.org/api/rest_v1", code)
+}
 
-	url := fmt.Sprintf("%s/page/definition/%s", baseURL, word)
+// pageURL returns the endpoint of the wikitext source of a page
+func (s *Service) pageURL(code, word string) string {
+	if s.baseURL != "" {
+		return fmt.Sprintf("%s/page/%s", s.baseURL, url.PathEscape(word))
+	}
+	if code == "" {
+		code = "en"
+	}
+	return fmt.Sprintf("https://%s.wiktionary.org/w/rest.php/v1/page/%s", code, url.PathEscape(word))
+}
 
+// fetch decodes the JSON response of a Wiktionary endpoint into v
+func (s *Service) fetch(ctx context.Context, url string, v any) error {
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("User-Agent", "Synapse/1.0 (Language Learning App)")
 
 	resp, err := s.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch from Wiktionary: %w", err)
+		return fmt.Errorf("failed to fetch from Wiktionary: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == 404 {
-		return nil, fmt.Errorf("word not found in Wiktionary")
+		return fmt.Errorf("word not found in Wiktionary")
 	}
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Wiktionary API returned status %d", resp.StatusCode)
+		return fmt.Errorf("Wiktionary API returned status %d", resp.StatusCode)
 	}
 
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// GetDefinition fetches word definition from Wiktionary
+func (s *Service) GetDefinition(ctx context.Context, word string, edition Edition) (*WiktionaryResponse, error) {
+	if edition.Wikitext {
+		return s.getWikitextDefinition(ctx, word, edition)
+	}
+
+	// Use language-specific Wiktionary
+	baseURL := s.editionURL(edition.Code)
+
 	// The actual response structure from Wiktionary REST API
 	var apiResp map[string][]struct {
 		PartOfSpeech string `json:"partOfSpeech"`
@@ -77,8 +143,8 @@ func (s *Service) GetDefinition(ctx context.Context, word, language string) (*Wi
 		} `json:"definitions"`
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := s.fetch(ctx, fmt.Sprintf("%s/page/definition/%s", baseURL, word), &apiResp); err != nil {
+		return nil, err
 	}
 
 	// Parse the response into our structure
@@ -87,16 +153,23 @@ func (s *Service) GetDefinition(ctx context.Context, word, language string) (*Wi
 		Definitions: []Definition{},
 	}
 
-	for lang, entries := range apiResp {
-		// We want the target language definitions
-		// For Finnish words, look for "fi" or "Finnish" sections
-		if language == "finnish" && (lang != "fi" && lang != "Finnish") {
-			continue
+	// We want the target language definitions, read in a fixed order so
+	// that equally ranked senses come back the same way every time
+	sections := edition.Sections
+	if len(sections) == 0 {
+		for lang := range apiResp {
+			sections = append(sections, lang)
 		}
-
-		for _, entry := range entries {
+		sort.Strings(sections)
+	}
+	for _, lang := range sections {
+		for _, entry := range apiResp[lang] {
+			partOfSpeech, ok := edition.partOfSpeech(entry.PartOfSpeech)
+			if !ok {
+				continue
+			}
 			def := Definition{
-				PartOfSpeech: entry.PartOfSpeech,
+				PartOfSpeech: partOfSpeech,
 				Definitions:  []string{},
 				Examples:     []string{},
 			}
@@ -117,35 +190,127 @@ func (s *Service) GetDefinition(ctx context.Context, word, language string) (*Wi
 	return result, nil
 }
 
-// ExtractBestDefinition extracts the most relevant definition
-func (s *Service) ExtractBestDefinition(word, language string) (definition string, partOfSpeech string, examples []string, err error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+// Sense is a single meaning of a word
+type Sense struct {
+	PartOfSpeech string   `json:"part_of_speech"`
+	Definition   string   `json:"definition"`
+	Examples     []string `json:"examples,omitempty"`
+}
 
-	resp, err := s.GetDefinition(ctx, word, language)
+// Senses returns every sense of a word as plain text, in Wiktionary order
+func (s *Service) Senses(ctx context.Context, word string, edition Edition) ([]Sense, error) {
+	resp, err := s.GetDefinition(ctx, word, edition)
 	if err != nil {
-		return "", "", nil, err
+		return nil, err
 	}
 
-	if len(resp.Definitions) == 0 {
-		return "", "", nil, fmt.Errorf("no definitions available")
+	var senses []Sense
+	for _, def := range resp.Definitions {
+		first := len(senses)
+		for _, d := range def.Definitions {
+			definition := stripHTML(d)
+			if definition == "" {
+				continue
+			}
+			senses = append(senses, Sense{PartOfSpeech: def.PartOfSpeech, Definition: definition})
+		}
+		// The API groups examples per part of speech; attach them to its first sense
+		if len(senses) > first {
+			for _, e := range def.Examples {
+				if e = stripHTML(e); e != "" {
+					senses[first].Examples = append(senses[first].Examples, e)
+				}
+			}
+		}
+	}
+
+	if len(senses) == 0 {
+		return nil, fmt.Errorf("no definitions found")
+	}
+	return senses, nil
+}
+
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// stripHTML turns a Wiktionary definition fragment into plain text
+func stripHTML(s string) string {
+	s = html.UnescapeString(htmlTag.ReplaceAllString(s, ""))
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// RankSenses orders senses by how well they fit the sentence the word was
+// found in, best first. A sense scores a point for every sentence word that
+// also occurs in its definition or examples; words match on a shared prefix
+// of at least four letters so inflected Finnish forms still count. Ties
+// keep Wiktionary order, so with no useful context the first sense wins.
+func RankSenses(senses []Sense, word, sentence string) []Sense {
+	ranked := append([]Sense(nil), senses...)
+	if len(ranked) < 2 || strings.TrimSpace(sentence) == "" {
+		return ranked
+	}
+
+	target := strings.ToLower(word)
+	var contextWords []string
+	for _, w := range words(sentence) {
+		if w != target {
+			contextWords = append(contextWords, w)
+		}
 	}
 
-	// Take the first definition as the primary one
-	firstDef := resp.Definitions[0]
-	partOfSpeech = firstDef.PartOfSpeech
+	scores := make(map[int]int, len(ranked))
+	for i, sense := range ranked {
+		text := sense.Definition + " " + strings.Join(sense.Examples, " ")
+		for _, c := range contextWords {
+			for _, w := range words(text) {
+				if sameStem(c, w) {
+					scores[i]++
+					break
+				}
+			}
+		}
+	}
 
-	// Get the first definition text
-	if len(firstDef.Definitions) > 0 {
-		definition = firstDef.Definitions[0]
+	order := make([]int, len(ranked))
+	for i := range order {
+		order[i] = i
 	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return scores[order[a]] > scores[order[b]]
+	})
 
-	// Get up to 2 examples
-	maxExamples := 2
-	if len(firstDef.Examples) < maxExamples {
-		maxExamples = len(firstDef.Examples)
+	result := make([]Sense, len(ranked))
+	for i, idx := range order {
+		result[i] = ranked[idx]
 	}
-	examples = firstDef.Examples[:maxExamples]
+	return result
+}
 
-	return definition, partOfSpeech, examples, nil
+// words splits text into lower-cased words, dropping short function words
+func words(text string) []string {
+	var result []string
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}) {
+		if len([]rune(w)) >= 3 {
+			result = append(result, w)
+		}
+	}
+	return result
+}
+
+// sameStem reports whether two words are equal or share a prefix of at
+// least four letters
+func sameStem(a, b string) bool {
+	if a == b {
+		return true
+	}
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < 4 || len(rb) < 4 {
+		return false
+	}
+	n := 0
+	for n < len(ra) && n < len(rb) && ra[n] == rb[n] {
+		n++
+	}
+	return n >= 4
 }
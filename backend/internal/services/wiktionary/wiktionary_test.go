@@ -0,0 +1,166 @@
+package wiktionary
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const kuusiResponse = `{
+  "fi": [
+    {
+      "partOfSpeech": "Numeral",
+      "definitions": [
+        {"definition": "<a href=\"/wiki/six\">six</a> (numero 6)", "examples": ["<b>kuusi</b> omenaa"]}
+      ]
+    },
+    {
+      "partOfSpeech": "Noun",
+      "definitions": [
+        {"definition": "kuusi, havupuu (<i>Picea abies</i>)", "examples": ["Metsässä kasvaa <b>kuusia</b> ja mäntyjä."]},
+        {"definition": ""}
+      ]
+    }
+  ],
+  "en": [
+    {"partOfSpeech": "Noun", "definitions": [{"definition": "ignored"}]}
+  ]
+}`
+
+func TestSenses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/page/definition/kuusi" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(kuusiResponse))
+	}))
+	defer server.Close()
+
+	senses, err := NewServiceWithBaseURL(server.URL).Senses(context.Background(), "kuusi", Edition{Code: "fi", Sections: []string{"fi", "Finnish"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(senses) != 2 {
+		t.Fatalf("senses = %+v", senses)
+	}
+	if got := senses[0]; got.PartOfSpeech != "Numeral" || got.Definition != "six (numero 6)" || len(got.Examples) != 1 || got.Examples[0] != "kuusi omenaa" {
+		t.Errorf("first sense = %+v", got)
+	}
+	if got := senses[1]; got.PartOfSpeech != "Noun" || got.Definition != "kuusi, havupuu (Picea abies)" {
+		t.Errorf("second sense = %+v", got)
+	}
+}
+
+func TestSensesFollowSectionOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"Finnish": [{"partOfSpeech": "Noun", "definitions": [{"definition": "from Finnish"}]}],
+			"fi": [{"partOfSpeech": "Noun", "definitions": [{"definition": "from fi"}]}],
+			"en": [{"partOfSpeech": "Noun", "definitions": [{"definition": "from en"}]}]}`))
+	}))
+	defer server.Close()
+	service := NewServiceWithBaseURL(server.URL)
+
+	for i := 0; i < 20; i++ {
+		senses, err := service.Senses(context.Background(), "talo", Edition{Code: "fi", Sections: []string{"fi", "Finnish"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(senses) != 2 || senses[0].Definition != "from fi" || senses[1].Definition != "from Finnish" {
+			t.Fatalf("senses = %+v, want fi before Finnish", senses)
+		}
+
+		// Without sections every language is read, by name
+		senses, err = service.Senses(context.Background(), "talo", Edition{Code: "fi"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(senses) != 3 || senses[0].Definition != "from Finnish" || senses[1].Definition != "from en" {
+			t.Fatalf("senses = %+v, want sorted by language", senses)
+		}
+	}
+}
+
+func TestRankSenses(t *testing.T) {
+	senses := []Sense{
+		{PartOfSpeech: "Numeral", Definition: "six", Examples: []string{"kuusi omenaa"}},
+		{PartOfSpeech: "Noun", Definition: "spruce, a conifer tree", Examples: []string{"Metsässä kasvaa kuusia ja mäntyjä."}},
+	}
+
+	ranked := RankSenses(senses, "kuusi", "Metsän reunalla kasvoi korkea kuusi.")
+	if ranked[0].PartOfSpeech != "Noun" {
+		t.Errorf("forest sentence ranked %s first", ranked[0].PartOfSpeech)
+	}
+
+	ranked = RankSenses(senses, "kuusi", "Ostin kuusi omenaa.")
+	if ranked[0].PartOfSpeech != "Numeral" {
+		t.Errorf("apples sentence ranked %s first", ranked[0].PartOfSpeech)
+	}
+
+	// Without context Wiktionary order is kept
+	ranked = RankSenses(senses, "kuusi", "")
+	if ranked[0].PartOfSpeech != "Numeral" || len(ranked) != 2 {
+		t.Errorf("no-context ranking = %+v", ranked)
+	}
+}
+
+const husSource = `{{sv-sub-ett|hus|huset|hus|husen}}
+==Svenska==
+===Substantiv===
+{{sv-subst-n-oförändrad}}
+'''hus''' {{n}}
+# [[byggnad]] avsedd för [[bostad|boende]] {{tagg|kat=byggnader}}
+#: ''Vi bor i ett '''hus''' på landet.''
+#* 1899: ''I det gamla huset...''
+# {{tagg|ålderdomligt}} [[släkt]], ätt<ref>SAOL</ref>
+====Översättningar====
+# ignored
+===Verb===
+# {{böjning|sv|verb|husera}}
+
+==Danska==
+===Substantiv===
+# ignored too
+`
+
+func TestSensesFromWikitext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/page/hus" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"title": "hus", "source": ` + strconv.Quote(husSource) + `}`))
+	}))
+	defer server.Close()
+
+	edition := Edition{
+		Code:          "sv",
+		Sections:      []string{"Svenska"},
+		Wikitext:      true,
+		PartsOfSpeech: map[string]string{"Substantiv": "Noun", "Verb": "Verb"},
+	}
+	senses, err := NewServiceWithBaseURL(server.URL).Senses(context.Background(), "hus", edition)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Sense{
+		{PartOfSpeech: "Noun", Definition: "byggnad avsedd för boende", Examples: []string{"Vi bor i ett hus på landet."}},
+		{PartOfSpeech: "Noun", Definition: "släkt, ätt"},
+	}
+	if len(senses) != len(want) {
+		t.Fatalf("senses = %+v", senses)
+	}
+	for i, w := range want {
+		got := senses[i]
+		if got.PartOfSpeech != w.PartOfSpeech || got.Definition != w.Definition || strings.Join(got.Examples, "|") != strings.Join(w.Examples, "|") {
+			t.Errorf("sense %d = %+v, want %+v", i, got, w)
+		}
+	}
+
+	if _, err := NewServiceWithBaseURL(server.URL).Senses(context.Background(), "saknas", edition); err == nil {
+		t.Error("missing page gave no error")
+	}
+}
@@ -112,8 +112,8 @@ describe('AnalyzerPopup', () => {
       part_of_speech: 'verb',
       examples: [],
       conjugations: [
-        { id: 1, word_id: 0, tense: 'present', person: '1sg', form: 'puhun', language: 'finnish' },
-        { id: 2, word_id: 0, tense: 'present', person: '2sg', form: 'puhut', language: 'finnish' },
+        { id: 1, word_id: 0, mood: 'indicative', tense: 'present', voice: 'active', polarity: 'affirmative', person: '1sg', form: 'puhun', language: 'finnish' },
+        { id: 2, word_id: 0, mood: 'indicative', tense: 'present', voice: 'active', polarity: 'affirmative', person: '2sg', form: 'puhut', language: 'finnish' },
       ],
       audio_url: '',
       in_synapse: false,
@@ -2,6 +2,13 @@ import { useState, useEffect } from 'react';
 import { analyzeWord, addWordToSynapse } from '../../services/api';
 import type { AnalyzerResponse } from '../../types';
 
+// Voices of the Web Speech API fallback, by learned language
+const speechLanguages: Record<string, string> = {
+  finnish: 'fi-FI',
+  swedish: 'sv-SE',
+  english: 'en-US',
+};
+
 interface WordAnalyzerProps {
   word: string;
   language: string;
@@ -45,7 +52,8 @@ const WordAnalyzer = ({ word, language, position, onClose, userId }: WordAnalyze
         analysis.definition,
         analysis.part_of_speech,
         analysis.examples,
-        language
+        language,
+        analysis.context
       );
       // Update local state to show it's in synapse
       setAnalysis({ ...analysis, in_synapse: true });
@@ -63,7 +71,7 @@ const WordAnalyzer = ({ word, language, position, onClose, userId }: WordAnalyze
     } else {
       // Use Web Speech API as fallback
       const utterance = new SpeechSynthesisUtterance(word);
-      utterance.lang = language === 'finnish' ? 'fi-FI' : 'en-US';
+      utterance.lang = speechLanguages[language] ?? 'en-US';
       window.speechSynthesis.speak(utterance);
     }
   };
@@ -121,6 +129,30 @@ const WordAnalyzer = ({ word, language, position, onClose, userId }: WordAnalyze
                   </>
                 )}
               </p>
+              {analysis.morphology && analysis.morphology.length > 0 && (
+                <p className="text-xs text-gray-500 mt-1">
+                  {[
+                    analysis.morphology[0].case,
+                    analysis.morphology[0].number,
+                    analysis.morphology[0].definiteness,
+                    analysis.morphology[0].gender,
+                    analysis.morphology[0].degree,
+                    analysis.morphology[0].mood,
+                    analysis.morphology[0].tense,
+                    analysis.morphology[0].person,
+                    analysis.morphology[0].possessive && `possessive ${analysis.morphology[0].possessive}`,
+                    ...(analysis.morphology[0].clitics ?? []).map((clitic) => `-${clitic}`),
+                  ]
+                    .filter(Boolean)
+                    .join(' • ')}
+                </p>
+              )}
+              {analysis.compound && analysis.compound.length > 0 && (
+                <p className="text-xs text-gray-500 mt-1">
+                  <span className="font-semibold">Compound:</span>{' '}
+                  {analysis.compound.map((part) => part.lemma).join(' + ')}
+                </p>
+              )}
             </div>
 
             {/* Definition */}
@@ -150,12 +182,68 @@ const WordAnalyzer = ({ word, language, position, onClose, userId }: WordAnalyze
               <div className="mb-4">
                 <h4 className="text-sm font-semibold text-gray-400 mb-2">Conjugation Map</h4>
                 <div className="bg-synapse-background rounded-lg p-3 space-y-1">
-                  {analysis.conjugations.slice(0, 6).map((conj, idx) => (
-                    <div key={idx} className="flex justify-between text-sm">
-                      <span className="text-gray-400">{conj.tense} ({conj.person})</span>
-                      <span className="text-white font-mono">{conj.form}</span>
-                    </div>
-                  ))}
+                  {analysis.conjugations
+                    .filter((conj) =>
+                      // Swedish verbs have no persons: show the principal parts instead
+                      analysis.conjugations?.every((c) => !c.person)
+                        ? conj.voice === 'active' &&
+                          (conj.mood === 'indicative' || conj.variant === 'supine') &&
+                          !conj.form.includes(' ')
+                        : conj.mood === 'indicative' &&
+                          conj.tense === 'present' &&
+                          conj.voice === 'active' &&
+                          conj.polarity === 'affirmative'
+                    )
+                    .map((conj, idx) => (
+                      <div key={idx} className="flex justify-between text-sm">
+                        <span className="text-gray-400">
+                          {conj.variant || conj.tense}
+                          {conj.person && ` (${conj.person})`}
+                        </span>
+                        <span className="text-white font-mono">{conj.form}</span>
+                      </div>
+                    ))}
+                </div>
+              </div>
+            )}
+
+            {/* Declensions */}
+            {analysis.declensions && analysis.declensions.length > 0 && (
+              <div className="mb-4">
+                <h4 className="text-sm font-semibold text-gray-400 mb-2">
+                  Declension Map{analysis.kotus_type ? ` (type ${analysis.kotus_type})` : ''}
+                  {analysis.gender && ` (${analysis.gender})`}
+                </h4>
+                <div className="bg-synapse-background rounded-lg p-3 space-y-1">
+                  {analysis.declensions.some((decl) => decl.degree)
+                    ? // Swedish adjectives agree and compare rather than decline
+                      analysis.declensions.map((decl, idx) => (
+                        <div key={idx} className="flex justify-between text-sm">
+                          <span className="text-gray-400">
+                            {[decl.degree, decl.gender, decl.number, decl.definiteness].filter(Boolean).join(' ')}
+                          </span>
+                          <span className="text-white font-mono">{decl.form}</span>
+                        </div>
+                      ))
+                    : analysis.declensions
+                        .filter((decl) => decl.number === 'plural')
+                        .map((plural, idx) => {
+                          const singular = analysis.declensions?.find(
+                            (decl) =>
+                              decl.case === plural.case &&
+                              decl.definiteness === plural.definiteness &&
+                              decl.number === 'singular'
+                          );
+                          return (
+                            <div key={idx} className="grid grid-cols-3 gap-2 text-sm">
+                              <span className="text-gray-400">
+                                {[plural.case, plural.definiteness].filter(Boolean).join(' ')}
+                              </span>
+                              <span className="text-white font-mono">{singular?.form ?? '—'}</span>
+                              <span className="text-white font-mono">{plural.form}</span>
+                            </div>
+                          );
+                        })}
                 </div>
               </div>
             )}
@@ -165,6 +165,7 @@ const Layout = ({ children }: LayoutProps) => {
           <p className="text-xs text-lexia-text-inverse/80 font-medium uppercase tracking-wide">Learning</p>
           <p className="text-xl font-bold text-lexia-text-inverse mt-1">
             {user?.language === 'finnish' && '🇫🇮 Finnish'}
+            {user?.language === 'swedish' && '🇸🇪 Swedish'}
             {user?.language === 'english' && '🇬🇧 English'}
             {user?.language === 'spanish' && '🇪🇸 Spanish'}
             {user?.language === 'french' && '🇫🇷 French'}
@@ -1,12 +1,31 @@
 import { useState } from 'react';
 import { useSpeechRecognition } from '../../hooks/useSpeechRecognition';
 import { useSpeechSynthesis } from '../../hooks/useSpeechSynthesis';
+import { submitOratorAttempt } from '../../services/api';
+import type { PronunciationIssue } from '../../types';
 
 interface PronunciationPracticeProps {
   targetWord: string;
   language: string;
 }
 
+const describeIssue = (issue: PronunciationIssue): string => {
+  switch (issue.kind) {
+    case 'vowel_length':
+      return `Vowel length: say "${issue.expected}", not "${issue.heard}"`;
+    case 'consonant_length':
+      return `Consonant length: say "${issue.expected}", not "${issue.heard}"`;
+    case 'vowel_quality':
+      return `Vowel: say "${issue.expected}", not "${issue.heard}"`;
+    case 'missing':
+      return `Missing sound: "${issue.expected}"`;
+    case 'extra':
+      return `Extra sound: "${issue.heard}"`;
+    default:
+      return `Say "${issue.expected}", not "${issue.heard}"`;
+  }
+};
+
 const PronunciationPractice = ({ targetWord, language }: PronunciationPracticeProps) => {
   const languageCode = language === 'finnish' ? 'fi-FI' : 'en-US';
 
@@ -14,6 +33,7 @@ const PronunciationPractice = ({ targetWord, language }: PronunciationPracticePr
   const { speak, speaking } = useSpeechSynthesis(languageCode);
 
   const [score, setScore] = useState<number | null>(null);
+  const [issues, setIssues] = useState<PronunciationIssue[]>([]);
 
   const handleListen = () => {
     speak(targetWord);
@@ -25,14 +45,26 @@ const PronunciationPractice = ({ targetWord, language }: PronunciationPracticePr
     } else {
       resetTranscript();
       setScore(null);
+      setIssues([]);
       startListening();
     }
   };
 
-  const checkPronunciation = () => {
+  const checkPronunciation = async () => {
     if (!transcript) return;
 
-    // Simple similarity check (in production, use more sophisticated comparison)
+    // The server scores Finnish sounds (vowel length, double consonants, ä/a)
+    // and schedules weak words for review
+    try {
+      const attempt = await submitOratorAttempt(targetWord, transcript, language);
+      setScore(attempt.score);
+      setIssues(attempt.words.flatMap((w) => w.issues));
+      return;
+    } catch {
+      // Fall back to a local comparison when offline
+    }
+
+    // Simple similarity check
     const normalizedTarget = targetWord.toLowerCase().trim();
     const normalizedTranscript = transcript.toLowerCase().trim();
 
@@ -161,6 +193,13 @@ const PronunciationPractice = ({ targetWord, language }: PronunciationPracticePr
           <p className="text-sm">
             {score >= 80 ? '🎉 Excellent!' : score >= 60 ? '👍 Good try!' : '💪 Keep practicing!'}
           </p>
+          {issues.length > 0 && (
+            <ul className="mt-3 text-sm text-left space-y-1">
+              {issues.map((issue, i) => (
+                <li key={i}>{describeIssue(issue)}</li>
+              ))}
+            </ul>
+          )}
         </div>
       )}
 
@@ -1,4 +1,4 @@
-import { useState } from 'react';
+import { Fragment, useState } from 'react';
 import HoverableText from '../components/Analyzer/HoverableText';
 import { useAuth } from '../contexts/AuthContext';
 import { useToast } from '../contexts/ToastContext';
@@ -10,7 +10,7 @@ const LensPage = () => {
   const language = user?.language || 'finnish';
   const toast = useToast();
   const [url, setUrl] = useState('');
-  const [article, setArticle] = useState<{ title: string; content: string } | null>(null);
+  const [article, setArticle] = useState<{ title: string; content: string; sentences?: string[] } | null>(null);
   const [loading, setLoading] = useState(false);
   const [error, setError] = useState('');
 
@@ -24,6 +24,7 @@ const LensPage = () => {
       setArticle({
         title: importedArticle.title,
         content: importedArticle.content,
+        sentences: importedArticle.sentences,
       });
       setUrl(''); // Clear URL input after successful import
       toast.success('Article Imported!', 'Click on any word to analyze it and add it to your vocabulary.');
@@ -92,7 +93,13 @@ const LensPage = () => {
             </div>
 
             <div className="bg-lexia-background rounded-lg p-6">
-              <HoverableText text={article.content} language={language} userId={userId} className="text-lexia-text leading-relaxed" />
+              <div className="text-lexia-text leading-relaxed">
+                {(article.sentences?.length ? article.sentences : [article.content]).map((sentence, idx) => (
+                  <Fragment key={idx}>
+                    <HoverableText text={sentence} language={language} userId={userId} className="inline" />{' '}
+                  </Fragment>
+                ))}
+              </div>
             </div>
 
             <div className="mt-4 p-3 bg-lexia-primary/20 rounded-lg border border-lexia-primary">
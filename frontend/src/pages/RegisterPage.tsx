@@ -147,10 +147,8 @@ const RegisterPage = () => {
                 className="select"
               >
                 <option value="finnish">🇫🇮 Finnish</option>
+                <option value="swedish">🇸🇪 Swedish</option>
                 <option value="english">🇬🇧 English</option>
-                <option value="spanish">🇪🇸 Spanish</option>
-                <option value="french">🇫🇷 French</option>
-                <option value="german">🇩🇪 German</option>
               </select>
             </div>
 
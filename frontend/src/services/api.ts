@@ -2,7 +2,11 @@ import axios from 'axios';
 import type {
   Quest,
   AnalyzerResponse,
+  BatchAnalyzeResponse,
   MindMapData,
+  OratorAttempt,
+  TranslateResponse,
+  TranslationEntry,
   QuestValidationRequest,
   QuestValidationResponse
 } from '../types';
@@ -49,6 +53,36 @@ export const analyzeWord = async (word: string, language: string, context?: stri
   return data;
 };
 
+export const analyzeText = async (text: string, language: string): Promise<BatchAnalyzeResponse> => {
+  const { data } = await api.post('/analyze/batch', { text, language });
+  return data;
+};
+
+// Translation API
+export const translateText = async (text: string, fromLanguage: string, toLanguage: string): Promise<TranslateResponse> => {
+  const { data } = await api.post('/translate', { text, from_language: fromLanguage, to_language: toLanguage });
+  return data;
+};
+
+export const searchTranslations = async (query: string, pinned = false): Promise<TranslationEntry[]> => {
+  const { data } = await api.get('/translations', { params: { q: query, pinned } });
+  return data;
+};
+
+export const updateTranslation = async (
+  id: number,
+  changes: { translation?: string; pinned?: boolean }
+): Promise<TranslationEntry> => {
+  const { data } = await api.put(`/translations/${id}`, changes);
+  return data;
+};
+
+// Orator API
+export const submitOratorAttempt = async (target: string, transcript: string, language: string): Promise<OratorAttempt> => {
+  const { data } = await api.post('/orator/attempts', { target, transcript, language });
+  return data;
+};
+
 // Quest API
 export const getUserQuests = async (userId: number): Promise<Quest[]> => {
   const { data } = await api.get(`/users/${userId}/quests`);
@@ -78,8 +112,9 @@ export const addWordToSynapse = async (
   definition: string,
   partOfSpeech: string,
   examples: string[],
-  language: string
-): Promise<{ id: number; status: string }> => {
+  language: string,
+  context?: string
+): Promise<{ id: number; status: string; linked_words?: number }> => {
   const { data } = await api.post(`/users/${userId}/synapse/words`, {
     word,
     lemma,
@@ -87,6 +122,7 @@ export const addWordToSynapse = async (
     part_of_speech: partOfSpeech,
     examples,
     language,
+    context,
   });
   return data;
 };
@@ -97,6 +133,7 @@ export interface Article {
   title: string;
   content: string;
   url: string;
+  sentences?: string[];
 }
 
 export const importArticle = async (url: string, language: string): Promise<Article> => {
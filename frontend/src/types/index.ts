@@ -36,12 +36,49 @@ export interface Word {
 export interface WordConjugation {
   id: number;
   word_id: number;
+  mood: 'indicative' | 'conditional' | 'imperative' | 'potential' | 'infinitive' | 'participle';
   tense: string;
+  voice: 'active' | 'passive';
+  polarity: 'affirmative' | 'negative';
   person: string;
+  variant?: string;
   form: string;
   language: string;
 }
 
+export interface WordDeclension {
+  case: string; // empty for Swedish adjectives
+  number: 'singular' | 'plural' | '';
+  definiteness?: 'indefinite' | 'definite';
+  gender?: 'common' | 'neuter';
+  degree?: 'positive' | 'comparative' | 'superlative';
+  form: string;
+}
+
+export interface MorphAnalysis {
+  lemma: string;
+  part_of_speech: string;
+  case?: string;
+  number?: 'singular' | 'plural';
+  mood?: string;
+  tense?: string;
+  voice?: string;
+  person?: string;
+  variant?: string;
+  definiteness?: string;
+  gender?: string;
+  degree?: string;
+  possessive?: string;
+  clitics?: string[];
+  guessed?: boolean;
+}
+
+// One of the words a compound is made of
+export interface CompoundPart {
+  form: string;
+  lemma: string;
+}
+
 export interface AnalyzerResponse {
   word: string;
   lemma: string;
@@ -49,8 +86,29 @@ export interface AnalyzerResponse {
   part_of_speech: string;
   examples: string[];
   conjugations?: WordConjugation[];
+  declensions?: WordDeclension[];
+  kotus_type?: number;
+  gender?: 'common' | 'neuter';
+  morphology?: MorphAnalysis[];
+  compound?: CompoundPart[];
   audio_url?: string;
+  example_audio_urls?: string[];
   in_synapse: boolean;
+  context?: string;
+  other_senses?: string[];
+}
+
+export interface BatchTokenResult {
+  token: string;
+  occurrences: number;
+  analysis?: AnalyzerResponse;
+  error?: string;
+}
+
+export interface BatchAnalyzeResponse {
+  language: string;
+  tokens: BatchTokenResult[];
+  failed: number;
 }
 
 export interface MindMapNode {
@@ -83,3 +141,47 @@ export interface QuestValidationResponse {
   feedback: string;
   new_words?: string[];
 }
+
+export interface PronunciationIssue {
+  kind: 'vowel_length' | 'consonant_length' | 'vowel_quality' | 'substitution' | 'missing' | 'extra';
+  expected?: string;
+  heard?: string;
+}
+
+export interface WordScore {
+  word: string;
+  word_id?: number;
+  score: number;
+  issues: PronunciationIssue[];
+  rescheduled?: boolean;
+}
+
+export interface OratorAttempt {
+  id: number;
+  language: string;
+  target: string;
+  transcript: string;
+  score: number;
+  words: WordScore[];
+  created_at: string;
+}
+
+export interface TranslationEntry {
+  id: number;
+  source_language: string;
+  target_language: string;
+  source_text: string;
+  translation: string;
+  machine_translation: string;
+  edited: boolean;
+  pinned: boolean;
+  use_count: number;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface TranslateResponse {
+  entry: TranslationEntry;
+  reused: boolean;
+  pinned_matches?: TranslationEntry[];
+}